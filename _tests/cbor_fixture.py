@@ -0,0 +1,23 @@
+#!/usr/bin/env python3
+# Prints the CBOR fixture used in cborenc/cborenc_test.go.
+#
+# Requires the "cbor2" package (pip install cbor2). It encodes the Hashed
+# object of the hash string below in the layout documented in the cborenc
+# package.
+import base64
+
+import cbor2
+
+# $argon2id$v=19$m=65536,t=3,p=2$Woo1mErn1s7AHf96ewQ8Uw$D4TzIwGO4XD2buk96qAP+Ed2baMo/KbTRMqXX00wtsU
+salt = base64.b64decode("Woo1mErn1s7AHf96ewQ8Uw==")
+hashed = base64.b64decode("D4TzIwGO4XD2buk96qAP+Ed2baMo/KbTRMqXX00wtsU=")
+
+print(cbor2.dumps({
+    1: "argon2id",
+    2: 19,
+    3: 65536,
+    4: 3,
+    5: 2,
+    6: salt,
+    7: hashed,
+}, canonical=True).hex())
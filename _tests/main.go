@@ -35,7 +35,8 @@ func main() {
 	}
 
 	// Password hashing
-	hashedObj := argonize.HashCustom([]byte(password), salt, param)
+	hashedObj, err := argonize.HashWithSalt([]byte(password), salt, param)
+	exitOnError(errors.Wrap(err, "failed to hash the password"))
 
 	//nolint:forbidigo // allow use of fmt
 	fmt.Println(hashedObj.String())
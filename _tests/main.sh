@@ -11,8 +11,8 @@
 myPassword="$(mkpasswd -m sha512crypt "${RANDOM}" | tr -d "$/.")"
 echo "Password: ${myPassword} (random)"
 
-# Generate a random salt
-salt="${RANDOM}${RANDOM}"
+# Generate a random salt of 10 digits, longer than the minimum of 8
+salt="$(printf '%05d%05d' "${RANDOM}" "${RANDOM}")"
 echo "Salt: ${salt} (random)"
 
 # Generate a hash using the C implementation
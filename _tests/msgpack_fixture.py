@@ -0,0 +1,23 @@
+#!/usr/bin/env python3
+# Prints the MessagePack fixture used in msgpackenc/msgpackenc_test.go.
+#
+# Requires the "msgpack" package (pip install msgpack). It encodes the Hashed
+# object of the hash string below in the layout documented in the msgpackenc
+# package.
+import base64
+
+import msgpack
+
+# $argon2id$v=19$m=65536,t=3,p=2$Woo1mErn1s7AHf96ewQ8Uw$D4TzIwGO4XD2buk96qAP+Ed2baMo/KbTRMqXX00wtsU
+salt = base64.b64decode("Woo1mErn1s7AHf96ewQ8Uw==")
+hashed = base64.b64decode("D4TzIwGO4XD2buk96qAP+Ed2baMo/KbTRMqXX00wtsU=")
+
+print(msgpack.packb({
+    "alg": "argon2id",
+    "v": 19,
+    "m": 65536,
+    "t": 3,
+    "p": 2,
+    "salt": salt,
+    "hash": hashed,
+}, use_bin_type=True).hex())
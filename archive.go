@@ -0,0 +1,273 @@
+package argonize
+
+import (
+	"bufio"
+	"encoding/binary"
+	"fmt"
+	"io"
+
+	"github.com/pkg/errors"
+)
+
+// ============================================================================
+//  Archive Format
+// ============================================================================
+//
+// The archive is a stream of the binary encoded Hashed records (see
+// Hashed.AppendBinary()) to move the credentials between systems. It has the
+// following big-endian layout:
+//
+//	| magic "ARGZ" (4) | format version (1) |
+//	| record length (4) | record (n) | record length (4) | record (n) | ...
+//
+// The records are read and written one by one, so that the memory use does not
+// depend on the number of the records.
+//
+// The records are the binary encoding of Hashed.AppendBinary(), with its own
+// version byte.
+
+// ArchiveVersion is the format version written in the header of the archive.
+const ArchiveVersion = uint8(1)
+
+const (
+	// archiveMagic is the magic number at the beginning of the archive.
+	archiveMagic = "ARGZ"
+	// lenArchiveHeader is the length of the header of the archive.
+	lenArchiveHeader = len(archiveMagic) + 1
+	// lenArchivePrefix is the length of the length prefix of a record.
+	lenArchivePrefix = 4
+	// archiveRecordMax is the maximum length of a record. It prevents a
+	// corrupted length prefix from allocating a huge buffer.
+	archiveRecordMax = 64 * 1024
+)
+
+// ============================================================================
+//  Type: Exporter
+// ============================================================================
+
+// Exporter writes the Hashed records to the archive stream.
+type Exporter struct {
+	w          io.Writer
+	buf        []byte
+	headerDone bool
+}
+
+// ----------------------------------------------------------------------------
+//  Constructor of Exporter
+// ----------------------------------------------------------------------------
+
+// NewExporter returns a new Exporter writing to w. The header of the archive is
+// written with the first record or by Close().
+func NewExporter(w io.Writer) *Exporter {
+	return &Exporter{w: w}
+}
+
+// ----------------------------------------------------------------------------
+//  Methods of Exporter
+// ----------------------------------------------------------------------------
+
+// Close writes the header of the archive if no record was written, so that an
+// empty archive is still a valid archive. It does not close the underlying
+// writer.
+func (e *Exporter) Close() error {
+	if e.headerDone {
+		return nil
+	}
+
+	return e.flush()
+}
+
+// Write writes the hash to the archive as a record. The hash must be binary
+// encodable, that is an argon2id hash without a key ID.
+func (e *Exporter) Write(h *Hashed) error {
+	if e.w == nil {
+		return errors.New("failed to export the hash: writer is nil")
+	}
+
+	if err := h.Validate(); err != nil {
+		return errors.Wrap(err, "failed to export the hash")
+	}
+
+	e.buf = e.buf[:0]
+	if !e.headerDone {
+		e.buf = appendArchiveHeader(e.buf)
+	}
+
+	// Reserve the length prefix and fill it after the record is appended
+	posPrefix := len(e.buf)
+	e.buf = append(e.buf, 0, 0, 0, 0)
+
+	out, err := h.AppendBinary(e.buf)
+	if err != nil {
+		return errors.Wrap(err, "failed to export the hash")
+	}
+
+	lenRecord := len(out) - posPrefix - lenArchivePrefix
+	if lenRecord > archiveRecordMax {
+		return errors.Errorf("failed to export the hash: record length %d exceeds %d", lenRecord, archiveRecordMax)
+	}
+
+	binary.BigEndian.PutUint32(out[posPrefix:], uint32(lenRecord)) //nolint:gosec // checked above
+
+	e.buf = out
+
+	return e.flush()
+}
+
+// ----------------------------------------------------------------------------
+//  Methods of Exporter (Private)
+// ----------------------------------------------------------------------------
+
+// flush writes the buffered header and record to the underlying writer.
+func (e *Exporter) flush() error {
+	if e.w == nil {
+		return errors.New("failed to export the hash: writer is nil")
+	}
+
+	if !e.headerDone && len(e.buf) == 0 {
+		e.buf = appendArchiveHeader(e.buf)
+	}
+
+	if _, err := e.w.Write(e.buf); err != nil {
+		return errors.Wrap(err, "failed to write the archive")
+	}
+
+	e.headerDone = true
+
+	return nil
+}
+
+// ============================================================================
+//  Type: Importer
+// ============================================================================
+
+// Importer reads the Hashed records from the archive stream written by
+// Exporter.
+type Importer struct {
+	r          *bufio.Reader
+	buf        []byte
+	numRecord  int
+	headerDone bool
+}
+
+// ----------------------------------------------------------------------------
+//  Constructor of Importer
+// ----------------------------------------------------------------------------
+
+// NewImporter returns a new Importer reading from r. The header of the archive
+// is read and checked by the first call of Next().
+func NewImporter(r io.Reader) *Importer {
+	if r == nil {
+		return &Importer{}
+	}
+
+	return &Importer{r: bufio.NewReader(r)}
+}
+
+// ----------------------------------------------------------------------------
+//  Methods of Importer
+// ----------------------------------------------------------------------------
+
+// Next reads and validates the next record of the archive. It returns io.EOF
+// at the end of the archive.
+//
+// If the archive ends in the middle of the header or a record, the error wraps
+// io.ErrUnexpectedEOF. Check it with errors.Is().
+func (i *Importer) Next() (*Hashed, error) {
+	if i.r == nil {
+		return nil, errors.New("failed to import the hash: reader is nil")
+	}
+
+	if !i.headerDone {
+		if err := i.readHeader(); err != nil {
+			return nil, err
+		}
+
+		i.headerDone = true
+	}
+
+	var prefix [lenArchivePrefix]byte
+
+	if _, err := io.ReadFull(i.r, prefix[:]); err != nil {
+		if errors.Is(err, io.EOF) {
+			return nil, io.EOF
+		}
+
+		return nil, i.wrapRecordErr(err, "failed to read the record length")
+	}
+
+	lenRecord := binary.BigEndian.Uint32(prefix[:])
+	if lenRecord > archiveRecordMax {
+		return nil, errors.Errorf(
+			"failed to import record %d: record length %d exceeds %d", i.numRecord+1, lenRecord, archiveRecordMax)
+	}
+
+	if cap(i.buf) < int(lenRecord) {
+		i.buf = make([]byte, lenRecord)
+	}
+
+	i.buf = i.buf[:lenRecord]
+
+	if _, err := io.ReadFull(i.r, i.buf); err != nil {
+		return nil, i.wrapRecordErr(err, "failed to read the record")
+	}
+
+	i.numRecord++
+
+	// The decoder copies the salt and hash, so the buffer can be reused
+	hashed, err := DecodeBinary(i.buf)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to import record %d", i.numRecord)
+	}
+
+	return hashed, nil
+}
+
+// ----------------------------------------------------------------------------
+//  Methods of Importer (Private)
+// ----------------------------------------------------------------------------
+
+// readHeader reads and checks the header of the archive.
+func (i *Importer) readHeader() error {
+	var header [lenArchiveHeader]byte
+
+	if _, err := io.ReadFull(i.r, header[:]); err != nil {
+		return fmt.Errorf("failed to read the archive header: %w", asUnexpectedEOF(err))
+	}
+
+	if string(header[:len(archiveMagic)]) != archiveMagic {
+		return errors.New("failed to read the archive header: not an argonize archive")
+	}
+
+	if version := header[len(archiveMagic)]; version != ArchiveVersion {
+		return errors.Errorf(
+			"failed to read the archive header: unsupported format version %d, want %d", version, ArchiveVersion)
+	}
+
+	return nil
+}
+
+// wrapRecordErr wraps the read error of the record being read with the record
+// number.
+func (i *Importer) wrapRecordErr(err error, msg string) error {
+	return fmt.Errorf("failed to import record %d: %s: %w", i.numRecord+1, msg, asUnexpectedEOF(err))
+}
+
+// ----------------------------------------------------------------------------
+//  Private Functions
+// ----------------------------------------------------------------------------
+
+// appendArchiveHeader appends the header of the archive to b.
+func appendArchiveHeader(b []byte) []byte {
+	return append(append(b, archiveMagic...), ArchiveVersion)
+}
+
+// asUnexpectedEOF converts io.EOF to io.ErrUnexpectedEOF since the data ended
+// where more data was expected.
+func asUnexpectedEOF(err error) error {
+	if errors.Is(err, io.EOF) {
+		return io.ErrUnexpectedEOF
+	}
+
+	return err
+}
@@ -0,0 +1,280 @@
+package argonize_test
+
+import (
+	"bytes"
+	"encoding/hex"
+	"errors"
+	"io"
+	"runtime"
+	"testing"
+
+	"github.com/KEINOS/go-argonize"
+	"github.com/stretchr/testify/require"
+)
+
+// ----------------------------------------------------------------------------
+//  Exporter and Importer
+// ----------------------------------------------------------------------------
+
+func TestExporter_Importer_round_trip(t *testing.T) {
+	t.Parallel()
+
+	hashes := make([]*argonize.Hashed, 0, 3)
+
+	for _, password := range []string{"foo", "bar", "baz"} {
+		hashedObj, err := argonize.HashCustomChecked([]byte(password), nil, lowCostParams())
+		require.NoError(t, err)
+
+		hashes = append(hashes, hashedObj)
+	}
+
+	var buf bytes.Buffer
+
+	exporter := argonize.NewExporter(&buf)
+
+	for _, hashedObj := range hashes {
+		require.NoError(t, exporter.Write(hashedObj))
+	}
+
+	require.NoError(t, exporter.Close())
+	require.Equal(t, "ARGZ\x01", buf.String()[:5], "it should start with the header")
+
+	importer := argonize.NewImporter(&buf)
+
+	for index, want := range hashes {
+		got, err := importer.Next()
+		require.NoError(t, err, "record %d", index)
+		require.Equal(t, want.String(), got.String())
+		require.True(t, got.IsValidPassword([]byte([]string{"foo", "bar", "baz"}[index])))
+	}
+
+	_, err := importer.Next()
+	require.ErrorIs(t, err, io.EOF)
+}
+
+func TestExporter_Close_empty_archive(t *testing.T) {
+	t.Parallel()
+
+	var buf bytes.Buffer
+
+	exporter := argonize.NewExporter(&buf)
+
+	require.NoError(t, exporter.Close())
+	require.NoError(t, exporter.Close(), "closing twice should not write the header twice")
+	require.Equal(t, "ARGZ\x01", buf.String())
+
+	_, err := argonize.NewImporter(&buf).Next()
+	require.ErrorIs(t, err, io.EOF, "empty archive should be valid")
+}
+
+func TestExporter_Write_bad_hash(t *testing.T) {
+	t.Parallel()
+
+	var buf bytes.Buffer
+
+	exporter := argonize.NewExporter(&buf)
+
+	err := exporter.Write(&argonize.Hashed{})
+	require.ErrorContains(t, err, "failed to export the hash")
+
+	hashedObj, err := argonize.DecodeHashStr(sampleHashStr)
+	require.NoError(t, err)
+
+	hashedObj.Params.Variant = "unknown"
+
+	err = exporter.Write(hashedObj)
+	require.ErrorContains(t, err, "failed to export the hash")
+	require.Zero(t, buf.Len(), "nothing should be written on error")
+
+	err = argonize.NewExporter(nil).Write(hashedObj)
+	require.ErrorContains(t, err, "writer is nil")
+}
+
+func TestExporter_Write_writer_error(t *testing.T) {
+	t.Parallel()
+
+	hashedObj, err := argonize.DecodeHashStr(sampleHashStr)
+	require.NoError(t, err)
+
+	err = argonize.NewExporter(failingWriter{}).Write(hashedObj)
+	require.ErrorContains(t, err, "failed to write the archive: disk full")
+}
+
+func TestImporter_Next_truncated(t *testing.T) {
+	t.Parallel()
+
+	hashedObj, err := argonize.DecodeHashStr(sampleHashStr)
+	require.NoError(t, err)
+
+	var buf bytes.Buffer
+
+	require.NoError(t, argonize.NewExporter(&buf).Write(hashedObj))
+
+	archive := buf.Bytes()
+
+	for _, test := range []struct {
+		msgContain string
+		length     int
+	}{
+		{length: 3, msgContain: "failed to read the archive header"},
+		{length: 7, msgContain: "failed to import record 1: failed to read the record length"},
+		{length: len(archive) - 1, msgContain: "failed to import record 1: failed to read the record"},
+	} {
+		_, err := argonize.NewImporter(bytes.NewReader(archive[:test.length])).Next()
+
+		require.ErrorIs(t, err, io.ErrUnexpectedEOF, "length: %d", test.length)
+		require.ErrorContains(t, err, test.msgContain)
+	}
+}
+
+func TestImporter_Next_bad_archive(t *testing.T) {
+	t.Parallel()
+
+	for _, test := range []struct {
+		input      string
+		msgContain string
+	}{
+		{input: "GZIP\x01", msgContain: "not an argonize archive"},
+		{input: "ARGZ\x00", msgContain: "unsupported format version 0, want 1"},
+		{input: "ARGZ\x02", msgContain: "unsupported format version 2, want 1"},
+		{input: "ARGZ\x01\xff\xff\xff\xff", msgContain: "record length 4294967295 exceeds 65536"},
+		{input: "ARGZ\x01\x00\x00\x00\x03abc", msgContain: "failed to import record 1: failed to binary decode the hash"},
+	} {
+		_, err := argonize.NewImporter(bytes.NewReader([]byte(test.input))).Next()
+
+		require.ErrorContains(t, err, test.msgContain, "input: %q", test.input)
+		require.NotErrorIs(t, err, io.EOF)
+	}
+
+	_, err := argonize.NewImporter(nil).Next()
+	require.ErrorContains(t, err, "reader is nil")
+}
+
+func TestImporter_Next_fixture(t *testing.T) {
+	t.Parallel()
+
+	archive, err := hex.DecodeString("415247" + "5a01" + // magic "ARGZ" and version 1
+		"00000056" + // record length
+		sampleBinaryHex,
+	)
+	require.NoError(t, err)
+
+	importer := argonize.NewImporter(bytes.NewReader(archive))
+
+	hashedObj, err := importer.Next()
+	require.NoError(t, err)
+	require.Equal(t, sampleHashStr, hashedObj.String())
+
+	_, err = importer.Next()
+	require.ErrorIs(t, err, io.EOF)
+}
+
+func TestExporter_Importer_all_fields(t *testing.T) {
+	t.Parallel()
+
+	hashedObj, err := argonize.DecodeHashStr(
+		"$argon2i$v=19$m=65536,t=3,p=2,keyid=djE,data=cDE$" + sampleSaltB64 + "$" + sampleHashB64)
+	require.NoError(t, err)
+
+	var buf bytes.Buffer
+
+	require.NoError(t, argonize.NewExporter(&buf).Write(hashedObj))
+
+	got, err := argonize.NewImporter(&buf).Next()
+	require.NoError(t, err)
+	require.Equal(t, hashedObj, got, "it should keep the variant, key ID and data")
+}
+
+func TestImporter_Next_reader_error(t *testing.T) {
+	t.Parallel()
+
+	_, err := argonize.NewImporter(failingReader{}).Next()
+	require.ErrorContains(t, err, "connection reset")
+	require.NotErrorIs(t, err, io.ErrUnexpectedEOF)
+}
+
+// It streams a million records through a pipe to show that neither side
+// buffers the whole archive.
+//
+//nolint:paralleltest // disable parallel since other tests would skew the heap size
+func TestExporter_Importer_million_records(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping the million-record round trip in short mode")
+	}
+
+	const numRecords = 1_000_000
+
+	hashedObj, err := argonize.HashCustomChecked([]byte("password"), nil, lowCostParams())
+	require.NoError(t, err)
+
+	want := hashedObj.String()
+
+	pipeReader, pipeWriter := io.Pipe()
+
+	go func() {
+		exporter := argonize.NewExporter(pipeWriter)
+
+		for range numRecords {
+			if err := exporter.Write(hashedObj); err != nil {
+				pipeWriter.CloseWithError(err)
+
+				return
+			}
+		}
+
+		pipeWriter.Close()
+	}()
+
+	importer := argonize.NewImporter(pipeReader)
+
+	var before, after runtime.MemStats
+
+	runtime.GC()
+	runtime.ReadMemStats(&before)
+
+	count := 0
+
+	for {
+		got, err := importer.Next()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+
+		require.NoError(t, err)
+
+		if count%100_000 == 0 {
+			require.Equal(t, want, got.String())
+		}
+
+		count++
+	}
+
+	runtime.GC()
+	runtime.ReadMemStats(&after)
+
+	require.Equal(t, numRecords, count)
+
+	// The archive is about 70 MB. The live heap should not grow with it.
+	const maxGrowth = 8 * 1024 * 1024
+
+	growth := int64(after.HeapAlloc) - int64(before.HeapAlloc) //nolint:gosec // heap size fits in int64
+	require.Less(t, growth, int64(maxGrowth), "memory use should not depend on the number of records")
+}
+
+// ----------------------------------------------------------------------------
+//  Helpers
+// ----------------------------------------------------------------------------
+
+// failingWriter is an io.Writer that always fails.
+type failingWriter struct{}
+
+func (failingWriter) Write([]byte) (int, error) {
+	return 0, errors.New("disk full")
+}
+
+// failingReader is an io.Reader that always fails.
+type failingReader struct{}
+
+func (failingReader) Read([]byte) (int, error) {
+	return 0, errors.New("connection reset")
+}
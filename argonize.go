@@ -15,7 +15,7 @@ import (
 	"encoding/base64"
 	"encoding/gob"
 	"fmt"
-	"strings"
+	"math"
 
 	"github.com/pkg/errors"
 	"golang.org/x/crypto/argon2"
@@ -30,55 +30,158 @@ import (
 // It is a helper function that calls Reader.Read using io.ReadFull. The returned
 // `n` and `err` values, `n` will be len of the input if `err` is nil.
 //
+// Replacing it is not safe for concurrent use. It is kept for backward
+// compatibility and is called by the default Randomness. Prefer SetRandomness()
+// or Hasher.WithRandomness().
+//
 //nolint:gochecknoglobals // export for test convenience
 var RandRead = rand.Read
 
+// AllowEmptyPassword allows Hash() and HashCustomChecked() to hash an empty or
+// nil password. It is false by default, so that they reject empty passwords,
+// which are almost always a bug of the caller such as an unset form field.
+//
+// Set it to true only if the flow legitimately hashes empty inputs, such as an
+// optional PIN. Be aware that the hash of an empty password is guessed at the
+// first attempt by anyone who gets the hash, regardless of the parameters, and
+// that anyone can log in with an empty input if it is stored as a credential.
+// Reject empty passwords at the login in that case.
+//
+// Set it once at the initialization of the application, before hashing. It is
+// not safe to change it concurrently with hashing.
+//
+//nolint:gochecknoglobals // package-wide policy set once at initialization
+var AllowEmptyPassword bool
+
 // ============================================================================
 //  Functions
 // ============================================================================
 
 // Hash returns a Hashed object from the password using the Argon2id algorithm.
+// It returns an error if the password is empty unless AllowEmptyPassword is set.
 //
 // Note that this function, by its nature, consumes memory and CPU.
 func Hash(password []byte) (*Hashed, error) {
 	param := NewParams()
 
 	salt, err := NewSalt(param.SaltLength)
-	if err == nil && password == nil {
-		err = errors.New("the password is empty")
+	if err == nil {
+		err = checkEmptyPassword(password)
 	}
 
 	if err != nil {
 		return nil, errors.Wrap(err, "failed to hash the password")
 	}
 
-	hashed := HashCustom(password, salt, param)
-
-	return hashed, nil
+	return HashWithSalt(password, salt, param)
 }
 
 // HashCustom returns a Hashed object from the password using the Argon2id algorithm.
 //
 // Similar to the Hash() function, but allows you to specify the algorithm parameters.
+// If the salt is nil, a random salt with the length of parameters.SaltLength is
+// used. If the parameters are nil, the default parameters of NewParams() are
+// used.
+//
+// It panics if the password can not be hashed, such as invalid parameters, a
+// salt shorter than SaltLengthMin or a failure of the random salt generation.
+// The password is not validated, so an empty password is hashed as is.
+//
+// Deprecated: Use HashWithSalt(), which rejects the weak inputs and returns the
+// error, or HashCustomChecked() to reject empty passwords as well.
 func HashCustom(password []byte, salt []byte, parameters *Params) *Hashed {
+	if parameters == nil {
+		parameters = NewParams()
+	}
+
+	hashed, err := HashWithSalt(password, salt, parameters)
+	if err != nil {
+		panic(errors.Wrap(err, "argonize: HashCustom"))
+	}
+
+	return hashed
+}
+
+// HashCustomChecked is similar to HashCustom() but validates the inputs and
+// returns an error instead of panicking. Like Hash(), it rejects an empty or nil
+// password, which is almost always a bug of the caller, unless
+// AllowEmptyPassword is set.
+//
+// Use HashWithSalt() if you genuinely want to hash an empty password
+// regardless of the policy. The salt is handled in the same way as
+// HashWithSalt(), and nil parameters are reported as an error wrapping
+// ErrNilParams rather than using the defaults.
+func HashCustomChecked(password []byte, salt []byte, parameters *Params) (*Hashed, error) {
+	if err := checkEmptyPassword(password); err != nil {
+		return nil, errors.Wrap(err, "failed to hash the password")
+	}
+
+	return HashWithSalt(password, salt, parameters)
+}
+
+// HashWithSalt is similar to HashCustom() but returns an error instead of
+// panicking if the salt is shorter than SaltLengthMin or the parameters are
+// invalid. If the salt is nil, a random salt with the length of
+// parameters.SaltLength is used.
+//
+// The error of Params.Validate() is returned as is, so every violated
+// constraint of the parameters is reported at once. The returned object holds a
+// copy of the parameters and the salt, so modifying them afterwards does not
+// affect it.
+func HashWithSalt(password []byte, salt []byte, parameters *Params) (*Hashed, error) {
+	return hashWithSalt(currentKDF(), password, salt, parameters)
+}
+
+// checkEmptyPassword returns an error if the password is empty and
+// AllowEmptyPassword is not set.
+func checkEmptyPassword(password []byte) error {
+	if len(password) == 0 && !AllowEmptyPassword {
+		return errors.New("the password is empty")
+	}
+
+	return nil
+}
+
+// hashWithSalt is the implementation of HashWithSalt() deriving the key through
+// the given KDF.
+func hashWithSalt(kdf KDF, password []byte, salt []byte, parameters *Params) (*Hashed, error) {
+	if err := parameters.Validate(); err != nil {
+		return nil, err
+	}
+
+	// Copy the params so that the caller can reuse and modify them without
+	// affecting the returned object.
+	params := *parameters
+
 	if salt == nil {
-		salt, _ = NewSalt(parameters.SaltLength)
+		newSalt, err := NewSalt(params.SaltLength)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to hash the password")
+		}
+
+		salt = newSalt
 	}
 
-	hashedPass := argon2.IDKey(
-		password,
-		salt,
-		parameters.Iterations,
-		parameters.MemoryCost,
-		parameters.Parallelism,
-		parameters.KeyLength,
-	)
+	if len(salt) < int(SaltLengthMin) {
+		return nil, errors.Errorf(
+			"failed to hash the password: salt length %d is shorter than the minimum %d",
+			len(salt), SaltLengthMin,
+		)
+	}
+
+	// Copy the salt so that the caller can reuse or zero the buffer.
+	salt = append(Salt(nil), salt...)
+
+	hashedPass, err := deriveKeyWith(kdf, password, salt, &params)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to hash the password")
+	}
 
 	return &Hashed{
-		Params: parameters,
+		Params: &params,
 		Salt:   salt,
 		Hash:   hashedPass,
-	}
+	}, nil
 }
 
 // RandomBytes returns a random number of byte slice with the given length.
@@ -89,13 +192,7 @@ func HashCustom(password []byte, salt []byte, parameters *Params) *Hashed {
 // an error is returned. Also note that if lenOut is zero, an empty byte slice
 // is returned with no error.
 func RandomBytes(lenOut uint32) ([]byte, error) {
-	bytesOut := make([]byte, lenOut)
-
-	if _, err := RandRead(bytesOut); err != nil {
-		return nil, errors.Wrap(err, "failed to read random bytes")
-	}
-
-	return bytesOut, nil
+	return randomBytesFrom(currentRandomness(), lenOut)
 }
 
 // ============================================================================
@@ -107,12 +204,52 @@ type Hashed struct {
 	Params *Params
 	Salt   Salt
 	Hash   []byte
+	// KeyID is the ID of the pepper mixed into the salt, if any. It is encoded
+	// as the "keyid" parameter of the PHC string. The Salt is the bare one
+	// without the pepper. See Hasher and PepperProvider.
+	//
+	// Only the keyed verification of Hasher.Verify() consumes it. The others,
+	// such as IsValidPassword(), ignore it and verify without pepper, so the
+	// hashes of the encoders emitting a "keyid" for their own use still verify.
+	KeyID string
+	// Data is the metadata encoded as the "data" parameter of the PHC string,
+	// or decoded from the trailing data segment, such as the profile name of
+	// Hasher.WithProfileName(). It is not a part of the key derivation and the
+	// verification ignores it.
+	//
+	// Note that some other implementations may mix the data into the key
+	// derivation as the associated data of Argon2. Such hashes are decoded but
+	// can not be verified, since "golang.org/x/crypto/argon2" does not support
+	// it.
+	Data string
+}
+
+// Stable names of the types for gob, registered regardless of the import path
+// of the package, such as a fork or a vendored copy.
+const (
+	gobNameHashed = "argonize.Hashed"
+	gobNameParams = "argonize.Params"
+)
+
+// init registers the types under the stable names, so that the gob encoded
+// interface values holding them, such as map[string]any, are decodable across
+// the forks and the copies of the package.
+//
+//nolint:gochecknoinits // gob requires the registration before decoding
+func init() {
+	gob.RegisterName(gobNameHashed, &Hashed{})
+	gob.RegisterName(gobNameParams, &Params{})
 }
 
 // ----------------------------------------------------------------------------
 //  Constructors of Hashed
 // ----------------------------------------------------------------------------
 
+// PadFiller is the filler of Hashed.StringPadded(). It is a space, which is not
+// a part of the PHC string format and is the filler of the fixed-width CHAR
+// columns of SQL databases as well.
+const PadFiller = ' '
+
 const (
 	maxInt32     = 2147483647
 	lenDecChunks = 6 // Number of chunks in the encoded hash string.
@@ -121,70 +258,40 @@ const (
 // DecodeHashStr decodes an Argon2id formatted hash string into a Hashed object.
 // Which is the value returned by Hashed.String() method.
 //
+// Argon2i formatted hash strings ("$argon2i$...") are also supported for
+// interoperability. The variant is stored in Params.Variant.
+//
+// The hash strings without the version segment, such as
+// "$argon2id$m=65536,t=3,p=2$<salt>$<hash>", are treated as the current
+// version. A trailing data segment, such as "$argon2id$v=19$...$<hash>$<data>",
+// is decoded into Hashed.Data as the "data" parameter is. Use
+// DecodeHashStrStrict() to reject them.
+//
+// On failure, it returns a *ParseError telling the bad segment and why. Use
+// DecodeHashStrWith() to configure the limits and the strictness.
+//
 // Note that the password remains hashed even if the object is decoded. Once hashed,
 // the original password cannot be recovered in any case.
 func DecodeHashStr(encodedHash string) (*Hashed, error) {
-	vals := strings.Split(encodedHash, "$")
-	if len(vals) != lenDecChunks {
-		return nil, errors.New("invalid hash format")
-	}
-
-	var version int
-
-	if _, err := fmt.Sscanf(vals[2],
-		"v=%d", &version); err != nil {
-		return nil, errors.Wrap(err, "failed to parse the version")
-	}
-
-	if version != argon2.Version {
-		return nil, errors.New("incompatible version of Argon2")
-	}
-
-	params := NewParams()
-
-	if _, err := fmt.Sscanf(vals[3],
-		"m=%d,t=%d,p=%d", &params.MemoryCost, &params.Iterations, &params.Parallelism); err != nil {
-		return nil, errors.Wrap(err, "missing parameters in the hash")
-	}
-
-	salt, err := base64.RawStdEncoding.Strict().DecodeString(vals[4])
-	if err != nil {
-		return nil, errors.Wrap(err, "failed to decode salt value")
-	}
-
-	hash, err := base64.RawStdEncoding.Strict().DecodeString(vals[5])
-	if err != nil {
-		return nil, errors.Wrap(err, "failed to decode hash value")
-	}
-
-	lenSalt := len(salt)
-	lenHash := len(hash)
-
-	// Salt length must be 8..(2^32 -1) bytes and hash length (tagLength)
-	// must be 4..(2^32 -1) bytes.
-	// Ref: https://en.wikipedia.org/wiki/Argon2#Algorithm
-	const (
-		minLenSalt = 8
-		minLenHash = 4
-	)
-
-	if lenSalt < maxInt32 && lenHash < maxInt32 && lenSalt >= minLenSalt {
-		params.SaltLength = uint32(lenSalt) //nolint:gosec // int overflow is checked above
-		params.KeyLength = uint32(lenHash)  //nolint:gosec // int overflow is checked above
-
-		return &Hashed{
-			Params: params,
-			Salt:   Salt(salt),
-			Hash:   hash,
-		}, nil
-	}
-
-	return nil, errors.New("hash or salt length is too long or too short")
+	return DecodeHashStrWith(encodedHash, DecodeOptions{})
 }
 
 // DecodeHashGob decodes gob-encoded byte slice into a Hashed object.
 // The argument should be the value from Hashed.Gob() method.
 //
+// The decoded object is validated and an error wrapping *InvalidHashError is
+// returned if it is inconsistent, such as missing parameters, too short salt or
+// hash, or the key length not matching the hash.
+//
+// Compatibility policy: the gob of Hashed.Gob() is a plain struct whose fields
+// are matched by name, not by order. The fields are never renamed, removed or
+// changed in type, and the new ones are only added with the zero value meaning
+// the previous behavior. So the gobs of the older releases lacking the newer
+// fields, such as Params.Variant, KeyID and Data, are decoded with them
+// defaulted. Hashed can be embedded in the structs to gob encode. The
+// interface values holding *Hashed and *Params are registered under the names
+// "argonize.Hashed" and "argonize.Params".
+//
 // Note that the password remains hashed even if the object is decoded. Once hashed,
 // the original password cannot be recovered in any case.
 func DecodeHashGob(gobEncHash []byte) (*Hashed, error) {
@@ -198,6 +305,10 @@ func DecodeHashGob(gobEncHash []byte) (*Hashed, error) {
 		return nil, errors.Wrap(err, "failed to gob decode the hash")
 	}
 
+	if err := hashedObj.Validate(); err != nil {
+		return nil, errors.Wrap(err, "failed to gob decode the hash")
+	}
+
 	return &hashedObj, nil
 }
 
@@ -227,16 +338,40 @@ func (h *Hashed) Gob() ([]byte, error) {
 // IsValidPassword returns true if the given password is valid.
 //
 // Note that the parameters must be the same as those used to generate the hash.
+// It returns false rather than panicking if the object is invalid, such as nil
+// Params or zero iterations. Use Validate() to find out why.
 func (h *Hashed) IsValidPassword(password []byte) bool {
+	if h.IsZero() {
+		return false
+	}
+
+	return h.isValidPasswordWith(currentKDF(), password, h.Salt)
+}
+
+// VerifyAndCanonicalize verifies the password and returns the canonical PHC
+// string of the hash along with whether the password matched, in one pass. The
+// canonical string is the one of String(), which fixes the non-canonical input
+// accepted by DecodeHashStr(), such as the padded or URL-safe base64 and the
+// order of the parameters, without changing the cost.
+//
+// The string does not depend on the password and is returned regardless of the
+// match. It returns an empty string and false if the object is uninitialized.
+func (h *Hashed) VerifyAndCanonicalize(password []byte) (string, bool) {
+	if h.IsZero() {
+		return "", false
+	}
+
+	return h.String(), h.isValidPasswordWith(currentKDF(), password, h.Salt)
+}
+
+// isValidPasswordWith returns true if the key derived from the password and
+// salt through the given KDF matches the hash.
+func (h *Hashed) isValidPasswordWith(kdf KDF, password, salt []byte) bool {
 	// The same parameters are used to derive the key from the other password.
-	otherHash := argon2.IDKey(
-		password,
-		h.Salt,
-		h.Params.Iterations,
-		h.Params.MemoryCost,
-		h.Params.Parallelism,
-		h.Params.KeyLength,
-	)
+	otherHash, err := deriveKeyWith(kdf, password, salt, h.Params)
+	if err != nil {
+		return false
+	}
 
 	// Compare hashed passwords to ensure they are identical.
 	// Note that the subtle.ConstantTimeCompare() function is used to prevent
@@ -245,23 +380,55 @@ func (h *Hashed) IsValidPassword(password []byte) bool {
 }
 
 // String returns the encoded hash string using the standard encoded hash
-// representation of the Argon2 algorithm.
+// representation of the Argon2 algorithm. The algorithm prefix reflects the
+// Params.Variant, which is "argon2id" by default.
 //
 // To decode to a Hashed object, use the DecodeHashStr() function.
 func (h *Hashed) String() string {
-	// Base64 encode the salt and hashed password.
-	b64Salt := base64.RawStdEncoding.EncodeToString(h.Salt)
-	b64Hash := base64.RawStdEncoding.EncodeToString(h.Hash)
-
-	// Return a string using the standard encoded hash representation.
-	return fmt.Sprintf(
-		"$argon2id$v=%d$m=%d,t=%d,p=%d$%s$%s",
-		argon2.Version,
-		h.Params.MemoryCost,
-		h.Params.Iterations,
-		h.Params.Parallelism,
-		b64Salt,
-		b64Hash,
+	return string(h.appendString(nil, base64.RawStdEncoding))
+}
+
+// StringURLSafe is similar to String() but encodes the salt, hash and key ID
+// with the URL-safe base64 alphabet, which uses "-" and "_" instead of "+" and
+// "/". The prefix is the same as String().
+//
+// It is for the stores which dislike "+" and "/". DecodeHashStr() accepts both
+// alphabets, but the result is not PHC compliant and DecodeHashStrStrict()
+// rejects it. Prefer String() unless needed.
+func (h *Hashed) StringURLSafe() string {
+	return string(h.appendString(nil, base64.RawURLEncoding))
+}
+
+// StringPadded is similar to String() but right-pads the encoded hash string
+// with PadFiller up to the width in bytes. It is for fixed-width database
+// columns and to avoid distinguishing hashes by their length.
+//
+// It returns an error if the encoded hash string is already longer than the
+// width. DecodeHashStr() trims the filler, so the padded string can be decoded
+// as is.
+func (h *Hashed) StringPadded(width int) (string, error) {
+	encoded := h.appendString(nil, base64.RawStdEncoding)
+	if len(encoded) > width {
+		return "", errors.Errorf("failed to pad the hash: length %d exceeds the width %d", len(encoded), width)
+	}
+
+	return string(append(encoded, bytes.Repeat([]byte{PadFiller}, width-len(encoded))...)), nil
+}
+
+// Summary returns a one-line human readable summary of the hash without the
+// salt and hash values. E.g. "argon2id v=19 m=64MiB t=3 p=4 salt=16B key=32B".
+//
+// Unlike String(), it contains no secret bytes, so it is safe to display in
+// admin panels and logs. The salt and key sizes are the actual lengths of the
+// Salt and Hash fields.
+func (h *Hashed) Summary() string {
+	if h == nil || h.Params == nil {
+		return "<no params>"
+	}
+
+	return fmt.Sprintf("%s v=%d m=%s t=%d p=%d salt=%dB key=%dB",
+		h.Params.Variant, argon2.Version, FormatMemory(h.Params.MemoryCost),
+		h.Params.Iterations, h.Params.Parallelism, len(h.Salt), len(h.Hash),
 	)
 }
 
@@ -271,23 +438,37 @@ func (h *Hashed) String() string {
 
 // Params holds the parameters for the Argon2id algorithm.
 type Params struct {
+	// Variant is the variant of the Argon2 algorithm. Defaults to the empty
+	// Variant which means argon2id.
+	Variant Variant `yaml:"variant,omitempty" toml:"variant,omitempty"`
 	// Iterations is the number of iterations or passes over the memory.
 	// Defaults to 1 which is the sensible number from the Argon2's draft RFC
 	// recommends[2].
-	Iterations uint32
+	Iterations uint32 `yaml:"iterations" toml:"iterations"`
 	// KeyLength is the length of the key used in Argon2.
 	// Defaults to 32.
-	KeyLength uint32
+	KeyLength uint32 `yaml:"key_length" toml:"key_length"`
 	// MemoryCost is the amount of memory used by the algorithm in KiB.
 	// Defaults to 64 * 1024 KiB = 64 MiB. Which is the sensible number from
 	// the Argon2's draft RFC recommends[2].
-	MemoryCost uint32
+	MemoryCost uint32 `yaml:"memory" toml:"memory"`
 	// SaltLength is the length of the salt used in Argon2.
 	// Defaults to 16.
-	SaltLength uint32
+	SaltLength uint32 `yaml:"salt_length" toml:"salt_length"`
 	// Parallelism is the number of threads or lanes used by the algorithm.
 	// Defaults to 2.
-	Parallelism uint8
+	Parallelism uint8 `yaml:"parallelism" toml:"parallelism"`
+	// MaxThreads limits the number of threads used by the concurrent hashings
+	// with the same MaxThreads value. Zero means no limit (default). It is a
+	// runtime setting and is not stored in the encoded hashes.
+	//
+	// Note that a single hashing always runs one goroutine per lane, since the
+	// underlying "golang.org/x/crypto/argon2" package can not compute p lanes
+	// with fewer threads. See the "Thread Gate" section of threads.go.
+	MaxThreads uint8 `yaml:"max_threads,omitempty" toml:"max_threads,omitempty"`
+	// memoryMiBOverflow is the MiB given to WithMemoryMiB() which overflowed
+	// the MemoryCost. Zero means no overflow.
+	memoryMiBOverflow uint32
 }
 
 const (
@@ -296,18 +477,43 @@ const (
 	// KeyLengthDefault is the default key length used in the Argon2id algorithm parameters.
 	KeyLengthDefault = uint32(32)
 	// MemoryCostDefault is the default amount of memory (KiB) used by the algorithm parameters.
-	MemoryCostDefault = uint32(64 * 1024)
+	MemoryCostDefault = MemoryCost64MiB
 	// ParallelismDefault is the default number of threads used in the algorithm parameters.
 	ParallelismDefault = uint8(2)
 	// SaltLengthDefault is the default length of the salt used in the Argon2id algorithm parameters.
 	SaltLengthDefault = uint32(16)
+	// SaltLengthMin is the minimum length of the salt allowed by the Argon2 specification.
+	SaltLengthMin = uint32(8)
+	// KeyLengthMin is the minimum key length allowed by the Argon2 specification.
+	KeyLengthMin = uint32(4)
 )
 
+// Memory costs in KiB for the MemoryCost field, such as PresetRFC9106Second of
+// 64 MiB and PresetRFC9106First of 2 GiB. See also Params.WithMemoryMiB().
+const (
+	// MemoryCost64MiB is 64 MiB in KiB.
+	MemoryCost64MiB = uint32(64 * 1024)
+	// MemoryCost256MiB is 256 MiB in KiB.
+	MemoryCost256MiB = uint32(256 * 1024)
+	// MemoryCost1GiB is 1 GiB in KiB.
+	MemoryCost1GiB = uint32(1024 * 1024)
+	// MemoryCost2GiB is 2 GiB in KiB.
+	MemoryCost2GiB = uint32(2 * 1024 * 1024)
+	// MemoryMiBMax is the maximum MiB of Params.WithMemoryMiB() that fits in
+	// the MemoryCost in KiB.
+	MemoryMiBMax = uint32(math.MaxUint32 / 1024)
+)
+
+// memoryPerLaneMin is the minimum memory cost in KiB per lane allowed by the
+// Argon2 specification.
+const memoryPerLaneMin = 8
+
 // ----------------------------------------------------------------------------
 //  Constructor of Params
 // ----------------------------------------------------------------------------
 
-// NewParams returns a new Params object with default values.
+// NewParams returns a new Params object with default values. See
+// DefaultParams() and SetDefaultParams() to change them.
 func NewParams() *Params {
 	p := new(Params)
 
@@ -316,17 +522,56 @@ func NewParams() *Params {
 	return p
 }
 
+// ParamsFromHashStr returns the parameters, including the salt and key lengths,
+// of an Argon2id formatted hash string.
+//
+// It is useful to hash a new password with the same settings as an existing
+// hash. The hash string is validated in the same way as DecodeHashStr().
+func ParamsFromHashStr(encodedHash string) (*Params, error) {
+	hashed, err := DecodeHashStr(encodedHash)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to read params from the hash")
+	}
+
+	return hashed.Params, nil
+}
+
 // ----------------------------------------------------------------------------
 //  Methods of Params
 // ----------------------------------------------------------------------------
 
-// SetDefault sets the fields to default values.
+// SetDefault sets the fields to default values. The Variant and MaxThreads are
+// left as is.
 func (p *Params) SetDefault() {
-	p.Iterations = IterationsDefault
-	p.KeyLength = KeyLengthDefault
-	p.MemoryCost = MemoryCostDefault
-	p.SaltLength = SaltLengthDefault
-	p.Parallelism = ParallelismDefault
+	defaults := currentDefaultParams()
+
+	p.Iterations = defaults.Iterations
+	p.KeyLength = defaults.KeyLength
+	p.MemoryCost = defaults.MemoryCost
+	p.SaltLength = defaults.SaltLength
+	p.Parallelism = defaults.Parallelism
+}
+
+// WithMemoryMiB sets the MemoryCost to the mib MiB and returns p itself for the
+// fluent configuration:
+//
+//	params := argonize.NewParams().WithMemoryMiB(256)
+//
+// If the mib is greater than MemoryMiBMax, the KiB would overflow the uint32
+// MemoryCost. In that case the MemoryCost is left as is and p is flagged, so
+// that Validate() and Hasher.Hash() report it as ErrMemoryCostOverflow. A later
+// call with a valid mib clears the flag.
+func (p *Params) WithMemoryMiB(mib uint32) *Params {
+	if mib > MemoryMiBMax {
+		p.memoryMiBOverflow = mib
+
+		return p
+	}
+
+	p.MemoryCost = mib * 1024
+	p.memoryMiBOverflow = 0
+
+	return p
 }
 
 // ============================================================================
@@ -342,13 +587,11 @@ type Salt []byte
 // ----------------------------------------------------------------------------
 
 // NewSalt returns a new Salt object with a random salt and given length.
+//
+// It returns an error if lenOut is shorter than SaltLengthMin. Salts shorter
+// than the minimum produce a credential that is barely salted.
 func NewSalt(lenOut uint32) (Salt, error) {
-	salt, err := RandomBytes(lenOut)
-	if err != nil {
-		return nil, errors.Wrap(err, "failed to generate salt")
-	}
-
-	return Salt(salt), nil
+	return newSaltFrom(currentRandomness(), lenOut)
 }
 
 // ----------------------------------------------------------------------------
@@ -356,6 +599,31 @@ func NewSalt(lenOut uint32) (Salt, error) {
 // ----------------------------------------------------------------------------
 
 // AddPepper add/appends a pepper value to the salt.
+//
+// The peppered salt is always a new slice, so the spare capacity of the buffer
+// which the salt was sliced from is never written to.
 func (s *Salt) AddPepper(pepper []byte) {
-	*s = append(*s, pepper...)
+	peppered := make(Salt, 0, len(*s)+len(pepper))
+	peppered = append(peppered, *s...)
+
+	*s = append(peppered, pepper...)
+}
+
+// Split separates the salt peppered by AddPepper() into the random part and the
+// pepper of pepperLen bytes at the end. It is for debugging and for tools which
+// re-derive the hash with a different pepper. It applies only to the appending
+// of AddPepper(), not to the other PepperMode.
+//
+// The parts share the memory of the salt, but the capacity of random is clipped
+// so that appending to it never overwrites the pepper. It panics if pepperLen is
+// negative or longer than the salt, as slicing out of range does, since the
+// pepper length is a fixed configuration and a mismatch is a bug of the caller.
+func (s Salt) Split(pepperLen int) (random, pepper []byte) {
+	if pepperLen < 0 || pepperLen > len(s) {
+		panic(errors.Errorf("argonize: pepper length %d is out of range of salt length %d", pepperLen, len(s)))
+	}
+
+	lenRandom := len(s) - pepperLen
+
+	return s[:lenRandom:lenRandom], s[lenRandom:]
 }
@@ -1,6 +1,11 @@
 package argonize_test
 
 import (
+	"bytes"
+	"encoding/base64"
+	"encoding/gob"
+	"encoding/hex"
+	"strings"
 	"testing"
 
 	"github.com/KEINOS/go-argonize"
@@ -22,6 +27,185 @@ func TestDecodeHashGob(t *testing.T) {
 	require.Nil(t, hashedObj, "it should be nil on error")
 }
 
+func TestDecodeHashGob_corrupt(t *testing.T) {
+	t.Parallel()
+
+	validParams := func() *argonize.Params {
+		return &argonize.Params{MemoryCost: 65536, Iterations: 3, Parallelism: 2, KeyLength: 32, SaltLength: 16}
+	}
+
+	for _, test := range []struct {
+		hashed     *argonize.Hashed
+		field      string
+		msgContain string
+	}{
+		{
+			&argonize.Hashed{Params: nil, Salt: make([]byte, 16), Hash: make([]byte, 32)},
+			"Params", "missing parameters",
+		},
+		{
+			&argonize.Hashed{Params: validParams(), Salt: make([]byte, 2), Hash: make([]byte, 32)},
+			"Salt", "length 2 is shorter than the minimum 8",
+		},
+		{
+			&argonize.Hashed{Params: validParams(), Salt: make([]byte, 16), Hash: make([]byte, 2)},
+			"Hash", "length 2 is shorter than the minimum 4",
+		},
+		{
+			&argonize.Hashed{Params: validParams(), Salt: make([]byte, 16), Hash: make([]byte, 16)},
+			"Params.KeyLength", "32 does not match the hash length 16",
+		},
+		{
+			&argonize.Hashed{
+				Params: &argonize.Params{Iterations: 3, Parallelism: 2, KeyLength: 32, SaltLength: 16},
+				Salt:   make([]byte, 16),
+				Hash:   make([]byte, 32),
+			},
+			"Params", "memory cost must be at least 8 KiB per lane",
+		},
+	} {
+		gobEnc, err := test.hashed.Gob()
+		require.NoError(t, err)
+
+		hashedObj, err := argonize.DecodeHashGob(gobEnc)
+
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "failed to gob decode the hash")
+		require.Contains(t, err.Error(), test.msgContain)
+		require.Nil(t, hashedObj, "it should be nil on error")
+
+		var invalidErr *argonize.InvalidHashError
+
+		require.ErrorAs(t, err, &invalidErr, "it should be a typed error")
+		require.Equal(t, test.field, invalidErr.Field)
+	}
+}
+
+// Gob encoded data generated before Hashed implemented encoding.BinaryMarshaler
+// must be decodable.
+func TestDecodeHashGob_legacy_format(t *testing.T) {
+	t.Parallel()
+
+	//nolint:lll // long hex string
+	const legacyGob = "317f0301010648617368656401ff800001030106506172616d7301ff8200010453616c74010a00010448617368010a0000005fff8103010106506172616d7301ff82000105010a497465726174696f6e7301060001094b65794c656e677468010600010a4d656d6f7279436f7374010600010a53616c744c656e677468010600010b506172616c6c656c69736d010600000046ff80010103012001fd010000011001020001105a8a35984ae7d6cec01dff7a7b043c5301200f84f323018ee170f66ee93deaa00ff847766da328fca6d344ca975f4d30b6c500"
+
+	gobEnc, err := hex.DecodeString(legacyGob)
+	require.NoError(t, err)
+
+	hashedObj, err := argonize.DecodeHashGob(gobEnc)
+	require.NoError(t, err)
+	require.Equal(t,
+		"$argon2id$v=19$m=65536,t=3,p=2$Woo1mErn1s7AHf96ewQ8Uw$D4TzIwGO4XD2buk96qAP+Ed2baMo/KbTRMqXX00wtsU",
+		hashedObj.String())
+
+	reEncoded, err := hashedObj.Gob()
+	require.NoError(t, err)
+
+	reDecoded, err := argonize.DecodeHashGob(reEncoded)
+	require.NoError(t, err)
+	require.Equal(t, hashedObj, reDecoded)
+}
+
+// Gob encoded data generated by the current release. Future code must keep
+// decoding them. See the compatibility policy of DecodeHashGob().
+func TestDecodeHashGob_release_fixtures(t *testing.T) {
+	t.Parallel()
+
+	for _, test := range []struct {
+		name    string
+		gobHex  string
+		encoded string
+	}{
+		{
+			name: "Hashed.Gob() with Variant, KeyID and Data",
+			//nolint:lll // long hex string
+			gobHex:  "477f03010109686173686564476f6201ff800001050106506172616d7301ff8200010453616c74010a00010448617368010a0001054b65794944010c00010444617461010c0000007aff8103010106506172616d7301ff82000107010756617269616e74010c00010a497465726174696f6e7301060001094b65794c656e677468010600010a4d656d6f7279436f7374010600010a53616c744c656e677468010600010b506172616c6c656c69736d010600010a4d617854687265616473010600000056ff800101076172676f6e32690103012001fe1000011001010001106f6c642d6e6f64652d73616c742d31360120f7c34c4c84e673df775b0edf3b00131cdcc209e35d9cd2bcb598ee2e4d53dbdf010276310102703100",
+			encoded: "$argon2i$v=19$m=4096,t=3,p=1,keyid=djE,data=cDE$b2xkLW5vZGUtc2FsdC0xNg$98NMTITmc993Ww7fOwATHNzCCeNdnNK8tZjuLk1T298",
+		},
+	} {
+		gobEnc, err := hex.DecodeString(test.gobHex)
+		require.NoError(t, err, test.name)
+
+		hashedObj, err := argonize.DecodeHashGob(gobEnc)
+		require.NoError(t, err, test.name)
+		require.Equal(t, test.encoded, hashedObj.String(), test.name)
+	}
+}
+
+// Hashed embedded in a user struct must be gob decodable, including the gob
+// encoded before Hashed implemented encoding.BinaryMarshaler.
+func TestHashed_gob_embedded(t *testing.T) {
+	t.Parallel()
+
+	type user struct {
+		Name string
+		argonize.Hashed
+	}
+
+	// gob of user{Name: "alice", Hashed: sampleHashStr} by the baseline release
+	//
+	//nolint:lll // long hex string
+	const legacyGob = "267f030101045573657201ff8000010201044e616d65010c00010648617368656401ff8200000032ff810301010648617368656401ff820001030106506172616d7301ff8400010453616c74010a00010448617368010a0000005fff8303010106506172616d7301ff84000105010a497465726174696f6e7301060001094b65794c656e677468010600010a4d656d6f7279436f7374010600010a53616c744c656e677468010600010b506172616c6c656c69736d01060000004fff800105616c69636501010103012001fd010000011001020001105a8a35984ae7d6cec01dff7a7b043c5301200f84f323018ee170f66ee93deaa00ff847766da328fca6d344ca975f4d30b6c50000"
+
+	gobEnc, err := hex.DecodeString(legacyGob)
+	require.NoError(t, err)
+
+	var decoded user
+
+	require.NoError(t, gob.NewDecoder(bytes.NewReader(gobEnc)).Decode(&decoded))
+	require.Equal(t, "alice", decoded.Name)
+	require.Equal(t, sampleHashStr, decoded.String())
+
+	// Round trip with the current version
+	var buf bytes.Buffer
+
+	require.NoError(t, gob.NewEncoder(&buf).Encode(decoded))
+
+	var reDecoded user
+
+	require.NoError(t, gob.NewDecoder(&buf).Decode(&reDecoded))
+	require.Equal(t, decoded, reDecoded)
+}
+
+// *Hashed and *Params in the interface-typed fields must be gob encodable
+// under the stable names regardless of the import path of the package.
+func TestHashed_gob_interface(t *testing.T) {
+	t.Parallel()
+
+	type session struct {
+		User  string
+		Value any
+		Extra map[string]any
+	}
+
+	hashedObj, err := argonize.DecodeHashStr(sampleHashStr)
+	require.NoError(t, err)
+
+	var buf bytes.Buffer
+
+	require.NoError(t, gob.NewEncoder(&buf).Encode(session{
+		User:  "alice",
+		Value: hashedObj,
+		Extra: map[string]any{"params": hashedObj.Params},
+	}))
+
+	require.Contains(t, buf.String(), "argonize.Hashed", "it should be encoded under the stable name")
+	require.Contains(t, buf.String(), "argonize.Params", "it should be encoded under the stable name")
+
+	var decoded session
+
+	require.NoError(t, gob.NewDecoder(&buf).Decode(&decoded))
+	require.Equal(t, "alice", decoded.User)
+
+	gotHashed, ok := decoded.Value.(*argonize.Hashed)
+	require.True(t, ok, "it should be decoded as *argonize.Hashed, got %T", decoded.Value)
+	require.Equal(t, sampleHashStr, gotHashed.String())
+
+	gotParams, ok := decoded.Extra["params"].(*argonize.Params)
+	require.True(t, ok, "it should be decoded as *argonize.Params, got %T", decoded.Extra["params"])
+	require.Equal(t, hashedObj.Params, gotParams)
+}
+
 // ----------------------------------------------------------------------------
 //  DecodeHashStr()
 // ----------------------------------------------------------------------------
@@ -33,41 +217,61 @@ var _DecodeHashStrBadCases = []struct {
 	encodedHash string
 	msgContain  string
 	errMsg      string
+	segment     argonize.Segment
 }{
 	{
 		"argon2id;v=19;m=65536,t=3,p=1;c29tZS1hc3NldA==;c29tZS1hc3NldA==",
 		"invalid hash format",
 		"missing chunks should be an error",
+		argonize.SegmentWhole,
 	},
 	{
 		"$argon2id$v=myversion$m=65536,t=3,p=2$Woo1mErn1s7AHf96ewQ8Uw$D4TzIwGO4XD2buk96qAP+Ed2baMo/KbTRMqXX00wtsU",
 		"failed to parse the version",
 		"invalid version should be an error",
+		argonize.SegmentVersion,
 	},
 	{
 		"$argon2id$v=999$m=65536,t=3,p=2$Woo1mErn1s7AHf96ewQ8Uw$D4TzIwGO4XD2buk96qAP+Ed2baMo/KbTRMqXX00wtsU",
 		"incompatible version of Argon2",
 		"incompatible version should be an error",
+		argonize.SegmentVersion,
 	},
 	{
 		"$argon2id$v=19$m=65536,t=mytime,p=2$Woo1mErn1s7AHf96ewQ8Uw$D4TzIwGO4XD2buk96qAP+Ed2baMo/KbTRMqXX00wtsU",
 		"missing parameters in the hash",
 		"missing parameters or malformed should be an error",
+		argonize.SegmentParams,
 	},
 	{
 		"$argon2id$v=19$m=65536,t=3,p=2$%%BADSALT%%$D4TzIwGO4XD2buk96qAP+Ed2baMo/KbTRMqXX00wtsU",
 		"failed to decode salt value",
 		"malformed salt should be an error",
+		argonize.SegmentSalt,
 	},
 	{
 		"$argon2id$v=19$m=65536,t=3,p=2$Woo1mErn1s7AHf96ewQ8Uw$D4TzIwGO4XD2buk96qAP+Ed2baMo/%%BADHASH%%",
 		"failed to decode hash value",
 		"malformed salt should be an error",
+		argonize.SegmentHash,
 	},
 	{
 		"$argon2id$v=19$m=65536,t=3,p=2$Woo$D4TzIwGO4XD2buk96qAP+Ed2baMo/KbTRMqXX00wtsU",
 		"hash or salt length is too long or too short",
 		"salt and hash that are out of range length should be an error",
+		argonize.SegmentSalt,
+	},
+	{
+		"$argon2id$v=19$m=65536,t=3,p=2$Woo1mErn1s7AHf96ewQ8Uw$AAAA",
+		"hash or salt length is too long or too short",
+		"hash shorter than the minimum key length should be an error",
+		argonize.SegmentHash,
+	},
+	{
+		"$argon2id$v=19$m=65536,t=0,p=2$Woo1mErn1s7AHf96ewQ8Uw$D4TzIwGO4XD2buk96qAP+Ed2baMo/KbTRMqXX00wtsU",
+		"iterations must be at least 1",
+		"zero iterations should be an error",
+		argonize.SegmentParams,
 	},
 }
 
@@ -80,6 +284,11 @@ func TestDecodeHashStr(t *testing.T) {
 		require.Error(t, err, tt.errMsg)
 		require.Contains(t, err.Error(), tt.msgContain, tt.errMsg)
 		require.Nil(t, hashedObj, "it should be nil on error")
+
+		var parseErr *argonize.ParseError
+
+		require.True(t, errors.As(err, &parseErr), tt.errMsg)
+		require.Equal(t, tt.segment, parseErr.Segment, tt.errMsg)
 	}
 }
 
@@ -96,6 +305,35 @@ func TestHash(t *testing.T) {
 	require.Contains(t, err.Error(), "failed to hash the password")
 	require.Contains(t, err.Error(), "the password is empty")
 	require.Nil(t, hashedObj, "it should be nil on error")
+
+	hashedObj, err = argonize.Hash([]byte{})
+
+	require.ErrorContains(t, err, "the password is empty")
+	require.Nil(t, hashedObj, "it should be nil on error")
+}
+
+//nolint:paralleltest // changes the package-wide AllowEmptyPassword
+func TestAllowEmptyPassword(t *testing.T) {
+	require.False(t, argonize.AllowEmptyPassword, "empty passwords should be rejected by default")
+
+	argonize.AllowEmptyPassword = true
+
+	t.Cleanup(func() { argonize.AllowEmptyPassword = false })
+
+	hashedObj, err := argonize.Hash(nil)
+	require.NoError(t, err)
+	require.True(t, hashedObj.IsValidPassword([]byte{}))
+	require.False(t, hashedObj.IsValidPassword([]byte("password")))
+
+	for _, password := range [][]byte{nil, {}} {
+		hashedObj, err := argonize.HashCustomChecked(password, nil, lowCostParams())
+		require.NoError(t, err)
+		require.True(t, hashedObj.IsValidPassword(password))
+
+		hashedObj, err = argonize.NewHasher(lowCostParams()).Hash(password)
+		require.NoError(t, err)
+		require.True(t, hashedObj.IsValidPassword(password))
+	}
 }
 
 // ----------------------------------------------------------------------------
@@ -109,7 +347,7 @@ func TestHashCustom_fix_issue46(t *testing.T) {
 	t.Run("salt is consistent", func(t *testing.T) {
 		t.Parallel()
 
-		salt := []byte("salt")
+		salt := []byte("0123456789abcdef")
 		params := argonize.NewParams()
 
 		hashedObj1 := argonize.HashCustom([]byte("password"), salt, params)
@@ -132,6 +370,179 @@ func TestHashCustom_fix_issue46(t *testing.T) {
 	})
 }
 
+func TestHashCustom_short_salt(t *testing.T) {
+	t.Parallel()
+
+	require.PanicsWithError(t,
+		"argonize: HashCustom: failed to hash the password: salt length 4 is shorter than the minimum 8",
+		func() {
+			_ = argonize.HashCustom([]byte("password"), []byte("salt"), lowCostParams())
+		},
+		"a 4-byte salt should be rejected",
+	)
+
+	_, err := argonize.HashWithSalt([]byte("password"), []byte("salt"), lowCostParams())
+	require.ErrorContains(t, err, "salt length 4 is shorter than the minimum 8")
+}
+
+func TestHashCustom_invalid_params(t *testing.T) {
+	t.Parallel()
+
+	params := lowCostParams()
+	params.Iterations = 0
+
+	require.PanicsWithError(t,
+		"argonize: HashCustom: invalid params: iterations must be at least 1",
+		func() {
+			_ = argonize.HashCustom([]byte("password"), nil, params)
+		},
+		"it should not return nil silently",
+	)
+}
+
+func TestHashCustom_params_not_aliased(t *testing.T) {
+	t.Parallel()
+
+	password := []byte("password")
+
+	params := argonize.NewParams()
+	params.MemoryCost = 64
+	params.Parallelism = 1
+
+	hashedObj := argonize.HashCustom(password, []byte("0123456789abcdef"), params)
+	require.NotNil(t, hashedObj)
+
+	before := hashedObj.String()
+
+	// Reuse and modify the same params, such as for the admin accounts
+	params.Iterations++
+	params.MemoryCost *= 2
+
+	require.Equal(t, before, hashedObj.String(), "modifying the source params should not affect the hash")
+	require.Equal(t, uint32(1), hashedObj.Params.Iterations)
+
+	decoded, err := argonize.DecodeHashStr(hashedObj.String())
+	require.NoError(t, err)
+	require.True(t, decoded.IsValidPassword(password), "it should verify after a decode round-trip")
+
+	// Modifying the decoded params should not affect the original either
+	decoded.Params.Iterations = 99
+	require.Equal(t, before, hashedObj.String())
+}
+
+func TestHashCustom_salt_not_aliased(t *testing.T) {
+	t.Parallel()
+
+	password := []byte("password")
+
+	params := argonize.NewParams()
+	params.MemoryCost = 64
+	params.Parallelism = 1
+
+	salt := []byte("0123456789abcdef")
+
+	hashedObj := argonize.HashCustom(password, salt, params)
+	require.NotNil(t, hashedObj)
+
+	before := hashedObj.String()
+
+	// Zero the buffer after use as a hygiene habit
+	clear(salt)
+
+	require.Equal(t, argonize.Salt("0123456789abcdef"), hashedObj.Salt, "zeroing the source salt should not affect the hash")
+	require.Equal(t, before, hashedObj.String())
+	require.True(t, hashedObj.IsValidPassword(password))
+}
+
+func TestHashCustom_nil_params(t *testing.T) {
+	t.Parallel()
+
+	require.NotPanics(t, func() {
+		hashedObj := argonize.HashCustom([]byte("password"), []byte("0123456789abcdef"), nil)
+		require.NotNil(t, hashedObj)
+		require.Equal(t, argonize.NewParams(), hashedObj.Params, "nil params should use the defaults")
+
+		hashedObj = argonize.HashCustom([]byte("password"), nil, nil)
+		require.NotNil(t, hashedObj)
+		require.Len(t, hashedObj.Salt, int(argonize.NewParams().SaltLength))
+	})
+
+	_, err := argonize.HashWithSalt([]byte("password"), nil, nil)
+	require.ErrorIs(t, err, argonize.ErrInvalidParams)
+	require.ErrorIs(t, err, argonize.ErrNilParams)
+
+	_, err = argonize.HashCustomChecked([]byte("password"), []byte("0123456789abcdef"), nil)
+	require.ErrorIs(t, err, argonize.ErrNilParams)
+
+	hashedObj, err := argonize.HashCustomChecked([]byte("password"), nil, nil)
+	require.ErrorIs(t, err, argonize.ErrNilParams, "nil salt should not generate a salt from nil params")
+	require.Nil(t, hashedObj)
+}
+
+// ----------------------------------------------------------------------------
+//  HashCustomChecked()
+// ----------------------------------------------------------------------------
+
+func TestHashCustomChecked_empty_password(t *testing.T) {
+	t.Parallel()
+
+	for _, password := range [][]byte{nil, {}} {
+		hashedObj, err := argonize.HashCustomChecked(password, nil, argonize.NewParams())
+
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "failed to hash the password")
+		require.Contains(t, err.Error(), "the password is empty")
+		require.Nil(t, hashedObj, "it should be nil on error")
+	}
+
+	// HashCustom does not validate the password.
+	require.NotNil(t, argonize.HashCustom([]byte{}, nil, argonize.NewParams()))
+}
+
+func TestHashCustomChecked(t *testing.T) {
+	t.Parallel()
+
+	params := argonize.NewParams()
+	params.MemoryCost = 8
+	params.Parallelism = 1
+
+	hashedObj, err := argonize.HashCustomChecked([]byte("password"), nil, params)
+
+	require.NoError(t, err)
+	require.True(t, hashedObj.IsValidPassword([]byte("password")))
+
+	hashedObj, err = argonize.HashCustomChecked([]byte("password"), []byte("salt"), params)
+
+	require.Error(t, err, "short salt should be an error")
+	require.Nil(t, hashedObj, "it should be nil on error")
+}
+
+// ----------------------------------------------------------------------------
+//  HashWithSalt()
+// ----------------------------------------------------------------------------
+
+func TestHashWithSalt_short_salt(t *testing.T) {
+	t.Parallel()
+
+	hashedObj, err := argonize.HashWithSalt([]byte("password"), []byte("salt"), argonize.NewParams())
+
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "salt length 4 is shorter than the minimum 8")
+	require.Nil(t, hashedObj, "it should be nil on error")
+}
+
+func TestHashWithSalt_short_salt_length_param(t *testing.T) {
+	t.Parallel()
+
+	params := argonize.NewParams()
+	params.SaltLength = 0
+
+	hashedObj, err := argonize.HashWithSalt([]byte("password"), nil, params)
+
+	require.ErrorIs(t, err, argonize.ErrSaltLengthTooShort)
+	require.Nil(t, hashedObj, "it should be nil on error")
+}
+
 // ----------------------------------------------------------------------------
 //  Hashed.Gob()
 // ----------------------------------------------------------------------------
@@ -147,6 +558,138 @@ func TestHashed_Gob(t *testing.T) {
 	require.Nil(t, b, "it should be nil on error")
 }
 
+// ----------------------------------------------------------------------------
+//  Hashed.StringPadded()
+// ----------------------------------------------------------------------------
+
+func TestHashed_StringPadded(t *testing.T) {
+	t.Parallel()
+
+	const encoded = "$argon2id$v=19$m=65536,t=3,p=2$Woo1mErn1s7AHf96ewQ8Uw$D4TzIwGO4XD2buk96qAP+Ed2baMo/KbTRMqXX00wtsU"
+
+	hashedObj, err := argonize.DecodeHashStr(encoded)
+	require.NoError(t, err)
+
+	padded, err := hashedObj.StringPadded(128)
+	require.NoError(t, err)
+	require.Len(t, padded, 128)
+	require.Equal(t, encoded+strings.Repeat(" ", 128-len(encoded)), padded)
+	require.Equal(t, encoded, hashedObj.String(), "String() should stay unpadded")
+
+	// Decoding should trim the filler
+	decoded, err := argonize.DecodeHashStr(padded)
+	require.NoError(t, err)
+	require.Equal(t, hashedObj, decoded)
+
+	// Same width as the natural length
+	padded, err = hashedObj.StringPadded(len(encoded))
+	require.NoError(t, err)
+	require.Equal(t, encoded, padded)
+
+	// Too narrow
+	padded, err = hashedObj.StringPadded(len(encoded) - 1)
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "exceeds the width")
+	require.Empty(t, padded)
+}
+
+// ----------------------------------------------------------------------------
+//  Hashed.StringURLSafe()
+// ----------------------------------------------------------------------------
+
+func TestHashed_StringURLSafe(t *testing.T) {
+	t.Parallel()
+
+	hashedObj, err := argonize.DecodeHashStr(sampleHashStr)
+	require.NoError(t, err)
+
+	urlSafe := hashedObj.StringURLSafe()
+
+	require.Equal(t,
+		"$argon2id$v=19$m=65536,t=3,p=2$Woo1mErn1s7AHf96ewQ8Uw$D4TzIwGO4XD2buk96qAP-Ed2baMo_KbTRMqXX00wtsU",
+		urlSafe)
+	require.NotContains(t, urlSafe, "+")
+	require.NotContains(t, strings.TrimPrefix(urlSafe, "$argon2id$"), "/")
+	require.Equal(t, sampleHashStr, hashedObj.String(), "the default should remain the standard alphabet")
+
+	// DecodeHashStr() should accept both alphabets
+	decoded, err := argonize.DecodeHashStr(urlSafe)
+	require.NoError(t, err)
+	require.Equal(t, hashedObj, decoded)
+
+	decoded, err = argonize.DecodeHashStrLenient(urlSafe)
+	require.NoError(t, err)
+	require.Equal(t, hashedObj, decoded)
+
+	// Not PHC compliant
+	_, err = argonize.DecodeHashStrStrict(urlSafe)
+	require.ErrorIs(t, err, argonize.ErrInvalidHashValue)
+
+	// Mixed alphabets in a segment are rejected
+	_, err = argonize.DecodeHashStr(strings.Replace(urlSafe, "-", "+", 1))
+	require.ErrorIs(t, err, argonize.ErrInvalidHashValue)
+}
+
+func TestHashed_StringURLSafe_key_id(t *testing.T) {
+	t.Parallel()
+
+	hashedObj, err := argonize.DecodeHashStr(sampleHashStr)
+	require.NoError(t, err)
+
+	hashedObj.KeyID = "\xfb\xff" // "+/8" in the standard alphabet
+
+	require.Contains(t, hashedObj.String(), ",keyid=+/8$")
+	require.Contains(t, hashedObj.StringURLSafe(), ",keyid=-_8$")
+
+	decoded, err := argonize.DecodeHashStr(hashedObj.StringURLSafe())
+	require.NoError(t, err)
+	require.Equal(t, hashedObj, decoded)
+
+	// Strict accepts only the standard alphabet, including the key ID
+	decoded, err = argonize.DecodeHashStrStrict(hashedObj.String())
+	require.NoError(t, err)
+	require.Equal(t, hashedObj, decoded)
+
+	urlSafeKeyID := strings.Replace(hashedObj.String(), ",keyid=+/8$", ",keyid=-_8$", 1)
+
+	decoded, err = argonize.DecodeHashStr(urlSafeKeyID)
+	require.NoError(t, err)
+	require.Equal(t, hashedObj, decoded)
+
+	_, err = argonize.DecodeHashStrWith(urlSafeKeyID, argonize.DecodeOptions{Strict: true})
+	requireParseError(t, err, argonize.ErrMissingParams, argonize.SegmentParams)
+	require.ErrorContains(t, err, `bad value of parameter "keyid"`)
+}
+
+// ----------------------------------------------------------------------------
+//  Hashed.Summary()
+// ----------------------------------------------------------------------------
+
+func TestHashed_Summary(t *testing.T) {
+	t.Parallel()
+
+	hashedObj, err := argonize.DecodeHashStr(
+		"$argon2id$v=19$m=65536,t=3,p=2$Woo1mErn1s7AHf96ewQ8Uw$D4TzIwGO4XD2buk96qAP+Ed2baMo/KbTRMqXX00wtsU",
+	)
+	require.NoError(t, err)
+
+	summary := hashedObj.Summary()
+
+	require.Equal(t, "argon2id v=19 m=64MiB t=3 p=2 salt=16B key=32B", summary)
+
+	// No secret bytes in any encoding should appear
+	for _, secret := range [][]byte{hashedObj.Salt, hashedObj.Hash} {
+		require.NotContains(t, summary, base64.RawStdEncoding.EncodeToString(secret))
+		require.NotContains(t, summary, hex.EncodeToString(secret))
+		require.NotContains(t, summary, string(secret))
+	}
+
+	hashedObj.Params.Variant = argonize.VariantArgon2i
+
+	require.Equal(t, "argon2i v=19 m=64MiB t=3 p=2 salt=16B key=32B", hashedObj.Summary())
+	require.Equal(t, "<no params>", new(argonize.Hashed).Summary())
+}
+
 // ----------------------------------------------------------------------------
 //  Hashed.IsValidPassword()
 // ----------------------------------------------------------------------------
@@ -166,6 +709,47 @@ func TestHash_IsValidPassword_compatibility(t *testing.T) {
 	require.False(t, hashObj.IsValidPassword([]byte("2Apple1Mango")))
 }
 
+// ----------------------------------------------------------------------------
+//  Hashed.VerifyAndCanonicalize()
+// ----------------------------------------------------------------------------
+
+func TestHashed_VerifyAndCanonicalize(t *testing.T) {
+	t.Parallel()
+
+	//nolint:gosec // hardcoded credentials as an example
+	canonical := "$argon2id$v=19$m=65536,t=4,p=1$VzYzcEdxUTlaQ2E3b3Y4cw$oDUmWEt4fynfBCNMDK/EL6jgJB2yuhaP2TBW1DOsOeU"
+
+	for _, stored := range []string{
+		canonical,
+		"$argon2id$v=19$m=65536,t=4,p=1$VzYzcEdxUTlaQ2E3b3Y4cw==$oDUmWEt4fynfBCNMDK/EL6jgJB2yuhaP2TBW1DOsOeU=",
+		"$argon2id$v=19$m=65536,t=4,p=1$VzYzcEdxUTlaQ2E3b3Y4cw$oDUmWEt4fynfBCNMDK_EL6jgJB2yuhaP2TBW1DOsOeU",
+		"$argon2id$v=19$t=4,p=1,m=65536$VzYzcEdxUTlaQ2E3b3Y4cw$oDUmWEt4fynfBCNMDK/EL6jgJB2yuhaP2TBW1DOsOeU",
+	} {
+		hashObj, err := argonize.DecodeHashStrLenient(stored)
+		require.NoError(t, err)
+
+		encoded, isValid := hashObj.VerifyAndCanonicalize([]byte("2Melon1Banana"))
+		require.True(t, isValid, "stored: %s", stored)
+		require.Equal(t, canonical, encoded, "it should re-emit the canonical string")
+
+		encoded, isValid = hashObj.VerifyAndCanonicalize([]byte("2Apple1Mango"))
+		require.False(t, isValid)
+		require.Equal(t, canonical, encoded, "the string should not depend on the match")
+	}
+}
+
+func TestHashed_VerifyAndCanonicalize_zero(t *testing.T) {
+	t.Parallel()
+
+	var nilHash *argonize.Hashed
+
+	for _, hashObj := range []*argonize.Hashed{nilHash, {}} {
+		encoded, isValid := hashObj.VerifyAndCanonicalize([]byte("password"))
+		require.Empty(t, encoded)
+		require.False(t, isValid)
+	}
+}
+
 // ----------------------------------------------------------------------------
 //  NewSalt()
 // ----------------------------------------------------------------------------
@@ -190,6 +774,242 @@ func TestNewSalt(t *testing.T) {
 	require.Zero(t, salt, "it should be zero on error")
 }
 
+func TestNewSalt_short_length(t *testing.T) {
+	t.Parallel()
+
+	salt, err := argonize.NewSalt(argonize.SaltLengthMin - 1)
+
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "length 7 is shorter than the minimum 8")
+	require.Nil(t, salt, "it should be nil on error")
+}
+
+// ----------------------------------------------------------------------------
+//  Salt.AddPepper()
+// ----------------------------------------------------------------------------
+
+func TestSalt_AddPepper_spare_capacity(t *testing.T) {
+	t.Parallel()
+
+	buf := make([]byte, 16, 64)
+	copy(buf, "0123456789abcdef")
+
+	salt := argonize.Salt(buf)
+	salt.AddPepper([]byte("pepper"))
+
+	require.Equal(t, argonize.Salt("0123456789abcdefpepper"), salt)
+	require.Equal(t, make([]byte, 48), buf[16:64], "it should not write into the buffer of the caller")
+
+	// Modifying the peppered salt should not affect the original buffer
+	salt[0] = 'X'
+	require.Equal(t, []byte("0123456789abcdef"), buf)
+}
+
+// ----------------------------------------------------------------------------
+//  Salt.Split()
+// ----------------------------------------------------------------------------
+
+func TestSalt_Split(t *testing.T) {
+	t.Parallel()
+
+	salt := argonize.Salt("0123456789abcdef")
+	salt.AddPepper([]byte("pepper"))
+
+	random, pepper := salt.Split(len("pepper"))
+	require.Equal(t, []byte("0123456789abcdef"), random)
+	require.Equal(t, []byte("pepper"), pepper)
+
+	// Appending to the random part should not overwrite the pepper
+	_ = append(random, "XXXXXX"...)
+	require.Equal(t, []byte("pepper"), pepper)
+
+	// Re-pepper with a different pepper, such as on rotation
+	rotated := argonize.Salt(append([]byte(nil), random...))
+	rotated.AddPepper([]byte("new-pepper"))
+	require.Equal(t, argonize.Salt("0123456789abcdefnew-pepper"), rotated)
+
+	// Edge lengths
+	random, pepper = salt.Split(0)
+	require.Equal(t, []byte(salt), random)
+	require.Empty(t, pepper)
+
+	random, pepper = salt.Split(len(salt))
+	require.Empty(t, random)
+	require.Equal(t, []byte(salt), pepper)
+}
+
+func TestSalt_Split_out_of_range(t *testing.T) {
+	t.Parallel()
+
+	salt := argonize.Salt("0123456789abcdef")
+
+	require.PanicsWithError(t, "argonize: pepper length 17 is out of range of salt length 16", func() {
+		salt.Split(17)
+	})
+	require.Panics(t, func() {
+		salt.Split(-1)
+	})
+}
+
+// ----------------------------------------------------------------------------
+//  Params.Validate()
+// ----------------------------------------------------------------------------
+
+func TestParams_Validate(t *testing.T) {
+	t.Parallel()
+
+	require.NoError(t, argonize.NewParams().Validate())
+
+	var nilParams *argonize.Params
+
+	require.ErrorContains(t, nilParams.Validate(), "params are nil")
+	require.ErrorIs(t, nilParams.Validate(), argonize.ErrNilParams)
+
+	for _, test := range []struct {
+		modify     func(p *argonize.Params)
+		sentinel   error
+		msgContain string
+	}{
+		{func(p *argonize.Params) { p.Iterations = 0 }, argonize.ErrIterationsTooLow, "iterations must be at least 1"},
+		{func(p *argonize.Params) { p.Parallelism = 0 }, argonize.ErrParallelismTooLow, "parallelism must be at least 1"},
+		{func(p *argonize.Params) { p.MemoryCost = 15 }, argonize.ErrMemoryCostTooLow, "memory cost must be at least 8 KiB per lane"},
+		{func(p *argonize.Params) { p.KeyLength = 3 }, argonize.ErrKeyLengthTooShort, "key length must be at least 4"},
+		{func(p *argonize.Params) { p.SaltLength = 7 }, argonize.ErrSaltLengthTooShort, "salt length must be at least 8"},
+		{func(p *argonize.Params) { p.Variant = "argon2d" }, argonize.ErrUnsupportedVariant, `unsupported algorithm variant "argon2d"`},
+	} {
+		params := argonize.NewParams()
+
+		test.modify(params)
+
+		err := params.Validate()
+
+		require.ErrorContains(t, err, test.msgContain)
+		require.ErrorIs(t, err, test.sentinel)
+		require.ErrorIs(t, err, argonize.ErrInvalidParams)
+	}
+}
+
+func TestParams_Validate_multiple_errors(t *testing.T) {
+	t.Parallel()
+
+	params := argonize.NewParams()
+	params.Iterations = 0
+	params.MemoryCost = 8 // less than 8 KiB * 2 lanes
+	params.SaltLength = 4
+
+	err := params.Validate()
+
+	require.Error(t, err)
+
+	for _, sentinel := range []error{
+		argonize.ErrIterationsTooLow,
+		argonize.ErrMemoryCostTooLow,
+		argonize.ErrSaltLengthTooShort,
+	} {
+		require.ErrorIs(t, err, sentinel, "every violation should be reported")
+	}
+
+	for _, sentinel := range []error{
+		argonize.ErrParallelismTooLow,
+		argonize.ErrKeyLengthTooShort,
+		argonize.ErrUnsupportedVariant,
+	} {
+		require.NotErrorIs(t, err, sentinel, "valid fields should not be reported")
+	}
+
+	// Hashing functions should surface the joined error unchanged
+	hashedObj, hashErr := argonize.HashWithSalt([]byte("password"), nil, params)
+
+	require.Nil(t, hashedObj)
+	require.Equal(t, err.Error(), hashErr.Error())
+	require.Panics(t, func() {
+		_ = argonize.HashCustom([]byte("password"), nil, params)
+	})
+}
+
+// ----------------------------------------------------------------------------
+//  Params.WithMemoryMiB()
+// ----------------------------------------------------------------------------
+
+func TestParams_WithMemoryMiB(t *testing.T) {
+	t.Parallel()
+
+	params := argonize.NewParams()
+
+	require.Same(t, params, params.WithMemoryMiB(256), "it should return the same pointer")
+	require.Equal(t, argonize.MemoryCost256MiB, params.MemoryCost)
+
+	require.Equal(t, uint32(65536), argonize.MemoryCost64MiB)
+	require.Equal(t, uint32(1048576), argonize.MemoryCost1GiB)
+	require.Equal(t, argonize.MemoryCost2GiB, argonize.NewParams().WithMemoryMiB(2048).MemoryCost)
+	require.Equal(t, argonize.PresetRFC9106First.Params().MemoryCost, argonize.MemoryCost2GiB)
+
+	hashedObj, err := argonize.NewHasher(lowCostParams().WithMemoryMiB(1)).Hash([]byte("password"))
+	require.NoError(t, err)
+	require.Equal(t, uint32(1024), hashedObj.Params.MemoryCost)
+
+	// The maximum should not overflow
+	params = argonize.NewParams().WithMemoryMiB(argonize.MemoryMiBMax)
+
+	require.NoError(t, params.Validate())
+	require.Equal(t, uint32(0xFFFFFC00), params.MemoryCost)
+	require.Equal(t, argonize.NewParams().WithMemoryMiB(256), argonize.NewParams().WithMemoryMiB(256))
+}
+
+func TestParams_WithMemoryMiB_overflow(t *testing.T) {
+	t.Parallel()
+
+	params := lowCostParams()
+	before := params.MemoryCost
+
+	require.NotPanics(t, func() {
+		require.Same(t, params, params.WithMemoryMiB(argonize.MemoryMiBMax+1))
+	})
+	require.Equal(t, before, params.MemoryCost, "it should not modify the memory cost on overflow")
+
+	err := params.Validate()
+
+	require.ErrorIs(t, err, argonize.ErrInvalidParams)
+	require.ErrorIs(t, err, argonize.ErrMemoryCostOverflow)
+	require.EqualError(t, err, "invalid params: memory must be at most 4194303 MiB: got 4194304 MiB")
+	require.False(t, params.IsZero())
+
+	hashedObj, err := argonize.NewHasher(params).Hash([]byte("password"))
+	require.ErrorIs(t, err, argonize.ErrMemoryCostOverflow)
+	require.Nil(t, hashedObj)
+
+	// A later valid call should clear the overflow
+	require.NoError(t, params.WithMemoryMiB(1).Validate())
+	require.Equal(t, uint32(1024), params.MemoryCost)
+}
+
+// ----------------------------------------------------------------------------
+//  ParamsFromHashStr()
+// ----------------------------------------------------------------------------
+
+func TestParamsFromHashStr(t *testing.T) {
+	t.Parallel()
+
+	params, err := argonize.ParamsFromHashStr(
+		"$argon2id$v=19$m=65536,t=3,p=4$Woo1mErn1s4$D4TzIwGO4XD2buk96qAP+Ed2baMo/KbTRMqXX00wtsU",
+	)
+	require.NoError(t, err)
+
+	require.Equal(t, &argonize.Params{
+		Iterations:  3,
+		KeyLength:   32,
+		MemoryCost:  65536,
+		SaltLength:  8,
+		Parallelism: 4,
+	}, params)
+
+	params, err = argonize.ParamsFromHashStr("invalid")
+
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "failed to read params from the hash")
+	require.Nil(t, params, "it should be nil on error")
+}
+
 // ----------------------------------------------------------------------------
 //  RandomBytes()
 // ----------------------------------------------------------------------------
@@ -0,0 +1,92 @@
+/*
+Package argonizeent provides the ent field and mixin of argonize.Hashed.
+
+The field stores the hash as the PHC string of Hashed.String() in a string
+column, with the sql.Scanner and driver.Valuer of *argonize.Hashed. Declare it
+in the schema with Field() or embed the Mixin:
+
+	func (User) Fields() []ent.Field {
+		return []ent.Field{
+			field.String("name"),
+			argonizeent.Field("password"),
+		}
+	}
+
+	func (User) Mixin() []ent.Mixin {
+		return []ent.Mixin{argonizeent.Mixin{}}
+	}
+
+The generated entity holds the field as a *argonize.Hashed. The invalid PHC
+strings in the database surface as the errors of the query, and the invalid
+hashes are rejected on save. The field is sensitive, so ent omits it from the
+String() and the JSON of the entity.
+
+It is a separate module to keep the core argonize package free of ent.
+*/
+package argonizeent
+
+import (
+	"entgo.io/ent"
+	"entgo.io/ent/schema/field"
+	"entgo.io/ent/schema/mixin"
+	"github.com/KEINOS/go-argonize"
+)
+
+// DefaultFieldName is the name of the field of the Mixin if Mixin.Name is empty.
+const DefaultFieldName = "password_hash"
+
+// ============================================================================
+//  Type: Mixin
+// ============================================================================
+
+// Mixin adds the field of argonize.Hashed to the schema.
+type Mixin struct {
+	mixin.Schema
+
+	// Name is the name of the field. Defaults to DefaultFieldName.
+	Name string
+	// Optional makes the field nullable in the database and nillable in the
+	// entity, such as for the users signing in with an external provider.
+	Optional bool
+}
+
+// ----------------------------------------------------------------------------
+//  Methods of Mixin
+// ----------------------------------------------------------------------------
+
+// Fields implements ent.Mixin. It returns the field of Field() or
+// OptionalField().
+func (m Mixin) Fields() []ent.Field {
+	name := m.Name
+	if name == "" {
+		name = DefaultFieldName
+	}
+
+	if m.Optional {
+		return []ent.Field{OptionalField(name)}
+	}
+
+	return []ent.Field{Field(name)}
+}
+
+// ============================================================================
+//  Functions
+// ============================================================================
+
+// Field returns the required string field of the name holding the hash as a
+// *argonize.Hashed.
+func Field(name string) ent.Field {
+	return field.String(name).
+		GoType(&argonize.Hashed{}).
+		Sensitive()
+}
+
+// OptionalField is the same as Field() but the field is nullable in the
+// database and nillable in the entity.
+func OptionalField(name string) ent.Field {
+	return field.String(name).
+		GoType(&argonize.Hashed{}).
+		Sensitive().
+		Optional().
+		Nillable()
+}
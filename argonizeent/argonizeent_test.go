@@ -0,0 +1,172 @@
+package argonizeent_test
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+
+	"entgo.io/ent"
+	"entgo.io/ent/dialect"
+	entsql "entgo.io/ent/dialect/sql"
+	"entgo.io/ent/schema/field"
+	"github.com/KEINOS/go-argonize"
+	"github.com/KEINOS/go-argonize/argonizeent"
+	"github.com/stretchr/testify/require"
+	_ "modernc.org/sqlite" // registers the "sqlite" driver
+)
+
+const sampleHashStr = "$argon2id$v=19$m=65536,t=3,p=2$Woo1mErn1s7AHf96ewQ8Uw$D4TzIwGO4XD2buk96qAP+Ed2baMo/KbTRMqXX00wtsU"
+
+// openDriver returns the ent driver of a new in-memory sqlite database with
+// the users table.
+func openDriver(t *testing.T) *entsql.Driver {
+	t.Helper()
+
+	db, err := sql.Open("sqlite", ":memory:")
+	require.NoError(t, err)
+
+	// Each connection of ":memory:" is a separate database
+	db.SetMaxOpenConns(1)
+
+	drv := entsql.OpenDB(dialect.SQLite, db)
+
+	t.Cleanup(func() {
+		_ = drv.Close()
+	})
+
+	err = drv.Exec(context.Background(),
+		"CREATE TABLE users (name TEXT NOT NULL, password_hash TEXT)", []any{}, nil)
+	require.NoError(t, err)
+
+	return drv
+}
+
+// insertUser inserts the user with the ent query builder as the generated
+// create builders do.
+func insertUser(t *testing.T, drv *entsql.Driver, name string, hashed any) error {
+	t.Helper()
+
+	query, args := entsql.Dialect(dialect.SQLite).
+		Insert("users").
+		Columns("name", argonizeent.DefaultFieldName).
+		Values(name, hashed).
+		Query()
+
+	return drv.Exec(context.Background(), query, args, nil)
+}
+
+// selectHash selects the hash of the user and scans it into a *argonize.Hashed
+// as the generated entities do.
+func selectHash(t *testing.T, drv *entsql.Driver, name string) (*argonize.Hashed, error) {
+	t.Helper()
+
+	query, args := entsql.Dialect(dialect.SQLite).
+		Select(argonizeent.DefaultFieldName).
+		From(entsql.Table("users")).
+		Where(entsql.EQ("name", name)).
+		Query()
+
+	var rows entsql.Rows
+
+	require.NoError(t, drv.Query(context.Background(), query, args, &rows))
+
+	defer rows.Close()
+
+	require.True(t, rows.Next(), "user %q should exist", name)
+
+	hashed := new(argonize.Hashed)
+
+	if err := rows.Scan(hashed); err != nil {
+		return nil, err
+	}
+
+	return hashed, rows.Err()
+}
+
+// ----------------------------------------------------------------------------
+//  Field() and OptionalField()
+// ----------------------------------------------------------------------------
+
+func TestField_descriptor(t *testing.T) {
+	t.Parallel()
+
+	for name, test := range map[string]struct {
+		field    ent.Field
+		optional bool
+	}{
+		"Field":         {argonizeent.Field("password"), false},
+		"OptionalField": {argonizeent.OptionalField("password"), true},
+	} {
+		desc := test.field.Descriptor()
+
+		require.NoError(t, desc.Err, name)
+		require.Equal(t, "password", desc.Name, name)
+		require.Equal(t, field.TypeString, desc.Info.Type, name)
+		require.Equal(t, "*argonize.Hashed", desc.Info.Ident, name)
+		require.True(t, desc.Info.ValueScanner(), "%s: it should use the Scanner and Valuer of Hashed", name)
+		require.True(t, desc.Sensitive, "%s: hash should be sensitive", name)
+		require.Equal(t, test.optional, desc.Optional, name)
+		require.Equal(t, test.optional, desc.Nillable, name)
+	}
+}
+
+// ----------------------------------------------------------------------------
+//  Mixin
+// ----------------------------------------------------------------------------
+
+func TestMixin_Fields(t *testing.T) {
+	t.Parallel()
+
+	fields := argonizeent.Mixin{}.Fields()
+	require.Len(t, fields, 1)
+	require.Equal(t, argonizeent.DefaultFieldName, fields[0].Descriptor().Name)
+	require.False(t, fields[0].Descriptor().Optional)
+
+	fields = argonizeent.Mixin{Name: "secret", Optional: true}.Fields()
+	require.Len(t, fields, 1)
+	require.Equal(t, "secret", fields[0].Descriptor().Name)
+	require.True(t, fields[0].Descriptor().Optional)
+}
+
+// ----------------------------------------------------------------------------
+//  sqlite
+// ----------------------------------------------------------------------------
+
+func TestField_sqlite_round_trip(t *testing.T) {
+	t.Parallel()
+
+	drv := openDriver(t)
+
+	hashed, err := argonize.DecodeHashStr(sampleHashStr)
+	require.NoError(t, err)
+
+	require.NoError(t, insertUser(t, drv, "alice", hashed))
+
+	got, err := selectHash(t, drv, "alice")
+	require.NoError(t, err)
+	require.Equal(t, sampleHashStr, got.String())
+
+	// NULL of the optional field
+	require.NoError(t, insertUser(t, drv, "bob", (*argonize.Hashed)(nil)))
+
+	got, err = selectHash(t, drv, "bob")
+	require.NoError(t, err)
+	require.True(t, got.IsZero(), "NULL should be the zero Hashed")
+}
+
+func TestField_sqlite_invalid_hash(t *testing.T) {
+	t.Parallel()
+
+	drv := openDriver(t)
+
+	// Broken hashes should not be stored
+	err := insertUser(t, drv, "eve", &argonize.Hashed{})
+	require.ErrorContains(t, err, "failed to store the hash")
+
+	// Broken PHC strings in the database should fail the query
+	require.NoError(t, insertUser(t, drv, "mallory", "not a hash"))
+
+	got, err := selectHash(t, drv, "mallory")
+	require.ErrorContains(t, err, "failed to scan the hash")
+	require.Nil(t, got)
+}
@@ -0,0 +1,133 @@
+/*
+Package argonizegorm provides the GORM serializer of argonize.Hashed.
+
+Importing the package registers the serializer under the name "argon2", so that
+the models can store a Hashed as the PHC string in a string column, such as
+VARCHAR:
+
+	import _ "github.com/KEINOS/go-argonize/argonizegorm"
+
+	type User struct {
+		ID       uint
+		Password argonize.Hashed `gorm:"serializer:argon2;type:varchar(255)"`
+	}
+
+The field may be a argonize.Hashed or a *argonize.Hashed. A nil pointer is
+stored as NULL, and NULL is loaded as a nil pointer or the zero Hashed. The
+invalid PHC strings in the database surface as the errors of the query.
+
+It is a separate module to keep the core argonize package free of GORM.
+*/
+package argonizegorm
+
+import (
+	"context"
+	"reflect"
+
+	"github.com/KEINOS/go-argonize"
+	"github.com/pkg/errors"
+	"gorm.io/gorm/schema"
+)
+
+// Name is the name of the serializer to declare in the gorm tag of the fields,
+// i.e. `gorm:"serializer:argon2"`.
+const Name = "argon2"
+
+// typeHashed is the reflect.Type of argonize.Hashed.
+//
+//nolint:gochecknoglobals // constant type information
+var typeHashed = reflect.TypeOf(argonize.Hashed{})
+
+// init registers the Serializer under the Name. Unlike gob.Register(), it
+// overwrites the registration of the other copies of the package instead of
+// panicking.
+//
+//nolint:gochecknoinits // the serializers of GORM are registered by importing
+func init() {
+	schema.RegisterSerializer(Name, Serializer{})
+}
+
+// ============================================================================
+//  Type: Serializer
+// ============================================================================
+
+// Serializer implements schema.SerializerInterface of GORM to store
+// argonize.Hashed as the PHC string of Hashed.String().
+type Serializer struct{}
+
+// ----------------------------------------------------------------------------
+//  Methods of Serializer
+// ----------------------------------------------------------------------------
+
+// Scan implements schema.SerializerInterface. It decodes the PHC string of the
+// database into the field with argonize.Hashed.Scan().
+//
+// It returns an error if the field is not a argonize.Hashed or a pointer to it,
+// or the value is not a valid PHC string.
+func (Serializer) Scan(ctx context.Context, field *schema.Field, dst reflect.Value, dbValue any) error {
+	isPtr, err := checkFieldType(field)
+	if err != nil {
+		return err
+	}
+
+	hashed := new(argonize.Hashed)
+
+	if err := hashed.Scan(dbValue); err != nil {
+		return errors.Wrapf(err, "failed to scan the field %s", field.Name)
+	}
+
+	fieldValue := reflect.ValueOf(hashed)
+
+	switch {
+	case !isPtr:
+		fieldValue = fieldValue.Elem()
+	case dbValue == nil:
+		fieldValue = reflect.Zero(field.FieldType)
+	}
+
+	field.ReflectValueOf(ctx, dst).Set(fieldValue)
+
+	return nil
+}
+
+// Value implements schema.SerializerInterface. It returns the PHC string of the
+// field with argonize.Hashed.Value(), or nil for a nil pointer.
+//
+// It returns an error if the field is not a argonize.Hashed or a pointer to it,
+// or the hash is invalid, so that a broken hash is not stored silently.
+func (Serializer) Value(_ context.Context, field *schema.Field, _ reflect.Value, fieldValue any) (any, error) {
+	var hashed *argonize.Hashed
+
+	switch value := fieldValue.(type) {
+	case argonize.Hashed:
+		hashed = &value
+	case *argonize.Hashed:
+		hashed = value
+	default:
+		return nil, errors.Errorf("failed to serialize the field %s: unsupported type %T", field.Name, fieldValue)
+	}
+
+	out, err := hashed.Value()
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to serialize the field %s", field.Name)
+	}
+
+	return out, nil
+}
+
+// ----------------------------------------------------------------------------
+//  Private Functions
+// ----------------------------------------------------------------------------
+
+// checkFieldType returns true if the field is a pointer to argonize.Hashed, and
+// an error if it is neither argonize.Hashed nor a pointer to it.
+func checkFieldType(field *schema.Field) (bool, error) {
+	switch field.FieldType {
+	case typeHashed:
+		return false, nil
+	case reflect.PointerTo(typeHashed):
+		return true, nil
+	}
+
+	return false, errors.Errorf("failed to scan the field %s: unsupported type %s", field.Name, field.FieldType)
+}
@@ -0,0 +1,143 @@
+package argonizegorm_test
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/KEINOS/go-argonize"
+	"github.com/KEINOS/go-argonize/argonizegorm"
+	"github.com/glebarez/sqlite"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+	"gorm.io/gorm/schema"
+)
+
+const sampleHashStr = "$argon2id$v=19$m=65536,t=3,p=2$Woo1mErn1s7AHf96ewQ8Uw$D4TzIwGO4XD2buk96qAP+Ed2baMo/KbTRMqXX00wtsU"
+
+type user struct {
+	ID       uint
+	Name     string
+	Password argonize.Hashed  `gorm:"serializer:argon2;type:varchar(255)"`
+	Previous *argonize.Hashed `gorm:"serializer:argon2;type:varchar(255)"`
+}
+
+// openDB returns a new in-memory sqlite database with the table of user.
+func openDB(t *testing.T) *gorm.DB {
+	t.Helper()
+
+	db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{Logger: logger.Discard})
+	require.NoError(t, err)
+
+	sqlDB, err := db.DB()
+	require.NoError(t, err)
+
+	// Each connection of ":memory:" is a separate database
+	sqlDB.SetMaxOpenConns(1)
+
+	t.Cleanup(func() {
+		_ = sqlDB.Close()
+	})
+
+	require.NoError(t, db.AutoMigrate(&user{}))
+
+	return db
+}
+
+// sampleHashed returns the decoded sampleHashStr.
+func sampleHashed(t *testing.T) *argonize.Hashed {
+	t.Helper()
+
+	hashed, err := argonize.DecodeHashStr(sampleHashStr)
+	require.NoError(t, err)
+
+	return hashed
+}
+
+// ----------------------------------------------------------------------------
+//  Serializer
+// ----------------------------------------------------------------------------
+
+func TestSerializer_registered(t *testing.T) {
+	t.Parallel()
+
+	serializer, ok := schema.GetSerializer(argonizegorm.Name)
+	require.True(t, ok, "it should be registered by importing the package")
+	require.IsType(t, argonizegorm.Serializer{}, serializer)
+}
+
+func TestSerializer_round_trip(t *testing.T) {
+	t.Parallel()
+
+	db := openDB(t)
+
+	hashed, err := argonize.HashCustomChecked([]byte("password"), nil,
+		&argonize.Params{Iterations: 1, KeyLength: 32, MemoryCost: 64, SaltLength: 16, Parallelism: 1})
+	require.NoError(t, err)
+
+	alice := user{Name: "alice", Password: *hashed, Previous: sampleHashed(t)}
+	bob := user{Name: "bob", Password: *sampleHashed(t)}
+
+	require.NoError(t, db.Create(&alice).Error)
+	require.NoError(t, db.Create(&bob).Error)
+
+	// It should be stored as the PHC string
+	var stored string
+
+	require.NoError(t, db.Raw("SELECT password FROM users WHERE name = ?", "alice").Scan(&stored).Error)
+	require.Equal(t, hashed.String(), stored)
+
+	var got user
+
+	require.NoError(t, db.First(&got, "name = ?", "alice").Error)
+	require.True(t, got.Password.IsValidPassword([]byte("password")))
+	require.NotNil(t, got.Previous)
+	require.Equal(t, sampleHashStr, got.Previous.String())
+
+	got = user{}
+
+	require.NoError(t, db.First(&got, "name = ?", "bob").Error)
+	require.Equal(t, sampleHashStr, got.Password.String())
+	require.Nil(t, got.Previous, "NULL should be loaded as nil")
+}
+
+func TestSerializer_Scan_invalid_hash(t *testing.T) {
+	t.Parallel()
+
+	db := openDB(t)
+
+	require.NoError(t, db.Exec("INSERT INTO users (name, password) VALUES (?, ?)", "mallory", "not a hash").Error)
+
+	var got user
+
+	err := db.First(&got, "name = ?", "mallory").Error
+	require.ErrorContains(t, err, "failed to scan the field Password")
+	require.ErrorContains(t, err, "failed to scan the hash")
+}
+
+func TestSerializer_Value_invalid_hash(t *testing.T) {
+	t.Parallel()
+
+	db := openDB(t)
+
+	err := db.Create(&user{Name: "eve"}).Error
+	require.ErrorContains(t, err, "failed to serialize the field Password")
+
+	var count int64
+
+	require.NoError(t, db.Model(&user{}).Count(&count).Error)
+	require.Zero(t, count, "broken hash should not be stored")
+}
+
+func TestSerializer_unsupported_type(t *testing.T) {
+	t.Parallel()
+
+	field := &schema.Field{Name: "Password", FieldType: reflect.TypeOf("")}
+
+	_, err := argonizegorm.Serializer{}.Value(context.Background(), field, reflect.Value{}, "plain")
+	require.ErrorContains(t, err, "failed to serialize the field Password: unsupported type string")
+
+	err = argonizegorm.Serializer{}.Scan(context.Background(), field, reflect.Value{}, sampleHashStr)
+	require.ErrorContains(t, err, "failed to scan the field Password: unsupported type string")
+}
@@ -0,0 +1,201 @@
+// Protocol Buffers schema of the hashed password of the go-argonize package.
+//
+// Other languages can generate their own bindings against this schema. The Go
+// bindings in this directory (argonize.pb.go) are generated from it with
+// protoc-gen-go. See "go generate" in argonizepb.go.
+
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.6
+// 	protoc        (unknown)
+// source: argonize.proto
+
+package argonizepb
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// HashedPassword is an Argon2 hashed password and its parameters.
+type HashedPassword struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Argon2 version. 19 (0x13) for the current version.
+	Version uint32 `protobuf:"varint,1,opt,name=version,proto3" json:"version,omitempty"`
+	// Algorithm variant. "argon2id" or "argon2i".
+	Variant string `protobuf:"bytes,2,opt,name=variant,proto3" json:"variant,omitempty"`
+	// Memory cost in KiB.
+	M uint32 `protobuf:"varint,3,opt,name=m,proto3" json:"m,omitempty"`
+	// Number of iterations (passes).
+	T uint32 `protobuf:"varint,4,opt,name=t,proto3" json:"t,omitempty"`
+	// Degree of parallelism (lanes). 1..255.
+	P uint32 `protobuf:"varint,5,opt,name=p,proto3" json:"p,omitempty"`
+	// Length of the hash in bytes. Must equal the length of the hash field.
+	KeyLength uint32 `protobuf:"varint,6,opt,name=key_length,json=keyLength,proto3" json:"key_length,omitempty"`
+	// Salt value.
+	Salt []byte `protobuf:"bytes,7,opt,name=salt,proto3" json:"salt,omitempty"`
+	// Hash value.
+	Hash          []byte `protobuf:"bytes,8,opt,name=hash,proto3" json:"hash,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *HashedPassword) Reset() {
+	*x = HashedPassword{}
+	mi := &file_argonize_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *HashedPassword) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*HashedPassword) ProtoMessage() {}
+
+func (x *HashedPassword) ProtoReflect() protoreflect.Message {
+	mi := &file_argonize_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use HashedPassword.ProtoReflect.Descriptor instead.
+func (*HashedPassword) Descriptor() ([]byte, []int) {
+	return file_argonize_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *HashedPassword) GetVersion() uint32 {
+	if x != nil {
+		return x.Version
+	}
+	return 0
+}
+
+func (x *HashedPassword) GetVariant() string {
+	if x != nil {
+		return x.Variant
+	}
+	return ""
+}
+
+func (x *HashedPassword) GetM() uint32 {
+	if x != nil {
+		return x.M
+	}
+	return 0
+}
+
+func (x *HashedPassword) GetT() uint32 {
+	if x != nil {
+		return x.T
+	}
+	return 0
+}
+
+func (x *HashedPassword) GetP() uint32 {
+	if x != nil {
+		return x.P
+	}
+	return 0
+}
+
+func (x *HashedPassword) GetKeyLength() uint32 {
+	if x != nil {
+		return x.KeyLength
+	}
+	return 0
+}
+
+func (x *HashedPassword) GetSalt() []byte {
+	if x != nil {
+		return x.Salt
+	}
+	return nil
+}
+
+func (x *HashedPassword) GetHash() []byte {
+	if x != nil {
+		return x.Hash
+	}
+	return nil
+}
+
+var File_argonize_proto protoreflect.FileDescriptor
+
+const file_argonize_proto_rawDesc = "" +
+	"\n" +
+	"\x0eargonize.proto\x12\vargonize.v1\"\xb5\x01\n" +
+	"\x0eHashedPassword\x12\x18\n" +
+	"\aversion\x18\x01 \x01(\rR\aversion\x12\x18\n" +
+	"\avariant\x18\x02 \x01(\tR\avariant\x12\f\n" +
+	"\x01m\x18\x03 \x01(\rR\x01m\x12\f\n" +
+	"\x01t\x18\x04 \x01(\rR\x01t\x12\f\n" +
+	"\x01p\x18\x05 \x01(\rR\x01p\x12\x1d\n" +
+	"\n" +
+	"key_length\x18\x06 \x01(\rR\tkeyLength\x12\x12\n" +
+	"\x04salt\x18\a \x01(\fR\x04salt\x12\x12\n" +
+	"\x04hash\x18\b \x01(\fR\x04hashB*Z(github.com/KEINOS/go-argonize/argonizepbb\x06proto3"
+
+var (
+	file_argonize_proto_rawDescOnce sync.Once
+	file_argonize_proto_rawDescData []byte
+)
+
+func file_argonize_proto_rawDescGZIP() []byte {
+	file_argonize_proto_rawDescOnce.Do(func() {
+		file_argonize_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_argonize_proto_rawDesc), len(file_argonize_proto_rawDesc)))
+	})
+	return file_argonize_proto_rawDescData
+}
+
+var file_argonize_proto_msgTypes = make([]protoimpl.MessageInfo, 1)
+var file_argonize_proto_goTypes = []any{
+	(*HashedPassword)(nil), // 0: argonize.v1.HashedPassword
+}
+var file_argonize_proto_depIdxs = []int32{
+	0, // [0:0] is the sub-list for method output_type
+	0, // [0:0] is the sub-list for method input_type
+	0, // [0:0] is the sub-list for extension type_name
+	0, // [0:0] is the sub-list for extension extendee
+	0, // [0:0] is the sub-list for field type_name
+}
+
+func init() { file_argonize_proto_init() }
+func file_argonize_proto_init() {
+	if File_argonize_proto != nil {
+		return
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_argonize_proto_rawDesc), len(file_argonize_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   1,
+			NumExtensions: 0,
+			NumServices:   0,
+		},
+		GoTypes:           file_argonize_proto_goTypes,
+		DependencyIndexes: file_argonize_proto_depIdxs,
+		MessageInfos:      file_argonize_proto_msgTypes,
+	}.Build()
+	File_argonize_proto = out.File
+	file_argonize_proto_goTypes = nil
+	file_argonize_proto_depIdxs = nil
+}
@@ -0,0 +1,99 @@
+/*
+Package argonizepb provides the Protocol Buffers message of argonize.Hashed and
+the conversion helpers.
+
+The schema is defined in argonize.proto in this directory, so other languages
+can generate their own bindings against it. The Go bindings, HashedPassword in
+argonize.pb.go, are generated from it with protoc-gen-go and implement
+proto.Message, so they can be used with proto.Marshal(), proto.Unmarshal() and
+the gRPC services directly.
+
+It is a separate module to keep the core argonize package free of Protocol
+Buffers.
+*/
+package argonizepb
+
+//go:generate protoc --go_out=. --go_opt=paths=source_relative argonize.proto
+
+import (
+	"math"
+
+	"github.com/KEINOS/go-argonize"
+	"github.com/pkg/errors"
+	"golang.org/x/crypto/argon2"
+)
+
+// ============================================================================
+//  Functions
+// ============================================================================
+
+// ToProto converts the Hashed object to a HashedPassword message.
+func ToProto(hashed *argonize.Hashed) *HashedPassword {
+	if hashed == nil || hashed.Params == nil {
+		return nil
+	}
+
+	return &HashedPassword{
+		Version:   argon2.Version,
+		Variant:   hashed.Params.Variant.String(),
+		M:         hashed.Params.MemoryCost,
+		T:         hashed.Params.Iterations,
+		P:         uint32(hashed.Params.Parallelism),
+		KeyLength: uint32(len(hashed.Hash)), //nolint:gosec // hash is never that long
+		Salt:      append([]byte{}, hashed.Salt...),
+		Hash:      append([]byte{}, hashed.Hash...),
+	}
+}
+
+// FromProto converts the HashedPassword message to a Hashed object. The message
+// is validated the same way as argonize.DecodeHashStr() does.
+func FromProto(msg *HashedPassword) (*argonize.Hashed, error) {
+	if msg == nil {
+		return nil, errors.New("failed to convert from proto: message is nil")
+	}
+
+	variant, err := argonize.ParseVariant(msg.Variant)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to convert from proto")
+	}
+
+	if msg.Version != argon2.Version {
+		return nil, errors.New("failed to convert from proto: incompatible version of Argon2")
+	}
+
+	if msg.M == 0 || msg.T == 0 || msg.P == 0 || msg.P > math.MaxUint8 {
+		return nil, errors.New("failed to convert from proto: missing or out of range parameters")
+	}
+
+	if int(msg.KeyLength) != len(msg.Hash) || len(msg.Hash) == 0 {
+		return nil, errors.Errorf(
+			"failed to convert from proto: key length %d does not match hash length %d",
+			msg.KeyLength, len(msg.Hash),
+		)
+	}
+
+	if len(msg.Salt) < int(argonize.SaltLengthMin) || len(msg.Salt) > math.MaxInt32 {
+		return nil, errors.New("failed to convert from proto: salt length is too long or too short")
+	}
+
+	params := argonize.NewParams()
+
+	params.Variant = variant
+	params.MemoryCost = msg.M
+	params.Iterations = msg.T
+	params.Parallelism = uint8(msg.P)
+	params.KeyLength = msg.KeyLength
+	params.SaltLength = uint32(len(msg.Salt))
+
+	hashed := &argonize.Hashed{
+		Params: params,
+		Salt:   argonize.Salt(append([]byte{}, msg.Salt...)),
+		Hash:   append([]byte{}, msg.Hash...),
+	}
+
+	if err := hashed.Validate(); err != nil {
+		return nil, errors.Wrap(err, "failed to convert from proto")
+	}
+
+	return hashed, nil
+}
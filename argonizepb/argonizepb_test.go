@@ -0,0 +1,150 @@
+package argonizepb_test
+
+import (
+	"encoding/hex"
+	"testing"
+
+	"github.com/KEINOS/go-argonize"
+	"github.com/KEINOS/go-argonize/argonizepb"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/proto"
+)
+
+const sampleHashStr = "$argon2id$v=19$m=65536,t=3,p=2$Woo1mErn1s7AHf96ewQ8Uw$D4TzIwGO4XD2buk96qAP+Ed2baMo/KbTRMqXX00wtsU"
+
+// fixtureWire is the proto3 wire encoding of sampleHashStr as a HashedPassword
+// message of argonize.proto, with the fields in the field number order.
+//
+//	08 13             version: 19
+//	12 08 617267…     variant: "argon2id"
+//	18 808004         m: 65536
+//	20 03             t: 3
+//	28 02             p: 2
+//	30 20             key_length: 32
+//	3a 10 5a8a…       salt (16 bytes)
+//	42 20 0f84…       hash (32 bytes)
+//
+//nolint:lll // long hex string
+const fixtureWire = "081312086172676f6e326964188080042003280230203a105a8a35984ae7d6cec01dff7a7b043c5342200f84f323018ee170f66ee93deaa00ff847766da328fca6d344ca975f4d30b6c5"
+
+// ----------------------------------------------------------------------------
+//  ToProto() and FromProto()
+// ----------------------------------------------------------------------------
+
+func TestToProto_round_trip(t *testing.T) {
+	t.Parallel()
+
+	hashedObj, err := argonize.DecodeHashStr(sampleHashStr)
+	require.NoError(t, err)
+
+	msg := argonizepb.ToProto(hashedObj)
+
+	wire, err := proto.MarshalOptions{Deterministic: true}.Marshal(msg)
+	require.NoError(t, err)
+	require.Equal(t, fixtureWire, hex.EncodeToString(wire))
+
+	var parsed argonizepb.HashedPassword
+
+	require.NoError(t, proto.Unmarshal(wire, &parsed))
+	require.True(t, proto.Equal(msg, &parsed))
+
+	decoded, err := argonizepb.FromProto(&parsed)
+	require.NoError(t, err)
+	require.Equal(t, sampleHashStr, decoded.String())
+}
+
+func TestToProto_nil(t *testing.T) {
+	t.Parallel()
+
+	require.Nil(t, argonizepb.ToProto(nil))
+	require.Nil(t, argonizepb.ToProto(new(argonize.Hashed)))
+}
+
+func TestFromProto_invalid(t *testing.T) {
+	t.Parallel()
+
+	hashedObj, err := argonize.DecodeHashStr(sampleHashStr)
+	require.NoError(t, err)
+
+	for _, test := range []struct {
+		modify     func(msg *argonizepb.HashedPassword)
+		msgContain string
+	}{
+		{func(msg *argonizepb.HashedPassword) { msg.Variant = "argon2d" }, `unsupported algorithm variant "argon2d"`},
+		{func(msg *argonizepb.HashedPassword) { msg.Version = 16 }, "incompatible version of Argon2"},
+		{func(msg *argonizepb.HashedPassword) { msg.M = 0 }, "missing or out of range parameters"},
+		{func(msg *argonizepb.HashedPassword) { msg.P = 256 }, "missing or out of range parameters"},
+		{func(msg *argonizepb.HashedPassword) { msg.KeyLength = 16 }, "key length 16 does not match hash length 32"},
+		{func(msg *argonizepb.HashedPassword) { msg.Salt = msg.Salt[:4] }, "salt length is too long or too short"},
+	} {
+		msg := argonizepb.ToProto(hashedObj)
+
+		test.modify(msg)
+
+		decoded, err := argonizepb.FromProto(msg)
+
+		require.Error(t, err)
+		require.Contains(t, err.Error(), test.msgContain)
+		require.Nil(t, decoded, "it should be nil on error")
+	}
+
+	decoded, err := argonizepb.FromProto(nil)
+
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "message is nil")
+	require.Nil(t, decoded, "it should be nil on error")
+}
+
+// ----------------------------------------------------------------------------
+//  HashedPassword with proto.Unmarshal()
+// ----------------------------------------------------------------------------
+
+func TestHashedPassword_unknown_fields(t *testing.T) {
+	t.Parallel()
+
+	// Unknown fields 9 (varint), 10 (bytes), 11 (fixed64) and 12 (fixed32)
+	// appended as a newer schema may produce.
+	data, err := hex.DecodeString(fixtureWire + "4801" + "52026869" + "590102030405060708" + "6501020304")
+	require.NoError(t, err)
+
+	var msg argonizepb.HashedPassword
+
+	require.NoError(t, proto.Unmarshal(data, &msg))
+
+	decoded, err := argonizepb.FromProto(&msg)
+	require.NoError(t, err)
+	require.Equal(t, sampleHashStr, decoded.String())
+
+	// The unknown fields should be kept for the newer schema
+	wire, err := proto.Marshal(&msg)
+	require.NoError(t, err)
+	require.Equal(t, data, wire)
+}
+
+func TestHashedPassword_bad_data(t *testing.T) {
+	t.Parallel()
+
+	for _, hexData := range []string{
+		"80",         // truncated tag
+		"08",         // truncated varint
+		"1205616263", // length exceeding the data
+		"5901",       // truncated fixed64
+		"0b",         // start group of the deprecated wire type
+	} {
+		data, err := hex.DecodeString(hexData)
+		require.NoError(t, err)
+
+		var msg argonizepb.HashedPassword
+
+		require.Error(t, proto.Unmarshal(data, &msg), hexData)
+	}
+}
+
+func TestHashedPassword_proto_message(t *testing.T) {
+	t.Parallel()
+
+	var msg proto.Message = new(argonizepb.HashedPassword)
+
+	require.Equal(t, "argonize.v1.HashedPassword", string(msg.ProtoReflect().Descriptor().FullName()))
+	require.Equal(t, "argonize.proto", msg.ProtoReflect().Descriptor().ParentFile().Path())
+}
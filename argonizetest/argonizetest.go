@@ -0,0 +1,98 @@
+/*
+Package argonizetest provides helpers for testing code that uses the argonize
+package.
+
+The functions in this package intentionally bypass the security checks of the
+argonize package, such as the minimum salt length. They exist to create
+fixtures like legacy or weak hashes and MUST NOT be used to hash real
+credentials.
+*/
+package argonizetest
+
+import (
+	"github.com/KEINOS/go-argonize"
+	"github.com/pkg/errors"
+	"golang.org/x/crypto/argon2"
+)
+
+// testSalt is the fixed salt of TestHashed(). It is 16 bytes long. It is also
+// the seed of the salts of TestHashedWithContext().
+const testSalt = "argonizetestsalt"
+
+// ============================================================================
+//  Functions
+// ============================================================================
+
+// TestHashed returns a valid Hashed object of the password computed with the
+// minimal parameters (m=8 KiB, t=1, p=1) and a fixed salt. It is cheap and
+// deterministic, so unit tests of the downstream code can create hashes without
+// paying the cost of the default parameters.
+//
+// The object passes Hashed.Validate() and round-trips through String() and
+// argonize.DecodeHashStr(). It panics if the hashing fails, which only happens
+// if the package-wide argonize.KDF fails.
+func TestHashed(password string) *argonize.Hashed {
+	return hashTest(password, []byte(testSalt))
+}
+
+// TestHashedWithContext is similar to TestHashed() but the salt is derived from
+// the context with argonize.NewDeterministicSalt(), so that the fixtures of the
+// same password, such as the golden files of different users, have distinct
+// but stable salts. The same password and context always give the same hash.
+func TestHashedWithContext(password, context string) *argonize.Hashed {
+	salt, err := argonize.NewDeterministicSalt([]byte(testSalt), context, uint32(len(testSalt)))
+	if err != nil {
+		panic(errors.Wrap(err, "failed to create the test hash"))
+	}
+
+	return hashTest(password, salt)
+}
+
+// HashInsecure returns a Hashed object from the password without checking the
+// salt length. It is the insecure override of argonize.HashWithSalt() to
+// create hashes with sub-minimum salts for testing purposes.
+func HashInsecure(password []byte, salt argonize.Salt, params *argonize.Params) *argonize.Hashed {
+	return &argonize.Hashed{
+		Params: params,
+		Salt:   salt,
+		Hash: argon2.IDKey(
+			password,
+			salt,
+			params.Iterations,
+			params.MemoryCost,
+			params.Parallelism,
+			params.KeyLength,
+		),
+	}
+}
+
+// NewInsecureSalt returns a random salt of any length, including the lengths
+// shorter than argonize.SaltLengthMin which argonize.NewSalt() rejects.
+func NewInsecureSalt(lenOut uint32) (argonize.Salt, error) {
+	salt, err := argonize.RandomBytes(lenOut)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to generate insecure salt")
+	}
+
+	return argonize.Salt(salt), nil
+}
+
+// ----------------------------------------------------------------------------
+//  Private Functions
+// ----------------------------------------------------------------------------
+
+// hashTest hashes the password with the salt and the minimal parameters of
+// TestHashed().
+func hashTest(password string, salt []byte) *argonize.Hashed {
+	params := argonize.NewParams()
+	params.MemoryCost = 8
+	params.Iterations = 1
+	params.Parallelism = 1
+
+	hashed, err := argonize.HashWithSalt([]byte(password), salt, params)
+	if err != nil {
+		panic(errors.Wrap(err, "failed to create the test hash"))
+	}
+
+	return hashed
+}
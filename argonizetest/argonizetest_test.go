@@ -0,0 +1,77 @@
+package argonizetest_test
+
+import (
+	"encoding/hex"
+	"testing"
+
+	"github.com/KEINOS/go-argonize"
+	"github.com/KEINOS/go-argonize/argonizetest"
+	"github.com/stretchr/testify/require"
+)
+
+// ----------------------------------------------------------------------------
+//  HashInsecure()
+// ----------------------------------------------------------------------------
+
+func TestHashInsecure_short_salt(t *testing.T) {
+	t.Parallel()
+
+	salt, err := argonizetest.NewInsecureSalt(4)
+	require.NoError(t, err)
+	require.Len(t, salt, 4)
+
+	params := argonize.NewParams()
+	params.MemoryCost = 8
+	params.Parallelism = 1
+
+	hashedObj := argonizetest.HashInsecure([]byte("password"), salt, params)
+
+	require.True(t, hashedObj.IsValidPassword([]byte("password")),
+		"short salted hashes should still be verifiable")
+	require.False(t, hashedObj.IsValidPassword([]byte("wrong password")))
+}
+
+// ----------------------------------------------------------------------------
+//  TestHashed()
+// ----------------------------------------------------------------------------
+
+func TestTestHashed(t *testing.T) {
+	t.Parallel()
+
+	hashedObj := argonizetest.TestHashed("password")
+
+	require.NoError(t, hashedObj.Validate())
+	require.True(t, hashedObj.IsValidPassword([]byte("password")))
+	require.False(t, hashedObj.IsValidPassword([]byte("wrong password")))
+
+	require.Equal(t, uint32(8), hashedObj.Params.MemoryCost)
+	require.Equal(t, uint32(1), hashedObj.Params.Iterations)
+	require.Equal(t, uint8(1), hashedObj.Params.Parallelism)
+
+	// Deterministic
+	require.Equal(t, hashedObj.String(), argonizetest.TestHashed("password").String())
+	require.NotEqual(t, hashedObj.String(), argonizetest.TestHashed("other").String())
+
+	decoded, err := argonize.DecodeHashStr(hashedObj.String())
+	require.NoError(t, err)
+	require.True(t, decoded.IsValidPassword([]byte("password")))
+}
+
+// ----------------------------------------------------------------------------
+//  TestHashedWithContext()
+// ----------------------------------------------------------------------------
+
+func TestTestHashedWithContext(t *testing.T) {
+	t.Parallel()
+
+	alice := argonizetest.TestHashedWithContext("password", "alice")
+
+	require.NoError(t, alice.Validate())
+	require.True(t, alice.IsValidPassword([]byte("password")))
+	require.Equal(t, "071760182f9482c205e38c379a83f1aa", hex.EncodeToString(alice.Salt), "salt should be pinned")
+
+	// Deterministic per context
+	require.Equal(t, alice.String(), argonizetest.TestHashedWithContext("password", "alice").String())
+	require.NotEqual(t, alice.Salt, argonizetest.TestHashedWithContext("password", "bob").Salt)
+	require.NotEqual(t, alice.Salt, argonizetest.TestHashed("password").Salt)
+}
@@ -0,0 +1,46 @@
+package argonize
+
+import (
+	"bytes"
+	"encoding/base64"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+// basicAuthScheme is the scheme of the HTTP Basic authentication.
+const basicAuthScheme = "Basic"
+
+// ----------------------------------------------------------------------------
+//  Methods of Hashed
+// ----------------------------------------------------------------------------
+
+// VerifyBasicAuth extracts the password from the value of an HTTP Basic
+// authentication header, such as "Basic dXNlcjpwYXNz", and returns true if it
+// matches the hash. The user ID is not checked. Look up the hash by the user ID
+// beforehand.
+//
+// The scheme is case-insensitive as in RFC 7617 and the credentials must be
+// the standard base64 of "user-id:password". It returns an error if the header
+// is malformed. It has no side effects and the decoded credentials are zeroed
+// before returning.
+func (h *Hashed) VerifyBasicAuth(header string) (bool, error) {
+	scheme, encoded, found := strings.Cut(strings.TrimSpace(header), " ")
+	if !found || !strings.EqualFold(scheme, basicAuthScheme) {
+		return false, errors.New("failed to verify basic auth: not a Basic authentication header")
+	}
+
+	credentials, err := base64.StdEncoding.DecodeString(strings.TrimSpace(encoded))
+	if err != nil {
+		return false, errors.Wrap(err, "failed to verify basic auth: malformed credentials")
+	}
+
+	defer clear(credentials)
+
+	idx := bytes.IndexByte(credentials, ':')
+	if idx < 0 {
+		return false, errors.New("failed to verify basic auth: missing ':' in the credentials")
+	}
+
+	return h.IsValidPassword(credentials[idx+1:]), nil
+}
@@ -0,0 +1,59 @@
+package argonize_test
+
+import (
+	"encoding/base64"
+	"testing"
+
+	"github.com/KEINOS/go-argonize"
+	"github.com/stretchr/testify/require"
+)
+
+// ----------------------------------------------------------------------------
+//  Hashed.VerifyBasicAuth()
+// ----------------------------------------------------------------------------
+
+func TestHashed_VerifyBasicAuth(t *testing.T) {
+	t.Parallel()
+
+	hashedObj := argonize.HashCustom([]byte("pass:word"), []byte("0123456789abcdef"), lowCostParams())
+	require.NotNil(t, hashedObj)
+
+	basic := func(credentials string) string {
+		return "Basic " + base64.StdEncoding.EncodeToString([]byte(credentials))
+	}
+
+	for header, expect := range map[string]bool{
+		basic("alice:pass:word"): true, // the password may contain ':'
+		basic("bob:pass:word"):   true, // the user ID is not checked
+		basic(":pass:word"):      true,
+		"basic " + base64.StdEncoding.EncodeToString([]byte("alice:pass:word")):     true, // case-insensitive scheme
+		"  BASIC   " + base64.StdEncoding.EncodeToString([]byte("alice:pass:word")): true,
+		basic("alice:wrong"): false,
+		basic("alice:"):      false,
+	} {
+		isValid, err := hashedObj.VerifyBasicAuth(header)
+		require.NoError(t, err, header)
+		require.Equal(t, expect, isValid, header)
+	}
+}
+
+func TestHashed_VerifyBasicAuth_malformed(t *testing.T) {
+	t.Parallel()
+
+	hashedObj := argonize.HashCustom([]byte("password"), []byte("0123456789abcdef"), lowCostParams())
+	require.NotNil(t, hashedObj)
+
+	for header, msgContain := range map[string]string{
+		"":                     "not a Basic authentication header",
+		"Basic":                "not a Basic authentication header",
+		"Bearer dG9rZW4=":      "not a Basic authentication header",
+		"Basicdm9pZA==":        "not a Basic authentication header",
+		"Basic %%%":            "malformed credentials",
+		"Basic YWxpY2U6cGFzcw": "malformed credentials", // unpadded
+		"Basic " + base64.StdEncoding.EncodeToString([]byte("alice")): "missing ':'",
+	} {
+		isValid, err := hashedObj.VerifyBasicAuth(header)
+		require.ErrorContains(t, err, msgContain, header)
+		require.False(t, isValid, header)
+	}
+}
@@ -0,0 +1,120 @@
+package argonize
+
+import (
+	"runtime"
+	"sync"
+
+	"github.com/pkg/errors"
+)
+
+// rehashBatchMemoryBudget is the total amount of memory in KiB that RehashBatch
+// may use for the concurrent hashings. Defaults to 1 GiB.
+const rehashBatchMemoryBudget = uint64(1024 * 1024)
+
+// ============================================================================
+//  Functions
+// ============================================================================
+
+// RehashBatch re-derives the hashes under the target parameters with a fresh
+// random salt. It is meant for admin migration chores, such as forced password
+// rotations where the (new) passwords are available.
+//
+// The hashes and passwords are parallel slices. If the password of an index is
+// nil, the hash of the index can not be re-derived and is returned as is. The
+// progress function, if not nil, is called after each item with the number of
+// processed items and the total. The calls are serialized.
+//
+// If some items fail, the error is a *BatchError of all the failed items and
+// the result still has the successful ones, with nil at the failed indexes.
+//
+// The number of concurrent hashings is bounded by the memory cost of the target
+// parameters, so that the batch uses at most 1 GiB of memory (or one hashing at
+// a time if the memory cost exceeds it).
+func RehashBatch(
+	hashes []*Hashed,
+	passwords [][]byte,
+	target *Params,
+	progress func(done, total int),
+) ([]*Hashed, error) {
+	if len(hashes) != len(passwords) {
+		return nil, errors.Errorf(
+			"failed to rehash batch: number of hashes %d and passwords %d mismatch",
+			len(hashes), len(passwords),
+		)
+	}
+
+	if target == nil {
+		return nil, errors.New("failed to rehash batch: target params are nil")
+	}
+
+	total := len(hashes)
+	result := make([]*Hashed, total)
+	errs := make([]error, total)
+	sem := make(chan struct{}, maxConcurrentRehash(target))
+
+	var (
+		wg   sync.WaitGroup
+		mu   sync.Mutex
+		done int
+	)
+
+	for index := range hashes {
+		wg.Add(1)
+
+		sem <- struct{}{}
+
+		go func(index int) {
+			defer func() {
+				<-sem
+
+				mu.Lock()
+
+				done++
+
+				if progress != nil {
+					progress(done, total)
+				}
+
+				mu.Unlock()
+				wg.Done()
+			}()
+
+			if passwords[index] == nil {
+				result[index] = hashes[index]
+
+				return
+			}
+
+			result[index], errs[index] = HashWithSalt(passwords[index], nil, target)
+		}(index)
+	}
+
+	wg.Wait()
+
+	return result, NewBatchError("rehash batch", errs)
+}
+
+// ----------------------------------------------------------------------------
+//  Private Functions
+// ----------------------------------------------------------------------------
+
+// maxConcurrentRehash returns the number of concurrent hashings that fit in the
+// memory budget of RehashBatch. It is at least 1 and at most GOMAXPROCS.
+func maxConcurrentRehash(target *Params) int {
+	numMax := runtime.GOMAXPROCS(0)
+
+	if target.MemoryCost == 0 {
+		return numMax
+	}
+
+	numFit := rehashBatchMemoryBudget / uint64(target.MemoryCost)
+
+	switch {
+	case numFit < 1:
+		return 1
+	case numFit < uint64(numMax):
+		return int(numFit)
+	default:
+		return numMax
+	}
+}
@@ -0,0 +1,114 @@
+package argonize_test
+
+import (
+	"testing"
+
+	"github.com/KEINOS/go-argonize"
+	"github.com/stretchr/testify/require"
+)
+
+// ----------------------------------------------------------------------------
+//  RehashBatch()
+// ----------------------------------------------------------------------------
+
+func TestRehashBatch(t *testing.T) {
+	t.Parallel()
+
+	oldParams := argonize.NewParams()
+	oldParams.MemoryCost = 8
+	oldParams.Parallelism = 1
+
+	target := argonize.NewParams()
+	target.MemoryCost = 16
+	target.Iterations = 2
+	target.Parallelism = 1
+
+	passwords := [][]byte{[]byte("pass1"), nil, []byte("pass3")}
+	hashes := make([]*argonize.Hashed, len(passwords))
+
+	for i := range hashes {
+		hashes[i] = argonize.HashCustom([]byte("old password"), nil, oldParams)
+	}
+
+	var (
+		calls  []int
+		totals []int
+	)
+
+	result, err := argonize.RehashBatch(hashes, passwords, target, func(done, total int) {
+		calls = append(calls, done)
+		totals = append(totals, total)
+	})
+
+	require.NoError(t, err)
+	require.Len(t, result, len(passwords))
+	require.Equal(t, []int{1, 2, 3}, calls, "progress should be reported for each item")
+	require.Equal(t, []int{3, 3, 3}, totals)
+
+	require.True(t, result[0].IsValidPassword([]byte("pass1")))
+	require.Equal(t, uint32(16), result[0].Params.MemoryCost, "it should use the target params")
+	require.Same(t, hashes[1], result[1], "items without password should be returned as is")
+	require.True(t, result[2].IsValidPassword([]byte("pass3")))
+	require.NotEqual(t, hashes[2].Salt, result[2].Salt, "it should use a fresh salt")
+}
+
+func TestRehashBatch_bad_args(t *testing.T) {
+	t.Parallel()
+
+	result, err := argonize.RehashBatch(make([]*argonize.Hashed, 2), nil, argonize.NewParams(), nil)
+
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "number of hashes 2 and passwords 0 mismatch")
+	require.Nil(t, result, "it should be nil on error")
+
+	result, err = argonize.RehashBatch(nil, nil, nil, nil)
+
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "target params are nil")
+	require.Nil(t, result, "it should be nil on error")
+
+	target := argonize.NewParams()
+	target.SaltLength = 4
+
+	result, err = argonize.RehashBatch(
+		[]*argonize.Hashed{nil}, [][]byte{[]byte("password")}, target, nil)
+
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "failed to rehash batch at index 0")
+	require.Equal(t, []*argonize.Hashed{nil}, result, "failed items should be nil")
+}
+
+func TestRehashBatch_partial_failure(t *testing.T) {
+	t.Parallel()
+
+	kept, err := argonize.HashCustomChecked([]byte("kept"), nil, lowCostParams())
+	require.NoError(t, err)
+
+	// The items with a password fail with the invalid target, while the ones
+	// without a password succeed by being returned as is.
+	hashes := []*argonize.Hashed{nil, kept, nil, kept}
+	passwords := [][]byte{[]byte("pass0"), nil, []byte("pass2"), nil}
+
+	badTarget := lowCostParams()
+	badTarget.Iterations = 0
+
+	result, err := argonize.RehashBatch(hashes, passwords, badTarget, nil)
+
+	var batchErr *argonize.BatchError
+
+	require.ErrorAs(t, err, &batchErr)
+	require.Len(t, batchErr.Errs, 2, "all the failures should be reported, not only the first")
+	require.Equal(t, 0, batchErr.Errs[0].Index)
+	require.Equal(t, 2, batchErr.Errs[1].Index)
+	require.ErrorIs(t, err, argonize.ErrIterationsTooLow, "errors.Is should work across the set")
+	require.ErrorIs(t, batchErr.Err(2), argonize.ErrIterationsTooLow)
+	require.NoError(t, batchErr.Err(1))
+
+	var indexErr *argonize.IndexError
+
+	require.ErrorAs(t, err, &indexErr)
+	require.Equal(t, 0, indexErr.Index)
+
+	// The successful results should be returned alongside
+	require.Equal(t, []*argonize.Hashed{nil, kept, nil, kept}, result)
+}
@@ -0,0 +1,104 @@
+package argonize
+
+import (
+	"fmt"
+	"strings"
+)
+
+// ============================================================================
+//  Type: IndexError
+// ============================================================================
+
+// IndexError is the error of an item of a batch operation.
+type IndexError struct {
+	// Err is the error of the item.
+	Err error
+	// Index is the 0-based index of the item in the batch.
+	Index int
+}
+
+// Error implements the error interface. E.g. "at index 3: the password is empty".
+func (e *IndexError) Error() string {
+	return fmt.Sprintf("at index %d: %v", e.Index, e.Err)
+}
+
+// Unwrap returns the error of the item.
+func (e *IndexError) Unwrap() error {
+	return e.Err
+}
+
+// ============================================================================
+//  Type: BatchError
+// ============================================================================
+
+// BatchError is the aggregate of the errors of a batch operation, such as
+// RehashBatch(). It implements Unwrap() []error like the errors of
+// errors.Join(), so errors.Is() and errors.As() work across all the items,
+// including *IndexError to find the failed index.
+type BatchError struct {
+	// Op is the operation of the batch, such as "rehash batch".
+	Op string
+	// Errs are the errors of the failed items in the order of the index.
+	Errs []*IndexError
+}
+
+// ----------------------------------------------------------------------------
+//  Constructor of BatchError
+// ----------------------------------------------------------------------------
+
+// NewBatchError returns a *BatchError of the non-nil errors of errs, which is a
+// slice parallel to the items of the batch, such as the one of
+// DecodeHashList(). It returns nil if all the errors are nil.
+func NewBatchError(op string, errs []error) error {
+	var indexErrs []*IndexError
+
+	for index, err := range errs {
+		if err != nil {
+			indexErrs = append(indexErrs, &IndexError{Index: index, Err: err})
+		}
+	}
+
+	if len(indexErrs) == 0 {
+		return nil
+	}
+
+	return &BatchError{Op: op, Errs: indexErrs}
+}
+
+// ----------------------------------------------------------------------------
+//  Methods of BatchError
+// ----------------------------------------------------------------------------
+
+// Err returns the error of the item at the index or nil if the item succeeded.
+func (e *BatchError) Err(index int) error {
+	for _, indexErr := range e.Errs {
+		if indexErr.Index == index {
+			return indexErr.Err
+		}
+	}
+
+	return nil
+}
+
+// Error implements the error interface. The errors of the items are separated
+// by newlines as in errors.Join(). E.g. "failed to rehash batch at index 3: ...".
+func (e *BatchError) Error() string {
+	lines := make([]string, len(e.Errs))
+
+	for i, indexErr := range e.Errs {
+		lines[i] = "failed to " + e.Op + " " + indexErr.Error()
+	}
+
+	return strings.Join(lines, "\n")
+}
+
+// Unwrap returns the *IndexError of each failed item.
+func (e *BatchError) Unwrap() []error {
+	errs := make([]error, len(e.Errs))
+
+	for i, indexErr := range e.Errs {
+		errs[i] = indexErr
+	}
+
+	return errs
+}
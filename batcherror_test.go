@@ -0,0 +1,60 @@
+package argonize_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/KEINOS/go-argonize"
+	"github.com/stretchr/testify/require"
+)
+
+// ----------------------------------------------------------------------------
+//  NewBatchError()
+// ----------------------------------------------------------------------------
+
+func TestNewBatchError(t *testing.T) {
+	t.Parallel()
+
+	errFoo := errors.New("foo")
+	errBar := errors.New("bar")
+
+	err := argonize.NewBatchError("do things", []error{nil, errFoo, nil, errBar})
+
+	require.EqualError(t, err, "failed to do things at index 1: foo\nfailed to do things at index 3: bar")
+	require.ErrorIs(t, err, errFoo)
+	require.ErrorIs(t, err, errBar)
+
+	var batchErr *argonize.BatchError
+
+	require.ErrorAs(t, err, &batchErr)
+	require.Equal(t, errFoo, batchErr.Err(1))
+	require.NoError(t, batchErr.Err(0))
+	require.NoError(t, batchErr.Err(99))
+	require.Len(t, batchErr.Unwrap(), 2)
+
+	require.NoError(t, argonize.NewBatchError("do things", []error{nil, nil}))
+	require.NoError(t, argonize.NewBatchError("do things", nil))
+}
+
+func TestNewBatchError_DecodeHashList(t *testing.T) {
+	t.Parallel()
+
+	data := []byte(sampleHashStr + "\nnot a hash\n" + sampleHashStr + "\n")
+
+	hashes, errs := argonize.DecodeHashList(data)
+	require.Len(t, hashes, 3)
+
+	err := argonize.NewBatchError("decode hash list", errs)
+	require.ErrorIs(t, err, argonize.ErrInvalidFormat)
+
+	var parseErr *argonize.ParseError
+
+	require.ErrorAs(t, err, &parseErr, "errors.As should reach the errors of the items")
+
+	var indexErr *argonize.IndexError
+
+	require.ErrorAs(t, err, &indexErr)
+	require.Equal(t, 1, indexErr.Index)
+	require.Nil(t, hashes[indexErr.Index])
+	require.NotNil(t, hashes[0], "successful results should be kept")
+}
@@ -0,0 +1,212 @@
+package argonize
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// ============================================================================
+//  Type: CalibrateOption
+// ============================================================================
+
+// CalibrateOption configures Calibrate() and CalibrateContext().
+type CalibrateOption func(*calibrateConfig)
+
+// calibrateConfig is the configuration of the calibration.
+type calibrateConfig struct {
+	memoryCost  uint32
+	parallelism uint8
+}
+
+// ----------------------------------------------------------------------------
+//  Constructors of CalibrateOption
+// ----------------------------------------------------------------------------
+
+// CalibrateMemory sets the memory cost in KiB of the calibration. The default
+// is MemoryCostDefault.
+func CalibrateMemory(kib uint32) CalibrateOption {
+	return func(c *calibrateConfig) {
+		c.memoryCost = kib
+	}
+}
+
+// CalibrateParallelism sets the parallelism of the calibration. The default is
+// ParallelismDefault.
+func CalibrateParallelism(parallelism uint8) CalibrateOption {
+	return func(c *calibrateConfig) {
+		c.parallelism = parallelism
+	}
+}
+
+// ============================================================================
+//  Type: PartialResult
+// ============================================================================
+
+// PartialResult is the error of CalibrateContext() stopped before the
+// calibration completed. It carries the best parameters found so far.
+type PartialResult struct {
+	// Params is the best parameters found so far. It is nil if no trial hash
+	// has completed within the target.
+	Params *Params
+	// Err is the reason of the stop, such as context.Canceled or
+	// context.DeadlineExceeded.
+	Err error
+}
+
+// Error implements the error interface.
+func (r *PartialResult) Error() string {
+	if r.Params == nil {
+		return fmt.Sprintf("calibration stopped with no result: %v", r.Err)
+	}
+
+	return fmt.Sprintf("calibration stopped at %s: %v", r.Params.EncodeParams(), r.Err)
+}
+
+// Unwrap returns the reason of the stop.
+func (r *PartialResult) Unwrap() error {
+	return r.Err
+}
+
+// ============================================================================
+//  Functions
+// ============================================================================
+
+// Calibrate is similar to CalibrateContext() but without a context.
+func Calibrate(target time.Duration, opts ...CalibrateOption) (*Params, error) {
+	return CalibrateContext(context.Background(), target, opts...)
+}
+
+// CalibrateContext measures trial hashes on the current machine and returns the
+// parameters with the most iterations that hash within the target duration.
+// The memory cost and parallelism are fixed by the options. If even a single
+// pass exceeds the target, the parameters with one iteration are returned.
+//
+// The context is checked between the trial hashes, and a trial whose expected
+// duration exceeds the remaining time until the deadline of the context is not
+// started. When the calibration stops early, it returns the best parameters
+// found so far along with a *PartialResult error wrapping the context error.
+//
+// Note that a trial hash cannot be interrupted. On cancellation it returns
+// promptly, but the running trial keeps the CPU and memory until it finishes in
+// the background.
+func CalibrateContext(ctx context.Context, target time.Duration, opts ...CalibrateOption) (*Params, error) {
+	if target <= 0 {
+		return nil, errors.Errorf("failed to calibrate: target duration %v must be positive", target)
+	}
+
+	config := calibrateConfig{
+		memoryCost:  MemoryCostDefault,
+		parallelism: ParallelismDefault,
+	}
+
+	for _, opt := range opts {
+		opt(&config)
+	}
+
+	params := NewParams()
+	params.MemoryCost = config.memoryCost
+	params.Parallelism = config.parallelism
+	params.Iterations = 1
+
+	if err := params.Validate(); err != nil {
+		return nil, errors.Wrap(err, "failed to calibrate")
+	}
+
+	var (
+		best    uint32 // the most iterations within the target so far
+		perPass time.Duration
+	)
+
+	partial := func(err error) (*Params, error) {
+		result := &PartialResult{Err: err}
+
+		if best > 0 {
+			result.Params = params.withIterations(best)
+		}
+
+		return result.Params, result
+	}
+
+	for {
+		if err := ctx.Err(); err != nil {
+			return partial(err)
+		}
+
+		if deadline, ok := ctx.Deadline(); ok && perPass > 0 &&
+			time.Until(deadline) < perPass*time.Duration(params.Iterations) {
+			return partial(context.DeadlineExceeded)
+		}
+
+		elapsed, err := trialHashContext(ctx, params)
+		if err != nil {
+			if ctxErr := ctx.Err(); ctxErr != nil {
+				return partial(ctxErr)
+			}
+
+			return nil, errors.Wrap(err, "failed to calibrate")
+		}
+
+		perPass = elapsed / time.Duration(params.Iterations)
+		next := iterationsFor(target, perPass)
+
+		if elapsed <= target {
+			best = params.Iterations
+			next = max(next, params.Iterations)
+		} else {
+			next = min(next, params.Iterations-1)
+		}
+
+		// Stop when the estimate has nowhere left to go between the best fit
+		// and the last trial.
+		if next <= best || next == params.Iterations {
+			break
+		}
+
+		params.Iterations = next
+	}
+
+	return params.withIterations(max(best, 1)), nil
+}
+
+// ----------------------------------------------------------------------------
+//  Methods of Params (Private)
+// ----------------------------------------------------------------------------
+
+// withIterations returns a copy of the params with the iterations.
+func (p *Params) withIterations(iterations uint32) *Params {
+	params := *p
+	params.Iterations = iterations
+
+	return &params
+}
+
+// ----------------------------------------------------------------------------
+//  Private Functions
+// ----------------------------------------------------------------------------
+
+// trialHashContext is similar to trialHash() but returns the context error as
+// soon as the context is done without waiting for the trial to finish.
+func trialHashContext(ctx context.Context, params *Params) (time.Duration, error) {
+	type result struct {
+		err     error
+		elapsed time.Duration
+	}
+
+	paramsCopy := *params
+	done := make(chan result, 1) // buffered so that an abandoned trial can exit
+
+	go func() {
+		elapsed, err := trialHash(&paramsCopy)
+		done <- result{elapsed: elapsed, err: err}
+	}()
+
+	select {
+	case res := <-done:
+		return res.elapsed, res.err
+	case <-ctx.Done():
+		return 0, ctx.Err()
+	}
+}
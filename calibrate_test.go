@@ -0,0 +1,113 @@
+package argonize_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/KEINOS/go-argonize"
+	"github.com/stretchr/testify/require"
+)
+
+// ----------------------------------------------------------------------------
+//  Calibrate()
+// ----------------------------------------------------------------------------
+
+func TestCalibrate(t *testing.T) {
+	t.Parallel()
+
+	params, err := argonize.Calibrate(20*time.Millisecond,
+		argonize.CalibrateMemory(256),
+		argonize.CalibrateParallelism(1),
+	)
+	require.NoError(t, err)
+	require.NoError(t, params.Validate())
+
+	require.Equal(t, uint32(256), params.MemoryCost, "memory should be fixed by the option")
+	require.Equal(t, uint8(1), params.Parallelism)
+	require.GreaterOrEqual(t, params.Iterations, uint32(1))
+}
+
+func TestCalibrate_bad_args(t *testing.T) {
+	t.Parallel()
+
+	params, err := argonize.Calibrate(0)
+	require.ErrorContains(t, err, "target duration 0s must be positive")
+	require.Nil(t, params)
+
+	params, err = argonize.Calibrate(time.Second, argonize.CalibrateParallelism(0))
+	require.ErrorIs(t, err, argonize.ErrParallelismTooLow)
+	require.Nil(t, params)
+
+	params, err = argonize.Calibrate(time.Second, argonize.CalibrateMemory(4), argonize.CalibrateParallelism(1))
+	require.ErrorIs(t, err, argonize.ErrMemoryCostTooLow)
+	require.Nil(t, params)
+}
+
+// ----------------------------------------------------------------------------
+//  CalibrateContext()
+// ----------------------------------------------------------------------------
+
+func TestCalibrateContext_canceled(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	params, err := argonize.CalibrateContext(ctx, time.Second)
+	require.ErrorIs(t, err, context.Canceled)
+	require.Nil(t, params)
+
+	var partial *argonize.PartialResult
+
+	require.ErrorAs(t, err, &partial)
+	require.Nil(t, partial.Params, "no trial should have completed")
+	require.EqualError(t, err, "calibration stopped with no result: context canceled")
+}
+
+func TestCalibrateContext_cancel_mid_calibration(t *testing.T) {
+	t.Parallel()
+
+	// A single pass of 256 MiB takes far longer than the timeout
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+
+	start := time.Now()
+
+	params, err := argonize.CalibrateContext(ctx, time.Minute, argonize.CalibrateMemory(256*1024))
+
+	require.Less(t, time.Since(start), 500*time.Millisecond, "it should return promptly")
+	require.ErrorIs(t, err, context.DeadlineExceeded)
+	require.Nil(t, params)
+}
+
+func TestCalibrateContext_deadline_guard(t *testing.T) {
+	t.Parallel()
+
+	const timeout = time.Second
+
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
+	start := time.Now()
+
+	// The first pass fits, but many more passes for the one hour target would
+	// exceed the deadline, so the next trial should not be started.
+	params, err := argonize.CalibrateContext(ctx, time.Hour,
+		argonize.CalibrateMemory(64*1024),
+		argonize.CalibrateParallelism(1),
+	)
+
+	require.Less(t, time.Since(start), timeout, "it should not run into the deadline")
+	require.ErrorIs(t, err, context.DeadlineExceeded)
+
+	var partial *argonize.PartialResult
+
+	require.True(t, errors.As(err, &partial))
+	require.NotNil(t, params, "it should return the best params found so far")
+	require.Equal(t, params, partial.Params)
+	require.Equal(t, uint32(1), params.Iterations)
+	require.Equal(t, uint32(64*1024), params.MemoryCost)
+	require.EqualError(t, err, "calibration stopped at m=65536,t=1,p=1: context deadline exceeded")
+}
@@ -0,0 +1,56 @@
+package argonize
+
+import (
+	"math"
+	"math/bits"
+
+	"github.com/pkg/errors"
+)
+
+// ============================================================================
+//  Functions
+// ============================================================================
+
+// MemoryBudget returns the total memory in bytes needed to run the given number
+// of the Argon2 key derivations concurrently with the parameters. That is,
+// params.MemoryCost * 1024 * concurrent.
+//
+// Use it for capacity planning of the server. It returns an error if params is
+// nil, if concurrent is negative or if the result overflows uint64.
+func MemoryBudget(params *Params, concurrent int) (uint64, error) {
+	if params == nil {
+		return 0, errors.New("failed to calculate memory budget: params are nil")
+	}
+
+	if concurrent < 0 {
+		return 0, errors.Errorf("failed to calculate memory budget: negative concurrency %d", concurrent)
+	}
+
+	perHash := uint64(params.MemoryCost) * 1024
+
+	hi, total := bits.Mul64(perHash, uint64(concurrent))
+	if hi != 0 {
+		return 0, errors.Errorf(
+			"failed to calculate memory budget: %d KiB * %d overflows uint64",
+			params.MemoryCost, concurrent,
+		)
+	}
+
+	return total, nil
+}
+
+// MaxConcurrent returns how many Argon2 key derivations with the parameters fit
+// in the memory budget in bytes. E.g. 64 for 64 MiB of memory cost and 4 GiB
+// of budget.
+//
+// It returns 0 if params is nil or the memory cost is zero. The result is
+// capped to the maximum int.
+func MaxConcurrent(params *Params, budgetBytes uint64) int {
+	if params == nil || params.MemoryCost == 0 {
+		return 0
+	}
+
+	count := budgetBytes / (uint64(params.MemoryCost) * 1024)
+
+	return int(min(count, math.MaxInt)) //nolint:gosec // capped to the maximum int
+}
@@ -0,0 +1,88 @@
+package argonize_test
+
+import (
+	"math"
+	"testing"
+
+	"github.com/KEINOS/go-argonize"
+	"github.com/stretchr/testify/require"
+)
+
+// ----------------------------------------------------------------------------
+//  MemoryBudget()
+// ----------------------------------------------------------------------------
+
+func TestMemoryBudget(t *testing.T) {
+	t.Parallel()
+
+	params := argonize.NewParams() // 64 MiB
+
+	for _, test := range []struct {
+		concurrent int
+		expect     uint64
+	}{
+		{0, 0},
+		{1, 64 * 1024 * 1024},
+		{64, 4 * 1024 * 1024 * 1024},
+	} {
+		budget, err := argonize.MemoryBudget(params, test.concurrent)
+
+		require.NoError(t, err)
+		require.Equal(t, test.expect, budget, test.concurrent)
+	}
+}
+
+func TestMemoryBudget_overflow(t *testing.T) {
+	t.Parallel()
+
+	params := argonize.NewParams()
+	params.MemoryCost = math.MaxUint32
+
+	perHash := uint64(math.MaxUint32) * 1024
+	maxConcurrent := int(math.MaxUint64 / perHash)
+
+	budget, err := argonize.MemoryBudget(params, maxConcurrent)
+
+	require.NoError(t, err, "the largest concurrency that fits should not overflow")
+	require.Equal(t, perHash*uint64(maxConcurrent), budget)
+
+	budget, err = argonize.MemoryBudget(params, maxConcurrent+1)
+
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "overflows uint64")
+	require.Zero(t, budget)
+}
+
+func TestMemoryBudget_bad_args(t *testing.T) {
+	t.Parallel()
+
+	_, err := argonize.MemoryBudget(nil, 1)
+	require.ErrorContains(t, err, "params are nil")
+
+	_, err = argonize.MemoryBudget(argonize.NewParams(), -1)
+	require.ErrorContains(t, err, "negative concurrency -1")
+}
+
+// ----------------------------------------------------------------------------
+//  MaxConcurrent()
+// ----------------------------------------------------------------------------
+
+func TestMaxConcurrent(t *testing.T) {
+	t.Parallel()
+
+	params := argonize.NewParams() // 64 MiB
+
+	require.Equal(t, 64, argonize.MaxConcurrent(params, 4*1024*1024*1024))
+	require.Equal(t, 63, argonize.MaxConcurrent(params, 4*1024*1024*1024-1))
+	require.Equal(t, 0, argonize.MaxConcurrent(params, 64*1024*1024-1))
+	require.Equal(t, 0, argonize.MaxConcurrent(nil, math.MaxUint64))
+
+	params.MemoryCost = 0
+
+	require.Equal(t, 0, argonize.MaxConcurrent(params, math.MaxUint64))
+
+	// Largest budget with the smallest memory cost
+	params.MemoryCost = 1
+
+	require.Equal(t, int(min(uint64(math.MaxUint64)/1024, math.MaxInt)), argonize.MaxConcurrent(params, math.MaxUint64))
+}
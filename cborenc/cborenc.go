@@ -0,0 +1,335 @@
+/*
+Package cborenc provides CBOR (RFC 8949) encoding and decoding of argonize.Hashed.
+
+It is a separate package to keep the core argonize package free of any CBOR
+specifics. It depends on no third-party CBOR library: the layout is small and
+fixed, so it is encoded and decoded by hand.
+
+# Layout
+
+A Hashed object is encoded as a CBOR map with unsigned integer keys. The
+encoding follows the "core deterministic encoding" of RFC 8949 section 4.2:
+the keys are sorted and all the integers and lengths use the shortest form.
+
+	{
+	  1: "argon2id",  ; text string, algorithm variant ("argon2id" or "argon2i")
+	  2: 19,          ; unsigned int, Argon2 version
+	  3: 65536,       ; unsigned int, memory cost in KiB (m)
+	  4: 3,           ; unsigned int, iterations (t)
+	  5: 2,           ; unsigned int, parallelism (p)
+	  6: h'5a8a…',    ; byte string, salt
+	  7: h'0f84…'     ; byte string, hash
+	}
+
+Any other CBOR encoder can produce and read this layout. Decoding rejects
+unknown or duplicate keys, indefinite lengths and missing fields, and validates
+the values the same way as argonize.DecodeHashStr() does.
+*/
+package cborenc
+
+import (
+	"math"
+
+	"github.com/KEINOS/go-argonize"
+	"github.com/pkg/errors"
+	"golang.org/x/crypto/argon2"
+)
+
+// Map keys of the CBOR layout.
+const (
+	KeyAlgorithm uint64 = iota + 1
+	KeyVersion
+	KeyMemoryCost
+	KeyIterations
+	KeyParallelism
+	KeySalt
+	KeyHash
+)
+
+// CBOR major types used in the layout.
+const (
+	majorUint  byte = 0
+	majorBytes byte = 2
+	majorText  byte = 3
+	majorMap   byte = 5
+)
+
+// numKeys is the number of entries in the CBOR map.
+const numKeys = 7
+
+// ============================================================================
+//  Functions
+// ============================================================================
+
+// Marshal returns the CBOR encoding of the Hashed object.
+func Marshal(hashed *argonize.Hashed) ([]byte, error) {
+	if hashed == nil || hashed.Params == nil {
+		return nil, errors.New("failed to CBOR encode the hash: params are nil")
+	}
+
+	if len(hashed.Hash) == 0 {
+		return nil, errors.New("failed to CBOR encode the hash: hash value is empty")
+	}
+
+	if hashed.KeyID != "" {
+		return nil, errors.New("failed to CBOR encode the hash: key ID is not supported")
+	}
+
+	if hashed.Data != "" {
+		return nil, errors.New("failed to CBOR encode the hash: data is not supported")
+	}
+
+	out := appendHead(nil, majorMap, numKeys)
+
+	out = appendHead(out, majorUint, KeyAlgorithm)
+	out = appendHead(out, majorText, uint64(len(hashed.Params.Variant.String())))
+	out = append(out, hashed.Params.Variant.String()...)
+
+	out = appendHead(out, majorUint, KeyVersion)
+	out = appendHead(out, majorUint, argon2.Version)
+
+	out = appendHead(out, majorUint, KeyMemoryCost)
+	out = appendHead(out, majorUint, uint64(hashed.Params.MemoryCost))
+
+	out = appendHead(out, majorUint, KeyIterations)
+	out = appendHead(out, majorUint, uint64(hashed.Params.Iterations))
+
+	out = appendHead(out, majorUint, KeyParallelism)
+	out = appendHead(out, majorUint, uint64(hashed.Params.Parallelism))
+
+	out = appendHead(out, majorUint, KeySalt)
+	out = appendHead(out, majorBytes, uint64(len(hashed.Salt)))
+	out = append(out, hashed.Salt...)
+
+	out = appendHead(out, majorUint, KeyHash)
+	out = appendHead(out, majorBytes, uint64(len(hashed.Hash)))
+	out = append(out, hashed.Hash...)
+
+	return out, nil
+}
+
+// Unmarshal decodes the CBOR encoded data into a Hashed object. The data may
+// come from Marshal() or any other CBOR encoder following the layout.
+func Unmarshal(data []byte) (*argonize.Hashed, error) {
+	dec := decoder{data: data}
+
+	hashed, err := dec.decodeHashed()
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to CBOR decode the hash")
+	}
+
+	return hashed, nil
+}
+
+// ============================================================================
+//  Private
+// ============================================================================
+
+// appendHead appends the CBOR head of the major type with the argument in its
+// shortest form.
+func appendHead(out []byte, major byte, arg uint64) []byte {
+	const (
+		maxDirect = 23
+		follow1   = 24
+		follow2   = 25
+		follow4   = 26
+		follow8   = 27
+	)
+
+	major <<= 5
+
+	switch {
+	case arg <= maxDirect:
+		return append(out, major|byte(arg))
+	case arg <= math.MaxUint8:
+		return append(out, major|follow1, byte(arg))
+	case arg <= math.MaxUint16:
+		return append(out, major|follow2, byte(arg>>8), byte(arg))
+	case arg <= math.MaxUint32:
+		return append(out, major|follow4,
+			byte(arg>>24), byte(arg>>16), byte(arg>>8), byte(arg))
+	default:
+		return append(out, major|follow8,
+			byte(arg>>56), byte(arg>>48), byte(arg>>40), byte(arg>>32),
+			byte(arg>>24), byte(arg>>16), byte(arg>>8), byte(arg))
+	}
+}
+
+// decoder reads the CBOR items of the layout from data.
+type decoder struct {
+	data []byte
+	pos  int
+}
+
+// decodeHashed reads the whole map and returns the validated Hashed object.
+func (d *decoder) decodeHashed() (*argonize.Hashed, error) {
+	lenMap, err := d.readHead(majorMap)
+	if err != nil {
+		return nil, err
+	}
+
+	if lenMap != numKeys {
+		return nil, errors.Errorf("map has %d entries, want %d", lenMap, numKeys)
+	}
+
+	var (
+		seen    = make(map[uint64]bool, numKeys)
+		values  = make(map[uint64]uint64, numKeys)
+		salt    []byte
+		hash    []byte
+		algName []byte
+	)
+
+	for range numKeys {
+		key, err := d.readHead(majorUint)
+		if err != nil {
+			return nil, errors.Wrap(err, "bad map key")
+		}
+
+		if seen[key] {
+			return nil, errors.Errorf("duplicate map key %d", key)
+		}
+
+		seen[key] = true
+
+		switch key {
+		case KeyAlgorithm:
+			algName, err = d.readString(majorText)
+		case KeySalt:
+			salt, err = d.readString(majorBytes)
+		case KeyHash:
+			hash, err = d.readString(majorBytes)
+		case KeyVersion, KeyMemoryCost, KeyIterations, KeyParallelism:
+			values[key], err = d.readHead(majorUint)
+		default:
+			return nil, errors.Errorf("unknown map key %d", key)
+		}
+
+		if err != nil {
+			return nil, errors.Wrapf(err, "bad value of map key %d", key)
+		}
+	}
+
+	if d.pos != len(d.data) {
+		return nil, errors.New("trailing data after the map")
+	}
+
+	variant, err := argonize.ParseVariant(string(algName))
+	if err != nil {
+		return nil, err
+	}
+
+	return newHashed(variant, values, salt, hash)
+}
+
+// readHead reads a CBOR head of the expected major type and returns its
+// argument. Indefinite lengths are not supported.
+func (d *decoder) readHead(wantMajor byte) (uint64, error) {
+	if d.pos >= len(d.data) {
+		return 0, errors.New("unexpected end of data")
+	}
+
+	initial := d.data[d.pos]
+	major, info := initial>>5, initial&0x1f
+	d.pos++
+
+	if major != wantMajor {
+		return 0, errors.Errorf("unexpected major type %d, want %d", major, wantMajor)
+	}
+
+	const maxDirect = 23
+
+	if info <= maxDirect {
+		return uint64(info), nil
+	}
+
+	var lenArg int
+
+	switch info {
+	case 24:
+		lenArg = 1
+	case 25:
+		lenArg = 2
+	case 26:
+		lenArg = 4
+	case 27:
+		lenArg = 8
+	default:
+		return 0, errors.Errorf("unsupported additional information %d", info)
+	}
+
+	if len(d.data)-d.pos < lenArg {
+		return 0, errors.New("unexpected end of data")
+	}
+
+	var arg uint64
+
+	for _, b := range d.data[d.pos : d.pos+lenArg] {
+		arg = arg<<8 | uint64(b)
+	}
+
+	d.pos += lenArg
+
+	return arg, nil
+}
+
+// readString reads a byte or text string of the given major type.
+func (d *decoder) readString(major byte) ([]byte, error) {
+	lenStr, err := d.readHead(major)
+	if err != nil {
+		return nil, err
+	}
+
+	if uint64(len(d.data)-d.pos) < lenStr {
+		return nil, errors.New("unexpected end of data")
+	}
+
+	out := append([]byte{}, d.data[d.pos:d.pos+int(lenStr)]...)
+	d.pos += int(lenStr)
+
+	return out, nil
+}
+
+// newHashed validates the decoded values and returns the Hashed object.
+func newHashed(
+	variant argonize.Variant, values map[uint64]uint64, salt, hash []byte,
+) (*argonize.Hashed, error) {
+	if values[KeyVersion] != argon2.Version {
+		return nil, errors.New("incompatible version of Argon2")
+	}
+
+	if values[KeyMemoryCost] > math.MaxUint32 || values[KeyIterations] > math.MaxUint32 ||
+		values[KeyParallelism] > math.MaxUint8 {
+		return nil, errors.New("parameter value overflows")
+	}
+
+	if values[KeyMemoryCost] == 0 || values[KeyIterations] == 0 || values[KeyParallelism] == 0 {
+		return nil, errors.New("missing parameters in the hash")
+	}
+
+	if len(salt) < int(argonize.SaltLengthMin) || len(hash) == 0 ||
+		len(salt) > math.MaxInt32 || len(hash) > math.MaxInt32 {
+		return nil, errors.New("hash or salt length is too long or too short")
+	}
+
+	params := argonize.NewParams()
+
+	params.Variant = variant
+	params.MemoryCost = uint32(values[KeyMemoryCost])
+	params.Iterations = uint32(values[KeyIterations])
+	params.Parallelism = uint8(values[KeyParallelism])
+	params.SaltLength = uint32(len(salt))
+	params.KeyLength = uint32(len(hash))
+
+	hashed := &argonize.Hashed{
+		Params: params,
+		Salt:   argonize.Salt(salt),
+		Hash:   hash,
+	}
+
+	if err := hashed.Validate(); err != nil {
+		return nil, err
+	}
+
+	return hashed, nil
+}
@@ -0,0 +1,159 @@
+package cborenc_test
+
+import (
+	"encoding/hex"
+	"testing"
+
+	"github.com/KEINOS/go-argonize"
+	"github.com/KEINOS/go-argonize/cborenc"
+	"github.com/stretchr/testify/require"
+)
+
+const sampleHashStr = "$argon2id$v=19$m=65536,t=3,p=2$Woo1mErn1s7AHf96ewQ8Uw$D4TzIwGO4XD2buk96qAP+Ed2baMo/KbTRMqXX00wtsU"
+
+// fixtureCanonical is the expected output of _tests/cbor_fixture.py (Python's
+// cbor2 with canonical=True) for sampleHashStr.
+//
+//	a7                  map(7)
+//	  01 68 617267…     1: "argon2id"
+//	  02 13             2: 19
+//	  03 1a 00010000    3: 65536
+//	  04 03             4: 3
+//	  05 02             5: 2
+//	  06 50 5a8a…       6: h'5a8a…' (16 bytes)
+//	  07 58 20 0f84…    7: h'0f84…' (32 bytes)
+//
+//nolint:lll // long hex string
+const fixtureCanonical = "a701686172676f6e3269640213031a000100000403050206505a8a35984ae7d6cec01dff7a7b043c530758200f84f323018ee170f66ee93deaa00ff847766da328fca6d344ca975f4d30b6c5"
+
+// fixtureUnordered is the same object with the keys in reverse order and the
+// iterations encoded in a non-shortest 8-byte form, as a non-deterministic
+// encoder may produce.
+//
+//nolint:lll // long hex string
+const fixtureUnordered = "a70758200f84f323018ee170f66ee93deaa00ff847766da328fca6d344ca975f4d30b6c506505a8a35984ae7d6cec01dff7a7b043c530502041b0000000000000003031a00010000021301686172676f6e326964"
+
+// ----------------------------------------------------------------------------
+//  Marshal()
+// ----------------------------------------------------------------------------
+
+func TestMarshal(t *testing.T) {
+	t.Parallel()
+
+	hashedObj, err := argonize.DecodeHashStr(sampleHashStr)
+	require.NoError(t, err)
+
+	encoded, err := cborenc.Marshal(hashedObj)
+	require.NoError(t, err)
+	require.Equal(t, fixtureCanonical, hex.EncodeToString(encoded),
+		"it should produce the same bytes as other deterministic CBOR encoders")
+}
+
+func TestMarshal_empty(t *testing.T) {
+	t.Parallel()
+
+	encoded, err := cborenc.Marshal(nil)
+
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "params are nil")
+	require.Nil(t, encoded)
+
+	encoded, err = cborenc.Marshal(&argonize.Hashed{Params: argonize.NewParams()})
+
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "hash value is empty")
+	require.Nil(t, encoded)
+
+	encoded, err = cborenc.Marshal(&argonize.Hashed{Params: argonize.NewParams(), Hash: []byte("hash"), KeyID: "v1"})
+
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "key ID is not supported")
+	require.Nil(t, encoded)
+}
+
+// ----------------------------------------------------------------------------
+//  Unmarshal()
+// ----------------------------------------------------------------------------
+
+func TestUnmarshal_fixtures(t *testing.T) {
+	t.Parallel()
+
+	for _, fixture := range []string{fixtureCanonical, fixtureUnordered} {
+		data, err := hex.DecodeString(fixture)
+		require.NoError(t, err)
+
+		hashedObj, err := cborenc.Unmarshal(data)
+		require.NoError(t, err)
+		require.Equal(t, sampleHashStr, hashedObj.String())
+	}
+}
+
+func TestUnmarshal_round_trip(t *testing.T) {
+	t.Parallel()
+
+	params := argonize.NewParams()
+	params.MemoryCost = 8
+	params.Parallelism = 1
+
+	hashedObj := argonize.HashCustom([]byte("password"), nil, params)
+
+	encoded, err := cborenc.Marshal(hashedObj)
+	require.NoError(t, err)
+
+	decoded, err := cborenc.Unmarshal(encoded)
+	require.NoError(t, err)
+
+	require.True(t, decoded.IsValidPassword([]byte("password")))
+	require.False(t, decoded.IsValidPassword([]byte("wrong password")))
+}
+
+func TestUnmarshal_bad_data(t *testing.T) {
+	t.Parallel()
+
+	for _, test := range []struct {
+		hexData    string
+		msgContain string
+	}{
+		{"", "unexpected end of data"},
+		{"a6", "map has 6 entries, want 7"},
+		{"bf", "unsupported additional information 31"},
+		{"80", "unexpected major type 4, want 5"},
+		{"a7" + "01", "unexpected end of data"},
+		{"a7" + "0102", "bad value of map key 1"},
+		{"a7" + "08", "unknown map key 8"},
+		{"a7" + "0213" + "0213", "duplicate map key 2"},
+		{"a7" + "0650", "bad value of map key 6: unexpected end of data"},
+		{fixtureCanonical + "00", "trailing data after the map"},
+		// version 18
+		{replace(fixtureCanonical, "0213", "0212"), "incompatible version of Argon2"},
+		// memory cost 0
+		{replace(fixtureCanonical, "031a00010000", "031a00000000"), "missing parameters in the hash"},
+		// parallelism 256
+		{replace(fixtureCanonical, "050206", "0519010006"), "parameter value overflows"},
+		// algorithm "argon2xx"
+		{replace(fixtureCanonical, "6964", "7878"), `unsupported algorithm variant "argon2xx"`},
+		// salt of 4 bytes
+		{replace(fixtureCanonical, "06505a8a35984ae7d6cec01dff7a7b043c53", "06445a8a3598"), "too long or too short"},
+	} {
+		data, err := hex.DecodeString(test.hexData)
+		require.NoError(t, err, test.hexData)
+
+		hashedObj, err := cborenc.Unmarshal(data)
+
+		require.Error(t, err, test.hexData)
+		require.Contains(t, err.Error(), "failed to CBOR decode the hash")
+		require.Contains(t, err.Error(), test.msgContain, test.hexData)
+		require.Nil(t, hashedObj, "it should be nil on error")
+	}
+}
+
+// replace replaces the first occurrence of old with replacement in s.
+func replace(s, old, replacement string) string {
+	for i := 0; i+len(old) <= len(s); i += 2 {
+		if s[i:i+len(old)] == old {
+			return s[:i] + replacement + s[i+len(old):]
+		}
+	}
+
+	panic("fixture not found: " + old)
+}
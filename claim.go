@@ -0,0 +1,50 @@
+package argonize
+
+import (
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+// ============================================================================
+//  Functions
+// ============================================================================
+
+// FromClaim decodes the claim value returned by Hashed.Claim() into a Hashed
+// object. It is similar to DecodeHashStr() but rejects the standard base64
+// alphabet ("+" and "/") and surrounding whitespace, so that a value which was
+// not made by Claim() is not accepted by accident.
+func FromClaim(s string) (*Hashed, error) {
+	if strings.ContainsAny(s, "+/ \t\r\n") {
+		return nil, errors.New("failed to decode the claim: not a URL-safe claim value")
+	}
+
+	hashed, err := DecodeHashStr(s)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to decode the claim")
+	}
+
+	return hashed, nil
+}
+
+// ----------------------------------------------------------------------------
+//  Methods of Hashed
+// ----------------------------------------------------------------------------
+
+// Claim returns the hash as a value of a JWT claim. It is the same string as
+// StringURLSafe(), that is the URL-safe base64 alphabet without padding, which
+// needs no escaping in JSON and URLs. Use FromClaim() to decode it. It returns
+// an empty string if h or its Params is nil.
+//
+// Note that the hash in a token is a verifier and not a secret. The claims of a
+// signed JWT are readable by anyone holding the token, so treat the hash as
+// exposed to offline guessing. Its strength relies on the parameters and the
+// password, not on the token being kept secret, and encrypting the token does
+// not make up for weak parameters.
+func (h *Hashed) Claim() string {
+	if h == nil || h.Params == nil {
+		return ""
+	}
+
+	return h.StringURLSafe()
+}
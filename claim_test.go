@@ -0,0 +1,75 @@
+package argonize_test
+
+import (
+	"encoding/json"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/KEINOS/go-argonize"
+	"github.com/stretchr/testify/require"
+)
+
+// ----------------------------------------------------------------------------
+//  Hashed.Claim()
+// ----------------------------------------------------------------------------
+
+func TestHashed_Claim(t *testing.T) {
+	t.Parallel()
+
+	hashedObj, err := argonize.HashCustomChecked([]byte("password"), nil, lowCostParams())
+	require.NoError(t, err)
+
+	claim := hashedObj.Claim()
+
+	require.NotContains(t, claim, "+")
+	require.NotContains(t, claim, "/")
+	require.True(t, strings.HasPrefix(claim, "$argon2id$v=19$m=64,t=1,p=1$"))
+
+	// It should need no escaping in JSON
+	encoded, err := json.Marshal(map[string]string{"pwv": claim})
+	require.NoError(t, err)
+	require.Equal(t, `{"pwv":"`+claim+`"}`, string(encoded))
+
+	// and survive the URL query encoding
+	query, err := url.ParseQuery(url.Values{"pwv": {claim}}.Encode())
+	require.NoError(t, err)
+	require.Equal(t, claim, query.Get("pwv"))
+
+	decoded, err := argonize.FromClaim(claim)
+	require.NoError(t, err)
+	require.Equal(t, hashedObj.String(), decoded.String())
+	require.True(t, decoded.IsValidPassword([]byte("password")))
+}
+
+func TestHashed_Claim_nil(t *testing.T) {
+	t.Parallel()
+
+	var hashedObj *argonize.Hashed
+
+	require.Empty(t, hashedObj.Claim())
+	require.Empty(t, new(argonize.Hashed).Claim())
+}
+
+// ----------------------------------------------------------------------------
+//  FromClaim()
+// ----------------------------------------------------------------------------
+
+func TestFromClaim_invalid(t *testing.T) {
+	t.Parallel()
+
+	for _, test := range []struct {
+		input      string
+		msgContain string
+	}{
+		{input: sampleHashStr, msgContain: "not a URL-safe claim value"}, // contains "+" and "/"
+		{input: " $argon2id$v=19", msgContain: "not a URL-safe claim value"},
+		{input: "$argon2id$v=19$m=65536,t=3,p=2$Woo1mErn1s7AHf96ewQ8Uw", msgContain: "failed to decode the claim"},
+		{input: "", msgContain: "failed to decode the claim"},
+	} {
+		hashedObj, err := argonize.FromClaim(test.input)
+
+		require.ErrorContains(t, err, test.msgContain, "input: %q", test.input)
+		require.Nil(t, hashedObj)
+	}
+}
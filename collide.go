@@ -0,0 +1,44 @@
+package argonize
+
+import (
+	"crypto/subtle"
+)
+
+// ============================================================================
+//  Functions
+// ============================================================================
+
+// WouldCollide returns true if the two passwords hash to the same value with
+// the same salt and params. It is a test and diagnostic helper, such as to
+// assert that distinct inputs hash differently or for teaching, and is
+// practically always false for distinct passwords with a sound KDF.
+//
+// If salt is nil, a random salt of params.SaltLength is used. It returns false
+// if the params are invalid or the hashing fails. The hash values are compared
+// in constant time.
+func WouldCollide(pw1, pw2, salt []byte, params *Params) bool {
+	if err := params.Validate(); err != nil {
+		return false
+	}
+
+	if salt == nil {
+		newSalt, err := NewSalt(params.SaltLength)
+		if err != nil {
+			return false
+		}
+
+		salt = newSalt
+	}
+
+	hash1, err := deriveKey(pw1, salt, params)
+	if err != nil {
+		return false
+	}
+
+	hash2, err := deriveKey(pw2, salt, params)
+	if err != nil {
+		return false
+	}
+
+	return subtle.ConstantTimeCompare(hash1, hash2) == 1
+}
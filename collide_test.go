@@ -0,0 +1,34 @@
+package argonize_test
+
+import (
+	"testing"
+
+	"github.com/KEINOS/go-argonize"
+	"github.com/stretchr/testify/require"
+)
+
+// ----------------------------------------------------------------------------
+//  WouldCollide()
+// ----------------------------------------------------------------------------
+
+func TestWouldCollide(t *testing.T) {
+	t.Parallel()
+
+	params := lowCostParams()
+	salt := []byte("0123456789abcdef")
+
+	require.False(t, argonize.WouldCollide([]byte("foo"), []byte("bar"), salt, params))
+	require.False(t, argonize.WouldCollide([]byte("foo"), []byte("foo "), nil, params))
+	require.False(t, argonize.WouldCollide([]byte(""), []byte{0x00}, salt, params))
+
+	// The same password always "collides" with itself
+	require.True(t, argonize.WouldCollide([]byte("foo"), []byte("foo"), salt, params))
+	require.True(t, argonize.WouldCollide([]byte("foo"), []byte("foo"), nil, params))
+}
+
+func TestWouldCollide_invalid_params(t *testing.T) {
+	t.Parallel()
+
+	require.False(t, argonize.WouldCollide([]byte("foo"), []byte("foo"), nil, nil))
+	require.False(t, argonize.WouldCollide([]byte("foo"), []byte("foo"), nil, &argonize.Params{}))
+}
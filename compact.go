@@ -0,0 +1,89 @@
+package argonize
+
+import (
+	"github.com/pkg/errors"
+)
+
+// ============================================================================
+//  Compact Encoding
+// ============================================================================
+//
+// The compact encoding is the concatenation of the salt and the hash value
+// (salt||hash) without any parameters. It is meant for deployments where all
+// the hashes share one frozen set of parameters and storing them per record is
+// pure overhead.
+//
+// WARNING: The parameters are NOT stored in the compact encoding. Changing the
+// parameters of the deployment (including the salt and key length) makes the
+// existing records undecodable or unverifiable. Any parameter change requires
+// a data migration, such as re-encoding the records with Hashed.String().
+
+// ----------------------------------------------------------------------------
+//  Constructor of Hashed
+// ----------------------------------------------------------------------------
+
+// DecodeCompact decodes the compact encoded byte slice (salt||hash) into a
+// Hashed object with the given externally known parameters. The salt and hash
+// lengths are taken from params.SaltLength and params.KeyLength.
+//
+// It returns an error if the length of the data does not equal to
+// params.SaltLength + params.KeyLength.
+func DecodeCompact(data []byte, params *Params) (*Hashed, error) {
+	if params == nil {
+		return nil, errors.New("failed to decode compact hash: params are nil")
+	}
+
+	lenSalt := uint64(params.SaltLength)
+	lenWant := lenSalt + uint64(params.KeyLength)
+
+	if uint64(len(data)) != lenWant {
+		return nil, errors.Errorf(
+			"failed to decode compact hash: data length %d does not match salt length %d + key length %d",
+			len(data), params.SaltLength, params.KeyLength,
+		)
+	}
+
+	paramsCopy := *params
+
+	hashed := &Hashed{
+		Params: &paramsCopy,
+		Salt:   Salt(append([]byte{}, data[:lenSalt]...)),
+		Hash:   append([]byte{}, data[lenSalt:]...),
+	}
+
+	if err := hashed.Validate(); err != nil {
+		return nil, errors.Wrap(err, "failed to decode compact hash")
+	}
+
+	return hashed, nil
+}
+
+// ----------------------------------------------------------------------------
+//  Methods of Hashed
+// ----------------------------------------------------------------------------
+
+// EncodeCompact returns the compact encoding (salt||hash) of the hash. The
+// parameters are not included. To decode, use DecodeCompact() with the same
+// parameters used for hashing.
+//
+// It returns an error if the salt or hash length does not match the lengths in
+// the parameters.
+func (h *Hashed) EncodeCompact() ([]byte, error) {
+	if h == nil || h.Params == nil {
+		return nil, errors.New("failed to encode compact hash: params are nil")
+	}
+
+	if uint64(len(h.Salt)) != uint64(h.Params.SaltLength) ||
+		uint64(len(h.Hash)) != uint64(h.Params.KeyLength) {
+		return nil, errors.Errorf(
+			"failed to encode compact hash: salt length %d or hash length %d does not match the params",
+			len(h.Salt), len(h.Hash),
+		)
+	}
+
+	out := make([]byte, 0, len(h.Salt)+len(h.Hash))
+	out = append(out, h.Salt...)
+	out = append(out, h.Hash...)
+
+	return out, nil
+}
@@ -0,0 +1,79 @@
+package argonize_test
+
+import (
+	"testing"
+
+	"github.com/KEINOS/go-argonize"
+	"github.com/stretchr/testify/require"
+)
+
+// ----------------------------------------------------------------------------
+//  DecodeCompact()
+// ----------------------------------------------------------------------------
+
+func TestDecodeCompact_round_trip(t *testing.T) {
+	t.Parallel()
+
+	hashedObj, err := argonize.DecodeHashStr(
+		"$argon2id$v=19$m=65536,t=3,p=2$Woo1mErn1s7AHf96ewQ8Uw$D4TzIwGO4XD2buk96qAP+Ed2baMo/KbTRMqXX00wtsU",
+	)
+	require.NoError(t, err)
+
+	compact, err := hashedObj.EncodeCompact()
+	require.NoError(t, err)
+	require.Len(t, compact, 16+32, "it should contain only salt and hash")
+
+	decoded, err := argonize.DecodeCompact(compact, hashedObj.Params)
+	require.NoError(t, err)
+	require.Equal(t, hashedObj.String(), decoded.String())
+}
+
+func TestDecodeCompact_length_mismatch(t *testing.T) {
+	t.Parallel()
+
+	params := argonize.NewParams()
+
+	for _, data := range [][]byte{
+		nil,
+		make([]byte, 16+32-1),
+		make([]byte, 16+32+1),
+	} {
+		hashedObj, err := argonize.DecodeCompact(data, params)
+
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "does not match salt length 16 + key length 32")
+		require.Nil(t, hashedObj, "it should be nil on error")
+	}
+
+	hashedObj, err := argonize.DecodeCompact(make([]byte, 48), nil)
+
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "params are nil")
+	require.Nil(t, hashedObj, "it should be nil on error")
+}
+
+// ----------------------------------------------------------------------------
+//  Hashed.EncodeCompact()
+// ----------------------------------------------------------------------------
+
+func TestHashed_EncodeCompact_length_mismatch(t *testing.T) {
+	t.Parallel()
+
+	hashedObj := &argonize.Hashed{
+		Params: argonize.NewParams(),
+		Salt:   make([]byte, 8), // params.SaltLength is 16
+		Hash:   make([]byte, 32),
+	}
+
+	compact, err := hashedObj.EncodeCompact()
+
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "salt length 8 or hash length 32 does not match the params")
+	require.Nil(t, compact, "it should be nil on error")
+
+	compact, err = new(argonize.Hashed).EncodeCompact()
+
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "params are nil")
+	require.Nil(t, compact, "it should be nil on error")
+}
@@ -0,0 +1,47 @@
+package argonize
+
+import (
+	"github.com/pkg/errors"
+)
+
+// ErrMismatchedHashAndPassword is the error of CompareHashAndPassword() when
+// the password does not match the hash. It is returned as is, so it can be
+// compared with == as bcrypt.ErrMismatchedHashAndPassword.
+//
+//nolint:gochecknoglobals // sentinel error
+var ErrMismatchedHashAndPassword = errors.New("argonize: hashedPassword is not the hash of the given password")
+
+// ============================================================================
+//  Functions
+// ============================================================================
+
+// CompareHashAndPassword compares the encoded hash with the password in the
+// shape of bcrypt.CompareHashAndPassword() of "golang.org/x/crypto/bcrypt". It
+// returns nil on success and ErrMismatchedHashAndPassword if the password does
+// not match, so that the bcrypt call sites can be migrated mechanically:
+//
+//	// before
+//	err := bcrypt.CompareHashAndPassword(stored, password)
+//	// after
+//	err := argonize.CompareHashAndPassword(stored, password)
+//
+// A malformed hash is reported as the *ParseError of DecodeHashStr(), and a
+// hash with a KeyID as an error wrapping ErrPepperUnavailable, since it needs
+// the pepper of Hasher.Verify(). Check them with errors.Is() and errors.As().
+func CompareHashAndPassword(encodedHash, password []byte) error {
+	hashed, err := DecodeHashStr(string(encodedHash))
+	if err != nil {
+		return errors.Wrap(err, "failed to compare hash and password")
+	}
+
+	isValid, err := new(Hasher).verify(hashed, password)
+	if err != nil {
+		return errors.Wrap(err, "failed to compare hash and password")
+	}
+
+	if !isValid {
+		return ErrMismatchedHashAndPassword
+	}
+
+	return nil
+}
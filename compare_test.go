@@ -0,0 +1,59 @@
+package argonize_test
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/KEINOS/go-argonize"
+	"github.com/stretchr/testify/require"
+)
+
+// ----------------------------------------------------------------------------
+//  CompareHashAndPassword()
+// ----------------------------------------------------------------------------
+
+func TestCompareHashAndPassword(t *testing.T) {
+	t.Parallel()
+
+	hashedObj, err := argonize.HashCustomChecked([]byte("password"), nil, lowCostParams())
+	require.NoError(t, err)
+
+	encoded := []byte(hashedObj.String())
+
+	require.NoError(t, argonize.CompareHashAndPassword(encoded, []byte("password")))
+
+	err = argonize.CompareHashAndPassword(encoded, []byte("wrong password"))
+	require.Equal(t, argonize.ErrMismatchedHashAndPassword, err, "mismatch should be the bare sentinel")
+}
+
+func TestCompareHashAndPassword_malformed(t *testing.T) {
+	t.Parallel()
+
+	for _, test := range []struct {
+		wantErr error
+		input   string
+	}{
+		{input: "", wantErr: argonize.ErrInvalidFormat},
+		{input: "$2a$10$N9qo8uLOickgx2ZMRZoMyeIjZAgcfl7p92ldGxad68LJZdL17lhWy", wantErr: argonize.ErrInvalidFormat},
+		{input: strings.Replace(sampleHashStr, "argon2id", "argon2x", 1), wantErr: argonize.ErrUnsupportedVariant},
+	} {
+		err := argonize.CompareHashAndPassword([]byte(test.input), []byte("password"))
+
+		require.ErrorIs(t, err, test.wantErr, "input: %q", test.input)
+		require.NotErrorIs(t, err, argonize.ErrMismatchedHashAndPassword)
+
+		var parseErr *argonize.ParseError
+
+		require.True(t, errors.As(err, &parseErr), "input: %q", test.input)
+	}
+}
+
+func TestCompareHashAndPassword_keyid(t *testing.T) {
+	t.Parallel()
+
+	withKeyID := strings.Replace(sampleHashStr, "p=2$", "p=2,keyid=djE$", 1)
+
+	err := argonize.CompareHashAndPassword([]byte(withKeyID), []byte("password"))
+	require.ErrorIs(t, err, argonize.ErrPepperUnavailable)
+}
@@ -0,0 +1,283 @@
+package argonize
+
+import (
+	"math"
+	"strconv"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+// ============================================================================
+//  Type: ParamsConfig
+// ============================================================================
+
+// ParamsConfig is the human friendly representation of Params for config
+// files. The memory is a size string such as "64MiB" instead of a number of
+// KiB.
+//
+// Zero values (or an empty Memory) mean the default value of the field. Use
+// ToParams() to convert it to Params.
+type ParamsConfig struct {
+	// Memory is the memory cost as a size string. See ParseMemory() for the
+	// accepted formats.
+	Memory string `json:"memory,omitempty" toml:"memory,omitempty" yaml:"memory,omitempty"`
+	// Iterations is the number of passes over the memory.
+	Iterations uint32 `json:"iterations,omitempty" toml:"iterations,omitempty" yaml:"iterations,omitempty"`
+	// KeyLength is the length of the hash in bytes.
+	KeyLength uint32 `json:"key_length,omitempty" toml:"key_length,omitempty" yaml:"key_length,omitempty"`
+	// SaltLength is the length of the salt in bytes.
+	SaltLength uint32 `json:"salt_length,omitempty" toml:"salt_length,omitempty" yaml:"salt_length,omitempty"`
+	// Parallelism is the number of lanes.
+	Parallelism uint8 `json:"parallelism,omitempty" toml:"parallelism,omitempty" yaml:"parallelism,omitempty"`
+}
+
+// ----------------------------------------------------------------------------
+//  Methods of ParamsConfig
+// ----------------------------------------------------------------------------
+
+// ToParams converts the config to a validated Params object. Omitted fields are
+// set to the default values. Use WarnIfWeak() on the result to check whether
+// the legal parameters are also wise.
+func (c ParamsConfig) ToParams() (*Params, error) {
+	params := NewParams()
+
+	if c.Memory != "" {
+		memoryCost, err := ParseMemory(c.Memory)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to convert config to params")
+		}
+
+		params.MemoryCost = memoryCost
+	}
+
+	if c.Iterations != 0 {
+		params.Iterations = c.Iterations
+	}
+
+	if c.KeyLength != 0 {
+		params.KeyLength = c.KeyLength
+	}
+
+	if c.SaltLength != 0 {
+		params.SaltLength = c.SaltLength
+	}
+
+	if c.Parallelism != 0 {
+		params.Parallelism = c.Parallelism
+	}
+
+	if err := params.Validate(); err != nil {
+		return nil, errors.Wrap(err, "failed to convert config to params")
+	}
+
+	return params, nil
+}
+
+// ----------------------------------------------------------------------------
+//  Methods of Params
+// ----------------------------------------------------------------------------
+
+// Config returns the human friendly config representation of the parameters.
+// It is the counterpart of ParamsConfig.ToParams().
+func (p *Params) Config() ParamsConfig {
+	return ParamsConfig{
+		Memory:      FormatMemory(p.MemoryCost),
+		Iterations:  p.Iterations,
+		KeyLength:   p.KeyLength,
+		SaltLength:  p.SaltLength,
+		Parallelism: p.Parallelism,
+	}
+}
+
+// UnmarshalTOML implements the toml.Unmarshaler interface of the
+// "github.com/BurntSushi/toml" package without depending on it. It accepts the
+// same keys and values as UnmarshalYAML().
+func (p *Params) UnmarshalTOML(data any) error {
+	table, ok := data.(map[string]any)
+	if !ok {
+		return errors.Errorf("failed to unmarshal params: want a table, got %T", data)
+	}
+
+	return p.setFromMap(table)
+}
+
+// UnmarshalYAML implements the yaml.Unmarshaler interface (the one of
+// "gopkg.in/yaml.v2", which "gopkg.in/yaml.v3" also supports) without
+// depending on the YAML package.
+//
+// The "memory" key accepts both an integer in KiB and a size string such as
+// "64MiB" (see ParseMemory). Omitted keys are set to the default values and
+// unknown keys are rejected. The result is validated, but not checked with
+// WarnIfWeak().
+func (p *Params) UnmarshalYAML(unmarshal func(any) error) error {
+	var mapping map[string]any
+
+	if err := unmarshal(&mapping); err != nil {
+		return errors.Wrap(err, "failed to unmarshal params")
+	}
+
+	return p.setFromMap(mapping)
+}
+
+// setFromMap sets the fields from the generic key-value map of a config file
+// decoder, then validates them. Omitted keys are set to the default values.
+func (p *Params) setFromMap(mapping map[string]any) error {
+	params := NewParams()
+
+	for key, value := range mapping {
+		var err error
+
+		switch key {
+		case "memory":
+			params.MemoryCost, err = memoryFromAny(value)
+		case "iterations":
+			params.Iterations, err = uintFromAny[uint32](value)
+		case "key_length":
+			params.KeyLength, err = uintFromAny[uint32](value)
+		case "salt_length":
+			params.SaltLength, err = uintFromAny[uint32](value)
+		case "parallelism":
+			params.Parallelism, err = uintFromAny[uint8](value)
+		case "max_threads":
+			params.MaxThreads, err = uintFromAny[uint8](value)
+		case "variant":
+			name, isStr := value.(string)
+			if !isStr {
+				err = errors.Errorf("want a string, got %T", value)
+
+				break
+			}
+
+			params.Variant, err = ParseVariant(name)
+		default:
+			err = errors.New("unknown key")
+		}
+
+		if err != nil {
+			return errors.Wrapf(err, "failed to unmarshal params: bad value of %q", key)
+		}
+	}
+
+	if err := params.Validate(); err != nil {
+		return errors.Wrap(err, "failed to unmarshal params")
+	}
+
+	*p = *params
+
+	return nil
+}
+
+// ============================================================================
+//  Functions
+// ============================================================================
+
+// FormatMemory returns the memory cost in KiB as a size string with the
+// largest binary unit that represents it exactly. E.g. 65536 -> "64MiB",
+// 2097152 -> "2GiB" and 1000 -> "1000KiB".
+func FormatMemory(kib uint32) string {
+	const unit = 1024
+
+	switch {
+	case kib != 0 && kib%(unit*unit) == 0:
+		return strconv.FormatUint(uint64(kib/(unit*unit)), 10) + "GiB"
+	case kib != 0 && kib%unit == 0:
+		return strconv.FormatUint(uint64(kib/unit), 10) + "MiB"
+	default:
+		return strconv.FormatUint(uint64(kib), 10) + "KiB"
+	}
+}
+
+// ParseMemory parses a size string and returns the memory cost in KiB.
+//
+// A number without unit is in KiB, as in the PHC string. The units KiB, MiB and
+// GiB (or K, M and G) are case insensitive and may be separated from the number
+// by spaces. E.g. "65536", "64MiB", "64 mib" and "2G" are accepted. Decimal
+// units such as "MB" are rejected to avoid ambiguity. It returns an error if
+// the result overflows uint32.
+func ParseMemory(size string) (uint32, error) {
+	number, multiplier := splitMemoryUnit(strings.ToLower(strings.TrimSpace(size)))
+
+	num, err := strconv.ParseUint(number, 10, 32)
+	if err != nil {
+		return 0, errors.Errorf("invalid memory size %q", size)
+	}
+
+	if num*multiplier > math.MaxUint32 {
+		return 0, errors.Errorf("memory size %q overflows", size)
+	}
+
+	return uint32(num * multiplier), nil
+}
+
+// ----------------------------------------------------------------------------
+//  Private Functions
+// ----------------------------------------------------------------------------
+
+// splitMemoryUnit splits the lower cased size string into the number part and
+// the multiplier to KiB of its unit.
+func splitMemoryUnit(size string) (string, uint64) {
+	const unit = 1024
+
+	for _, suffix := range []struct {
+		name string
+		mul  uint64
+	}{
+		{"kib", 1}, {"mib", unit}, {"gib", unit * unit},
+		{"k", 1}, {"m", unit}, {"g", unit * unit},
+	} {
+		if strings.HasSuffix(size, suffix.name) {
+			return strings.TrimSpace(strings.TrimSuffix(size, suffix.name)), suffix.mul
+		}
+	}
+
+	return size, 1
+}
+
+// memoryFromAny returns the memory cost in KiB from an integer or a size string
+// decoded by a config file decoder.
+func memoryFromAny(value any) (uint32, error) {
+	if size, ok := value.(string); ok {
+		return ParseMemory(size)
+	}
+
+	return uintFromAny[uint32](value)
+}
+
+// uintFromAny returns the unsigned integer value of the number decoded by a
+// config file decoder. It returns an error if the value is not an integer or
+// overflows T.
+func uintFromAny[T uint8 | uint32](value any) (T, error) {
+	var num uint64
+
+	switch val := value.(type) {
+	case int:
+		if val < 0 {
+			return 0, errors.New("negative value")
+		}
+
+		num = uint64(val)
+	case int64:
+		if val < 0 {
+			return 0, errors.New("negative value")
+		}
+
+		num = uint64(val)
+	case uint64:
+		num = val
+	case float64:
+		if val < 0 || val != math.Trunc(val) || val > math.MaxUint32 {
+			return 0, errors.New("not an unsigned integer")
+		}
+
+		num = uint64(val)
+	default:
+		return 0, errors.Errorf("want a number, got %T", value)
+	}
+
+	if num > uint64(^T(0)) {
+		return 0, errors.Errorf("value %d overflows", num)
+	}
+
+	return T(num), nil
+}
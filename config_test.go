@@ -0,0 +1,139 @@
+package argonize_test
+
+import (
+	"testing"
+
+	"github.com/KEINOS/go-argonize"
+	"github.com/stretchr/testify/require"
+)
+
+// ----------------------------------------------------------------------------
+//  FormatMemory()
+// ----------------------------------------------------------------------------
+
+func TestFormatMemory(t *testing.T) {
+	t.Parallel()
+
+	for kib, expect := range map[uint32]string{
+		0:               "0KiB",
+		8:               "8KiB",
+		1000:            "1000KiB",
+		1024:            "1MiB",
+		65536:           "64MiB",
+		2 * 1024 * 1024: "2GiB",
+		1536 * 1024:     "1536MiB",
+	} {
+		require.Equal(t, expect, argonize.FormatMemory(kib))
+	}
+}
+
+// ----------------------------------------------------------------------------
+//  ParseMemory()
+// ----------------------------------------------------------------------------
+
+func TestParseMemory(t *testing.T) {
+	t.Parallel()
+
+	for size, expect := range map[string]uint32{
+		"65536":      65536,
+		"64MiB":      65536,
+		" 64 mib ":   65536,
+		"64M":        65536,
+		"2GiB":       2 * 1024 * 1024,
+		"2g":         2 * 1024 * 1024,
+		"1024KiB":    1024,
+		"8k":         8,
+		"4095GiB":    4095 * 1024 * 1024,
+		"4294967295": 4294967295,
+	} {
+		kib, err := argonize.ParseMemory(size)
+
+		require.NoError(t, err, size)
+		require.Equal(t, expect, kib, size)
+	}
+}
+
+func TestParseMemory_invalid(t *testing.T) {
+	t.Parallel()
+
+	for size, msgContain := range map[string]string{
+		"":           "invalid memory size",
+		"MiB":        "invalid memory size",
+		"64MB":       "invalid memory size",
+		"-1MiB":      "invalid memory size",
+		"1.5GiB":     "invalid memory size",
+		"4096GiB":    "overflows",
+		"4294967296": "invalid memory size",
+	} {
+		kib, err := argonize.ParseMemory(size)
+
+		require.Error(t, err, size)
+		require.Contains(t, err.Error(), msgContain, size)
+		require.Zero(t, kib, size)
+	}
+}
+
+// ----------------------------------------------------------------------------
+//  ParamsConfig.ToParams()
+// ----------------------------------------------------------------------------
+
+func TestParamsConfig_ToParams(t *testing.T) {
+	t.Parallel()
+
+	params, err := argonize.ParamsConfig{}.ToParams()
+
+	require.NoError(t, err)
+	require.Equal(t, argonize.NewParams(), params, "zero config should be the defaults")
+
+	params, err = argonize.ParamsConfig{Memory: "256MiB", Iterations: 3, Parallelism: 4}.ToParams()
+
+	require.NoError(t, err)
+	require.Equal(t, uint32(256*1024), params.MemoryCost)
+	require.Equal(t, uint32(3), params.Iterations)
+	require.Equal(t, uint8(4), params.Parallelism)
+	require.Equal(t, argonize.KeyLengthDefault, params.KeyLength)
+}
+
+func TestParamsConfig_ToParams_invalid(t *testing.T) {
+	t.Parallel()
+
+	for _, test := range []struct {
+		config     argonize.ParamsConfig
+		msgContain string
+	}{
+		{argonize.ParamsConfig{Memory: "64MB"}, "invalid memory size"},
+		{argonize.ParamsConfig{Memory: "8KiB", Parallelism: 2}, "memory cost must be at least 8 KiB per lane"},
+		{argonize.ParamsConfig{SaltLength: 4}, "salt length must be at least 8"},
+		{argonize.ParamsConfig{KeyLength: 2}, "key length must be at least 4"},
+	} {
+		params, err := test.config.ToParams()
+
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "failed to convert config to params")
+		require.Contains(t, err.Error(), test.msgContain)
+		require.Nil(t, params, "it should be nil on error")
+	}
+}
+
+// ----------------------------------------------------------------------------
+//  Params.Config()
+// ----------------------------------------------------------------------------
+
+func TestParams_Config_round_trip(t *testing.T) {
+	t.Parallel()
+
+	for _, params := range []*argonize.Params{
+		argonize.NewParams(),
+		argonize.PresetRFC9106First.Params(),
+		{Iterations: 2, KeyLength: 64, MemoryCost: 12345, SaltLength: 32, Parallelism: 8},
+	} {
+		config := params.Config()
+
+		restored, err := config.ToParams()
+
+		require.NoError(t, err)
+		require.Equal(t, params, restored)
+	}
+
+	require.Equal(t, "2GiB", argonize.PresetRFC9106First.Params().Config().Memory)
+}
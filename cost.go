@@ -0,0 +1,215 @@
+package argonize
+
+import (
+	"cmp"
+	"fmt"
+	"math"
+
+	"github.com/pkg/errors"
+)
+
+// ErrBelowOWASPMinimum is the error of Params.Scale() when the scaled params
+// are weaker than all the minimum configurations of OWASP.
+//
+//nolint:gochecknoglobals // sentinel error
+var ErrBelowOWASPMinimum = errors.New("params are below the OWASP minimum")
+
+// owaspMinimums are the minimum configurations of Argon2id recommended by the
+// OWASP Password Storage Cheat Sheet. They are equivalent in strength, trading
+// the memory cost in KiB for the iterations.
+//
+//nolint:gochecknoglobals // read-only table
+var owaspMinimums = []struct {
+	memoryCost uint32
+	iterations uint32
+}{
+	{memoryCost: 47104, iterations: 1},
+	{memoryCost: 19456, iterations: 2},
+	{memoryCost: 12288, iterations: 3},
+	{memoryCost: 9216, iterations: 4},
+	{memoryCost: 7168, iterations: 5},
+}
+
+// ============================================================================
+//  Functions
+// ============================================================================
+
+// CompareCost compares the cost of the two parameters and returns -1 if a is
+// weaker than b, +1 if a is stronger than b and 0 if they cost the same. Use it
+// to decide whether a change of the parameters is an upgrade, such as
+// CompareCost(current, proposed) < 0.
+//
+// The parameters are ordered by the memory cost first, then by the iterations
+// and then by the parallelism. That is, a higher memory cost always wins
+// regardless of the iterations and the parallelism, since the memory is what
+// makes the brute-force attacks expensive. The iterations are only compared if
+// the memory costs are the same, and so on. The key length, the salt length and
+// the variant are not taken into account.
+//
+// A nil Params is weaker than any non-nil one, and two nil Params are equal.
+func CompareCost(a, b *Params) int {
+	switch {
+	case a == nil && b == nil:
+		return 0
+	case a == nil:
+		return -1
+	case b == nil:
+		return 1
+	}
+
+	return cmp.Or(
+		cmp.Compare(a.MemoryCost, b.MemoryCost),
+		cmp.Compare(a.Iterations, b.Iterations),
+		cmp.Compare(a.Parallelism, b.Parallelism),
+	)
+}
+
+// CompareCostReport compares the costs of the two parameters in detail, such
+// as to answer "how much stronger is the new config?" in a security review. The
+// ratios are of b to a, that is, greater than 1 if b costs more. It is pure
+// computation and hashes nothing.
+//
+// It returns an error if a or b is nil or invalid. Use CompareCost() to only
+// order them.
+func CompareCostReport(a, b *Params) (CostComparison, error) {
+	if err := a.Validate(); err != nil {
+		return CostComparison{}, errors.Wrap(err, "failed to compare cost: invalid params a")
+	}
+
+	if err := b.Validate(); err != nil {
+		return CostComparison{}, errors.Wrap(err, "failed to compare cost: invalid params b")
+	}
+
+	workA := float64(a.MemoryCost) * float64(a.Iterations)
+	workB := float64(b.MemoryCost) * float64(b.Iterations)
+
+	comparison := CostComparison{
+		MemoryRatio:      float64(b.MemoryCost) / float64(a.MemoryCost),
+		IterationsRatio:  float64(b.Iterations) / float64(a.Iterations),
+		WorkRatio:        workB / workA,
+		ParallelismDelta: int(b.Parallelism) - int(a.Parallelism),
+	}
+
+	comparison.Summary = fmt.Sprintf(
+		"memory x%.2f (%s -> %s), iterations x%.2f (%d -> %d), work x%.2f, parallelism %+d (%d -> %d)",
+		comparison.MemoryRatio, FormatMemory(a.MemoryCost), FormatMemory(b.MemoryCost),
+		comparison.IterationsRatio, a.Iterations, b.Iterations,
+		comparison.WorkRatio,
+		comparison.ParallelismDelta, a.Parallelism, b.Parallelism,
+	)
+
+	return comparison, nil
+}
+
+// ============================================================================
+//  Type: CostComparison
+// ============================================================================
+
+// CostComparison is the detailed comparison of the costs of the parameters a
+// and b returned by CompareCostReport(). The ratios are of b to a.
+type CostComparison struct {
+	// Summary is a human-readable summary, such as "memory x4.00 (64MiB ->
+	// 256MiB), iterations x1.00 (3 -> 3), work x4.00, parallelism +0 (4 -> 4)".
+	Summary string
+	// MemoryRatio is the ratio of the memory costs.
+	MemoryRatio float64
+	// IterationsRatio is the ratio of the iterations.
+	IterationsRatio float64
+	// WorkRatio is the ratio of the iterations times the memory cost, a crude
+	// metric of the work of a guess.
+	WorkRatio float64
+	// ParallelismDelta is the parallelism of b minus the one of a.
+	ParallelismDelta int
+}
+
+// ----------------------------------------------------------------------------
+//  Methods of CostComparison
+// ----------------------------------------------------------------------------
+
+// Dominates returns true if b costs at least as much as a in every dimension,
+// that is the memory cost, the iterations and the parallelism, and more in at
+// least one of them. Then b is an upgrade of a without any trade-off.
+func (c CostComparison) Dominates() bool {
+	if c.MemoryRatio < 1 || c.IterationsRatio < 1 || c.ParallelismDelta < 0 {
+		return false
+	}
+
+	return c.MemoryRatio > 1 || c.IterationsRatio > 1 || c.ParallelismDelta > 0
+}
+
+// ----------------------------------------------------------------------------
+//  Methods of Params
+// ----------------------------------------------------------------------------
+
+// Scale returns a new Params with the MemoryCost multiplied by memFactor and
+// the Iterations by timeFactor, keeping the ratio between them. E.g. Scale(4, 1)
+// when moving to the machines with four times the memory. The other fields are
+// copied as is.
+//
+// The results are rounded and floored at the validation minimums, that is
+// 8 KiB per lane and one iteration. It returns an error if a factor is not a
+// positive finite number, if a result overflows uint32, or if the result is
+// weaker than the minimums of the OWASP Password Storage Cheat Sheet, wrapping
+// ErrBelowOWASPMinimum, rather than producing weak params silently.
+func (p *Params) Scale(memFactor, timeFactor float64) (*Params, error) {
+	if p == nil {
+		return nil, errors.New("failed to scale params: params are nil")
+	}
+
+	memoryCost, err := scaleUint32(p.MemoryCost, memFactor)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to scale the memory cost")
+	}
+
+	iterations, err := scaleUint32(p.Iterations, timeFactor)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to scale the iterations")
+	}
+
+	scaled := *p
+	scaled.MemoryCost = max(memoryCost, uint32(p.Parallelism)*memoryPerLaneMin)
+	scaled.Iterations = max(iterations, 1)
+
+	if !scaled.meetsOWASPMinimum() {
+		return nil, errors.Wrapf(ErrBelowOWASPMinimum,
+			"failed to scale params: m=%d,t=%d", scaled.MemoryCost, scaled.Iterations)
+	}
+
+	return &scaled, nil
+}
+
+// ----------------------------------------------------------------------------
+//  Methods of Params (Private)
+// ----------------------------------------------------------------------------
+
+// meetsOWASPMinimum returns true if the memory cost and iterations meet one of
+// the owaspMinimums.
+func (p *Params) meetsOWASPMinimum() bool {
+	for _, minimum := range owaspMinimums {
+		if p.MemoryCost >= minimum.memoryCost && p.Iterations >= minimum.iterations {
+			return true
+		}
+	}
+
+	return false
+}
+
+// ----------------------------------------------------------------------------
+//  Private Functions
+// ----------------------------------------------------------------------------
+
+// scaleUint32 returns the value multiplied by the factor and rounded. It
+// returns an error if the factor is not positive and finite or if the result
+// overflows uint32.
+func scaleUint32(value uint32, factor float64) (uint32, error) {
+	if math.IsNaN(factor) || math.IsInf(factor, 0) || factor <= 0 {
+		return 0, errors.Errorf("factor %v must be a positive finite number", factor)
+	}
+
+	scaled := math.Round(float64(value) * factor)
+	if scaled > math.MaxUint32 {
+		return 0, errors.Errorf("%d * %v overflows uint32", value, factor)
+	}
+
+	return uint32(scaled), nil
+}
@@ -0,0 +1,231 @@
+package argonize_test
+
+import (
+	"math"
+	"testing"
+
+	"github.com/KEINOS/go-argonize"
+	"github.com/stretchr/testify/require"
+)
+
+// ----------------------------------------------------------------------------
+//  CompareCost()
+// ----------------------------------------------------------------------------
+
+func TestCompareCost(t *testing.T) {
+	t.Parallel()
+
+	newParams := func(memory, iterations uint32, parallelism uint8) *argonize.Params {
+		params := argonize.NewParams()
+		params.MemoryCost = memory
+		params.Iterations = iterations
+		params.Parallelism = parallelism
+
+		return params
+	}
+
+	for _, test := range []struct {
+		a, b   *argonize.Params
+		expect int
+		msg    string
+	}{
+		// Ties
+		{nil, nil, 0, "two nils should be equal"},
+		{newParams(65536, 3, 2), newParams(65536, 3, 2), 0, "same costs should be equal"},
+		{
+			newParams(65536, 3, 2),
+			&argonize.Params{MemoryCost: 65536, Iterations: 3, Parallelism: 2, KeyLength: 64, SaltLength: 32},
+			0, "key and salt lengths should not be compared",
+		},
+		// Clear wins
+		{newParams(32768, 3, 2), newParams(65536, 3, 2), -1, "more memory should be stronger"},
+		{newParams(65536, 3, 2), newParams(65536, 2, 2), 1, "fewer iterations should be weaker"},
+		{newParams(65536, 3, 1), newParams(65536, 3, 4), -1, "more parallelism should be stronger"},
+		// Weighting
+		{newParams(65536, 1, 1), newParams(32768, 10, 8), 1, "memory should outweigh iterations and parallelism"},
+		{newParams(65536, 4, 1), newParams(65536, 3, 8), 1, "iterations should outweigh parallelism"},
+		// Nil
+		{nil, newParams(8, 1, 1), -1, "nil should be weaker than any params"},
+		{newParams(8, 1, 1), nil, 1, "any params should be stronger than nil"},
+	} {
+		require.Equal(t, test.expect, argonize.CompareCost(test.a, test.b), test.msg)
+		require.Equal(t, -test.expect, argonize.CompareCost(test.b, test.a), "it should be antisymmetric: "+test.msg)
+	}
+}
+
+// ----------------------------------------------------------------------------
+//  CompareCostReport()
+// ----------------------------------------------------------------------------
+
+func TestCompareCostReport(t *testing.T) {
+	t.Parallel()
+
+	current := &argonize.Params{MemoryCost: 65536, Iterations: 3, Parallelism: 4, KeyLength: 32, SaltLength: 16}
+	proposed := &argonize.Params{MemoryCost: 262144, Iterations: 3, Parallelism: 4, KeyLength: 32, SaltLength: 16}
+
+	report, err := argonize.CompareCostReport(current, proposed)
+	require.NoError(t, err)
+
+	require.InDelta(t, 4.0, report.MemoryRatio, 1e-9)
+	require.InDelta(t, 1.0, report.IterationsRatio, 1e-9)
+	require.InDelta(t, 4.0, report.WorkRatio, 1e-9)
+	require.Zero(t, report.ParallelismDelta)
+	require.True(t, report.Dominates())
+	require.Equal(t,
+		"memory x4.00 (64MiB -> 256MiB), iterations x1.00 (3 -> 3), work x4.00, parallelism +0 (4 -> 4)",
+		report.Summary)
+
+	// The other way around is a downgrade
+	report, err = argonize.CompareCostReport(proposed, current)
+	require.NoError(t, err)
+	require.InDelta(t, 0.25, report.WorkRatio, 1e-9)
+	require.False(t, report.Dominates())
+
+	// Equal params do not dominate
+	report, err = argonize.CompareCostReport(current, current)
+	require.NoError(t, err)
+	require.InDelta(t, 1.0, report.WorkRatio, 1e-9)
+	require.False(t, report.Dominates())
+}
+
+func TestCompareCostReport_trade_off(t *testing.T) {
+	t.Parallel()
+
+	// RFC 9106 first vs second recommended options
+	first := argonize.PresetRFC9106First.Params()   // m=2GiB, t=1, p=4
+	second := argonize.PresetRFC9106Second.Params() // m=64MiB, t=3, p=4
+
+	report, err := argonize.CompareCostReport(second, first)
+	require.NoError(t, err)
+
+	require.InDelta(t, 32.0, report.MemoryRatio, 1e-9)
+	require.InDelta(t, 1.0/3.0, report.IterationsRatio, 1e-9)
+	require.InDelta(t, 32.0/3.0, report.WorkRatio, 1e-9)
+	require.False(t, report.Dominates(), "fewer iterations should be a trade-off, not a domination")
+	require.Contains(t, report.Summary, "iterations x0.33 (3 -> 1)")
+
+	// Only parallelism
+	lower := argonize.NewParams()
+	higher := argonize.NewParams()
+	higher.Parallelism = lower.Parallelism + 2
+
+	report, err = argonize.CompareCostReport(lower, higher)
+	require.NoError(t, err)
+	require.Equal(t, 2, report.ParallelismDelta)
+	require.True(t, report.Dominates())
+	require.Contains(t, report.Summary, "parallelism +2 (2 -> 4)")
+}
+
+func TestCompareCostReport_invalid(t *testing.T) {
+	t.Parallel()
+
+	params := argonize.NewParams()
+
+	_, err := argonize.CompareCostReport(nil, params)
+	require.ErrorIs(t, err, argonize.ErrNilParams)
+	require.ErrorContains(t, err, "invalid params a")
+
+	_, err = argonize.CompareCostReport(params, nil)
+	require.ErrorIs(t, err, argonize.ErrNilParams)
+	require.ErrorContains(t, err, "invalid params b")
+
+	_, err = argonize.CompareCostReport(params, &argonize.Params{})
+	require.ErrorIs(t, err, argonize.ErrZeroParams)
+}
+
+// ----------------------------------------------------------------------------
+//  Params.Scale()
+// ----------------------------------------------------------------------------
+
+func TestParams_Scale(t *testing.T) {
+	t.Parallel()
+
+	params := argonize.NewParams() // m=65536, t=1, p=2
+	params.Iterations = 3
+
+	scaled, err := params.Scale(4, 2)
+	require.NoError(t, err)
+	require.Equal(t, uint32(262144), scaled.MemoryCost)
+	require.Equal(t, uint32(6), scaled.Iterations)
+
+	// The other fields should be kept
+	require.Equal(t, params.Parallelism, scaled.Parallelism)
+	require.Equal(t, params.KeyLength, scaled.KeyLength)
+	require.Equal(t, params.SaltLength, scaled.SaltLength)
+
+	// The original should not be modified
+	require.Equal(t, uint32(65536), params.MemoryCost)
+	require.Equal(t, uint32(3), params.Iterations)
+
+	// Rounding
+	scaled, err = params.Scale(1.5, 0.5)
+	require.NoError(t, err)
+	require.Equal(t, uint32(98304), scaled.MemoryCost)
+	require.Equal(t, uint32(2), scaled.Iterations, "1.5 should round half away from zero")
+
+	// Floored at one iteration
+	scaled, err = params.Scale(1, 0.01)
+	require.NoError(t, err)
+	require.Equal(t, uint32(1), scaled.Iterations)
+	require.NoError(t, scaled.Validate())
+}
+
+func TestParams_Scale_below_OWASP(t *testing.T) {
+	t.Parallel()
+
+	params := argonize.NewParams() // m=65536, t=1
+
+	// 19456 KiB with t=1 is below the 47104 KiB needed for one iteration
+	_, err := params.Scale(19456.0/65536.0, 1)
+	require.ErrorIs(t, err, argonize.ErrBelowOWASPMinimum)
+	require.ErrorContains(t, err, "m=19456,t=1")
+
+	// but fine with t=2
+	scaled, err := params.Scale(19456.0/65536.0, 2)
+	require.NoError(t, err)
+	require.Equal(t, uint32(19456), scaled.MemoryCost)
+
+	// Floored at 8 KiB per lane, which is still too weak
+	_, err = params.Scale(1e-9, 1)
+	require.ErrorIs(t, err, argonize.ErrBelowOWASPMinimum)
+	require.ErrorContains(t, err, "m=16,t=1")
+}
+
+func TestParams_Scale_overflow(t *testing.T) {
+	t.Parallel()
+
+	params := argonize.NewParams()
+	params.MemoryCost = math.MaxUint32 / 2 // 2147483647
+	params.Iterations = math.MaxUint32
+
+	scaled, err := params.Scale(2, 1)
+	require.NoError(t, err, "just below the maximum should be fine")
+	require.Equal(t, uint32(math.MaxUint32-1), scaled.MemoryCost)
+	require.Equal(t, uint32(math.MaxUint32), scaled.Iterations, "factor 1 should keep the maximum")
+
+	_, err = params.Scale(2.000001, 1)
+	require.EqualError(t, err, "failed to scale the memory cost: 2147483647 * 2.000001 overflows uint32")
+
+	_, err = params.Scale(1, 1.000001)
+	require.ErrorContains(t, err, "failed to scale the iterations")
+	require.ErrorContains(t, err, "overflows uint32")
+}
+
+func TestParams_Scale_bad_factor(t *testing.T) {
+	t.Parallel()
+
+	params := argonize.NewParams()
+
+	for _, factor := range []float64{0, -1, math.NaN(), math.Inf(1), math.Inf(-1)} {
+		_, err := params.Scale(factor, 1)
+		require.ErrorContains(t, err, "must be a positive finite number", "factor: %v", factor)
+
+		_, err = params.Scale(1, factor)
+		require.ErrorContains(t, err, "must be a positive finite number", "factor: %v", factor)
+	}
+
+	var nilParams *argonize.Params
+
+	_, err := nilParams.Scale(1, 1)
+	require.ErrorContains(t, err, "params are nil")
+}
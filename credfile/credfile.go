@@ -0,0 +1,260 @@
+/*
+Package credfile reads and writes an htpasswd-style credential file with the
+Argon2 hashes, for small self-hosted services.
+
+Each entry is a line of "username:hash", where the hash is the PHC string of
+argonize.Hashed.String():
+
+	# users of the admin console
+	alice:$argon2id$v=19$m=65536,t=1,p=2$...$...
+	bob:$argon2id$v=19$m=65536,t=1,p=2$...$...
+
+The blank lines, the comment lines starting with "#" and the entries of other
+hash formats, such as bcrypt, are preserved as is on a round trip of Load()
+and Save(). The entries of the other formats can not be verified, but can be
+replaced by Set().
+*/
+package credfile
+
+import (
+	"bufio"
+	"io"
+	"strings"
+	"sync"
+
+	"github.com/KEINOS/go-argonize"
+	"github.com/pkg/errors"
+)
+
+const (
+	// separator separates the user name and the hash of an entry.
+	separator = ":"
+	// argonPrefix is the prefix of the hash strings handled by this package.
+	argonPrefix = "$argon2"
+)
+
+// ============================================================================
+//  Type: File
+// ============================================================================
+
+// File is a credential file in memory. It is safe for concurrent use.
+type File struct {
+	index map[string]int // user name to the index of lines
+	lines []line
+	mu    sync.RWMutex
+}
+
+// line is a line of the credential file.
+type line struct {
+	// hashed is the decoded hash of an Argon2 entry. It is nil for the other
+	// lines.
+	hashed *argonize.Hashed
+	// raw is the line as read or written, without the line break.
+	raw string
+	// user is the user name of an entry. It is empty for the blank and comment
+	// lines.
+	user string
+}
+
+// ----------------------------------------------------------------------------
+//  Constructors of File
+// ----------------------------------------------------------------------------
+
+// New returns an empty File.
+func New() *File {
+	return &File{index: make(map[string]int)}
+}
+
+// Load reads the credential file from r. The Argon2 entries are decoded and
+// validated with argonize.DecodeHashStr().
+//
+// It returns an error with the line number if an Argon2 entry is invalid or if
+// a user name appears more than once.
+func Load(r io.Reader) (*File, error) {
+	if r == nil {
+		return nil, errors.New("failed to load the credential file: reader is nil")
+	}
+
+	file := New()
+	scanner := bufio.NewScanner(r)
+
+	for lineNum := 1; scanner.Scan(); lineNum++ {
+		parsed, err := parseLine(scanner.Text())
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to load the credential file: line %d", lineNum)
+		}
+
+		if parsed.user != "" {
+			if _, found := file.index[parsed.user]; found {
+				return nil, errors.Errorf(
+					"failed to load the credential file: line %d: duplicate user %q", lineNum, parsed.user)
+			}
+
+			file.index[parsed.user] = len(file.lines)
+		}
+
+		file.lines = append(file.lines, parsed)
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, errors.Wrap(err, "failed to load the credential file")
+	}
+
+	return file, nil
+}
+
+// ----------------------------------------------------------------------------
+//  Methods of File
+// ----------------------------------------------------------------------------
+
+// Save writes the credential file to w. The lines are written in the order as
+// loaded, followed by the users added by Set().
+func (f *File) Save(w io.Writer) error {
+	f.mu.RLock()
+	defer f.mu.RUnlock()
+
+	bufWriter := bufio.NewWriter(w)
+
+	for _, line := range f.lines {
+		_, _ = bufWriter.WriteString(line.raw)
+		_ = bufWriter.WriteByte('\n')
+	}
+
+	if err := bufWriter.Flush(); err != nil {
+		return errors.Wrap(err, "failed to save the credential file")
+	}
+
+	return nil
+}
+
+// Set hashes the password with the params and sets it as the hash of the user.
+// The entry of an existing user is replaced in place, and a new user is
+// appended. If params is nil, the defaults of argonize.NewParams() are used.
+//
+// The user name must not be empty, contain ":" or line breaks, start with "#"
+// or have surrounding whitespace.
+func (f *File) Set(user string, password []byte, params *argonize.Params) error {
+	if err := validateUser(user); err != nil {
+		return errors.Wrap(err, "failed to set the password")
+	}
+
+	if params == nil {
+		params = argonize.NewParams()
+	}
+
+	hashed, err := argonize.HashCustomChecked(password, nil, params)
+	if err != nil {
+		return errors.Wrap(err, "failed to set the password")
+	}
+
+	newLine := line{
+		hashed: hashed,
+		raw:    user + separator + hashed.String(),
+		user:   user,
+	}
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if f.index == nil {
+		f.index = make(map[string]int)
+	}
+
+	if idx, found := f.index[user]; found {
+		f.lines[idx] = newLine
+
+		return nil
+	}
+
+	f.index[user] = len(f.lines)
+	f.lines = append(f.lines, newLine)
+
+	return nil
+}
+
+// Verify returns true if the password matches the hash of the user.
+//
+// An unknown user is not an error. It returns false after verifying against
+// argonize.DummyHash(), so that the user names can not be enumerated by the
+// timing. It returns an error if the entry of the user is not an Argon2 hash.
+func (f *File) Verify(user string, password []byte) (bool, error) {
+	entry, found := f.lookup(user)
+	if !found {
+		argonize.DummyHash().IsValidPassword(password) // spend the same time
+
+		return false, nil
+	}
+
+	if entry.hashed == nil {
+		return false, errors.Errorf("failed to verify user %q: unsupported hash format", user)
+	}
+
+	return entry.hashed.IsValidPassword(password), nil
+}
+
+// ----------------------------------------------------------------------------
+//  Methods of File (Private)
+// ----------------------------------------------------------------------------
+
+// lookup returns the entry of the user.
+func (f *File) lookup(user string) (line, bool) {
+	f.mu.RLock()
+	defer f.mu.RUnlock()
+
+	idx, found := f.index[user]
+	if !found {
+		return line{}, false
+	}
+
+	return f.lines[idx], true
+}
+
+// ----------------------------------------------------------------------------
+//  Private Functions
+// ----------------------------------------------------------------------------
+
+// parseLine parses a line of the credential file. The lines other than the
+// Argon2 entries are kept as is.
+func parseLine(raw string) (line, error) {
+	trimmed := strings.TrimSpace(raw)
+	if trimmed == "" || strings.HasPrefix(trimmed, "#") {
+		return line{raw: raw}, nil
+	}
+
+	user, hashStr, found := strings.Cut(trimmed, separator)
+	if !found {
+		return line{raw: raw}, nil // unknown line
+	}
+
+	parsed := line{raw: raw, user: user}
+
+	if !strings.HasPrefix(hashStr, argonPrefix) {
+		return parsed, nil // other hash formats
+	}
+
+	hashed, err := argonize.DecodeHashStr(hashStr)
+	if err != nil {
+		return line{}, errors.Wrapf(err, "invalid hash of user %q", user)
+	}
+
+	parsed.hashed = hashed
+
+	return parsed, nil
+}
+
+// validateUser returns an error if the user name can not be written to the
+// credential file.
+func validateUser(user string) error {
+	switch {
+	case user == "":
+		return errors.New("user name is empty")
+	case strings.ContainsAny(user, separator+"\r\n"):
+		return errors.Errorf("user name %q contains %q or a line break", user, separator)
+	case strings.HasPrefix(user, "#"):
+		return errors.Errorf("user name %q starts with %q", user, "#")
+	case strings.TrimSpace(user) != user:
+		return errors.Errorf("user name %q has surrounding whitespace", user)
+	}
+
+	return nil
+}
@@ -0,0 +1,212 @@
+package credfile_test
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/KEINOS/go-argonize"
+	"github.com/KEINOS/go-argonize/credfile"
+	"github.com/stretchr/testify/require"
+)
+
+// lowCostParams returns the params cheap enough for tests.
+func lowCostParams() *argonize.Params {
+	params := argonize.NewParams()
+	params.MemoryCost = 64
+	params.Parallelism = 1
+
+	return params
+}
+
+// hashStr returns the hash string of the password with lowCostParams().
+func hashStr(t *testing.T, password string) string {
+	t.Helper()
+
+	hashed, err := argonize.HashCustomChecked([]byte(password), nil, lowCostParams())
+	require.NoError(t, err)
+
+	return hashed.String()
+}
+
+// ----------------------------------------------------------------------------
+//  Load() and Save()
+// ----------------------------------------------------------------------------
+
+func TestLoad_Save_round_trip(t *testing.T) {
+	t.Parallel()
+
+	input := "# users of the admin console\n" +
+		"alice:" + hashStr(t, "alice-pw") + "\n" +
+		"\n" +
+		"carol:$2y$10$abcdefghijklmnopqrstuu5Ck3HJ0ZJ3bYXgIL0eHQ2y6VbUPrHlG\n" +
+		"  # indented comment\n" +
+		"some unknown line\n" +
+		"bob:" + hashStr(t, "bob-pw") + "\n"
+
+	file, err := credfile.Load(strings.NewReader(input))
+	require.NoError(t, err)
+
+	var out bytes.Buffer
+
+	require.NoError(t, file.Save(&out))
+	require.Equal(t, input, out.String(), "it should preserve the file as is")
+}
+
+func TestLoad_invalid(t *testing.T) {
+	t.Parallel()
+
+	hashed := hashStr(t, "password")
+
+	for _, test := range []struct {
+		input      string
+		msgContain string
+	}{
+		{
+			input:      "alice:" + hashed + "\n# comment\nalice:" + hashed + "\n",
+			msgContain: `line 3: duplicate user "alice"`,
+		},
+		{
+			input:      "alice:$2y$10$x\nalice:" + hashed + "\n",
+			msgContain: `line 2: duplicate user "alice"`,
+		},
+		{
+			input:      "alice:$argon2id$v=19$m=65536,t=3,p=2$!!!$" + strings.Split(hashed, "$")[5] + "\n",
+			msgContain: `line 1: invalid hash of user "alice": failed to decode salt value`,
+		},
+	} {
+		file, err := credfile.Load(strings.NewReader(test.input))
+
+		require.ErrorContains(t, err, test.msgContain)
+		require.Nil(t, file)
+	}
+
+	_, err := credfile.Load(nil)
+	require.ErrorContains(t, err, "reader is nil")
+}
+
+// ----------------------------------------------------------------------------
+//  File.Verify()
+// ----------------------------------------------------------------------------
+
+func TestFile_Verify(t *testing.T) {
+	t.Parallel()
+
+	input := "alice:" + hashStr(t, "alice-pw") + "\n" +
+		"carol:$2y$10$abcdefghijklmnopqrstuu5Ck3HJ0ZJ3bYXgIL0eHQ2y6VbUPrHlG\n"
+
+	file, err := credfile.Load(strings.NewReader(input))
+	require.NoError(t, err)
+
+	isValid, err := file.Verify("alice", []byte("alice-pw"))
+	require.NoError(t, err)
+	require.True(t, isValid)
+
+	isValid, err = file.Verify("alice", []byte("wrong"))
+	require.NoError(t, err)
+	require.False(t, isValid)
+
+	isValid, err = file.Verify("nobody", []byte("alice-pw"))
+	require.NoError(t, err, "unknown user should not be an error")
+	require.False(t, isValid)
+
+	isValid, err = file.Verify("carol", []byte("carol-pw"))
+	require.EqualError(t, err, `failed to verify user "carol": unsupported hash format`)
+	require.False(t, isValid)
+}
+
+// ----------------------------------------------------------------------------
+//  File.Set()
+// ----------------------------------------------------------------------------
+
+func TestFile_Set(t *testing.T) {
+	t.Parallel()
+
+	input := "# header\n" +
+		"alice:" + hashStr(t, "old-pw") + "\n" +
+		"carol:$2y$10$abcdefghijklmnopqrstuu5Ck3HJ0ZJ3bYXgIL0eHQ2y6VbUPrHlG\n" +
+		"# footer\n"
+
+	file, err := credfile.Load(strings.NewReader(input))
+	require.NoError(t, err)
+
+	require.NoError(t, file.Set("alice", []byte("new-pw"), lowCostParams()))
+	require.NoError(t, file.Set("carol", []byte("carol-pw"), lowCostParams()), "it should replace other formats")
+	require.NoError(t, file.Set("dave", []byte("dave-pw"), lowCostParams()))
+
+	var out bytes.Buffer
+
+	require.NoError(t, file.Save(&out))
+
+	lines := strings.Split(strings.TrimSuffix(out.String(), "\n"), "\n")
+	require.Len(t, lines, 5)
+	require.Equal(t, "# header", lines[0])
+	require.True(t, strings.HasPrefix(lines[1], "alice:$argon2id$v=19$m=64,t=1,p=1$"), "it should replace in place")
+	require.True(t, strings.HasPrefix(lines[2], "carol:$argon2id$"))
+	require.Equal(t, "# footer", lines[3])
+	require.True(t, strings.HasPrefix(lines[4], "dave:$argon2id$"), "new users should be appended")
+
+	// The saved file should load and verify
+	reloaded, err := credfile.Load(&out)
+	require.NoError(t, err)
+
+	for user, password := range map[string]string{"alice": "new-pw", "carol": "carol-pw", "dave": "dave-pw"} {
+		isValid, err := reloaded.Verify(user, []byte(password))
+		require.NoError(t, err)
+		require.True(t, isValid, user)
+	}
+
+	isValid, err := reloaded.Verify("alice", []byte("old-pw"))
+	require.NoError(t, err)
+	require.False(t, isValid)
+}
+
+func TestFile_Set_new_file(t *testing.T) {
+	t.Parallel()
+
+	file := credfile.New()
+
+	require.NoError(t, file.Set("alice", []byte("alice-pw"), lowCostParams()))
+
+	isValid, err := file.Verify("alice", []byte("alice-pw"))
+	require.NoError(t, err)
+	require.True(t, isValid)
+
+	// The zero value is usable as well
+	var zero credfile.File
+
+	require.NoError(t, zero.Set("bob", []byte("bob-pw"), lowCostParams()))
+
+	isValid, err = zero.Verify("bob", []byte("bob-pw"))
+	require.NoError(t, err)
+	require.True(t, isValid)
+}
+
+func TestFile_Set_invalid(t *testing.T) {
+	t.Parallel()
+
+	file := credfile.New()
+
+	for user, msgContain := range map[string]string{
+		"":        "user name is empty",
+		"a:b":     `contains ":" or a line break`,
+		"a\nb":    `contains ":" or a line break`,
+		"#admin":  `starts with "#"`,
+		" alice":  "has surrounding whitespace",
+		"alice\t": "has surrounding whitespace",
+	} {
+		err := file.Set(user, []byte("password"), lowCostParams())
+		require.ErrorContains(t, err, msgContain, "user: %q", user)
+	}
+
+	err := file.Set("alice", nil, lowCostParams())
+	require.ErrorContains(t, err, "failed to set the password")
+
+	err = file.Set("alice", []byte("password"), &argonize.Params{})
+	require.ErrorIs(t, err, argonize.ErrZeroParams)
+
+	var out bytes.Buffer
+
+	require.NoError(t, file.Save(&out))
+	require.Empty(t, out.String(), "nothing should be set on error")
+}
@@ -0,0 +1,497 @@
+package argonize
+
+import (
+	"encoding/base64"
+	"fmt"
+	"slices"
+	"strings"
+	"unicode"
+
+	"github.com/pkg/errors"
+	"golang.org/x/crypto/argon2"
+)
+
+// ============================================================================
+//  Type: MemoryUnit
+// ============================================================================
+
+// MemoryUnit is the unit of the "m=" value of the hash strings for
+// DecodeOptions.MemoryUnit.
+type MemoryUnit int
+
+const (
+	// MemoryUnitKiB is the KiB of the PHC string format. It is the default.
+	MemoryUnitKiB MemoryUnit = iota
+	// MemoryUnitMiB is the MiB of some non-standard encoders, which write
+	// "m=64" for 64 MiB.
+	MemoryUnitMiB
+)
+
+// String returns the name of the unit. E.g. "KiB".
+func (u MemoryUnit) String() string {
+	switch u {
+	case MemoryUnitKiB:
+		return "KiB"
+	case MemoryUnitMiB:
+		return "MiB"
+	default:
+		return "unknown"
+	}
+}
+
+// ============================================================================
+//  Type: DecodeOptions
+// ============================================================================
+
+// DecodeOptions configures the limits and the strictness of DecodeHashStrWith().
+//
+// The zero value decodes in the same way as DecodeHashStr(). DecodeOptions is
+// never modified by the decoder, so a value can be copied and reused across
+// goroutines.
+type DecodeOptions struct {
+	// AllowedVariants is the list of accepted algorithm variants. The empty
+	// Variant and VariantArgon2id are treated the same. If nil, all the
+	// supported variants are accepted.
+	AllowedVariants []Variant
+	// MaxInputLength is the maximum length of the hash string in bytes. Zero
+	// means no limit.
+	MaxInputLength int
+	// MaxMemoryCost is the maximum memory cost in KiB. Zero means no limit.
+	// Use it to reject resource-exhausting hashes from untrusted sources.
+	MaxMemoryCost uint32
+	// LenientBase64 accepts padded base64 and non-zero trailing bits in the
+	// salt and hash. If false, the strict unpadded base64 is required.
+	LenientBase64 bool
+	// AllowEmptySalt accepts an empty salt segment, such as the hash strings
+	// of HashSaltless(). Salts of 1 to 7 bytes are rejected regardless.
+	AllowEmptySalt bool
+	// Strict requires the canonical PHC string. The version segment must be
+	// present, the data must be the "data=" parameter rather than a trailing
+	// segment, the parameters must be in the "m=..,t=..,p=.." order without
+	// leading zeros, and the salt and hash must be the canonical base64
+	// encoding, which they are re-encoded and compared to. It can not be
+	// combined with LenientBase64.
+	Strict bool
+	// MemoryUnit is the unit of the "m=" value. The memory cost is normalized
+	// to KiB, so MaxMemoryCost is still in KiB and String() of the decoded hash
+	// is the standard PHC string. Auto-detection of the unit is not possible,
+	// so set it only for the hashes known to be of a non-standard encoder.
+	MemoryUnit MemoryUnit
+}
+
+// ============================================================================
+//  Public Variables
+// ============================================================================
+
+// Sentinel errors of the limits in DecodeOptions. Check them with errors.Is().
+//
+//nolint:gochecknoglobals // sentinel errors
+var (
+	// ErrInputTooLong is the error of a hash string longer than the
+	// DecodeOptions.MaxInputLength.
+	ErrInputTooLong = errors.New("hash string is too long")
+	// ErrMemoryCostTooHigh is the error of a memory cost higher than the
+	// DecodeOptions.MaxMemoryCost.
+	ErrMemoryCostTooHigh = errors.New("memory cost exceeds the limit")
+)
+
+// ============================================================================
+//  Functions
+// ============================================================================
+
+// DecodeHashStrWith decodes the hash string into a Hashed object with the given
+// options. DecodeHashStr(), DecodeHashStrStrict() and DecodeHashStrLenient()
+// are shorthands of it.
+//
+// The trailing PadFiller added by Hashed.StringPadded() is trimmed before
+// decoding. On failure, it returns a *ParseError. Its Segment refers to the
+// six segments of the canonical layout, even if the version segment is
+// missing. The errors of the trailing data segment refer to SegmentWhole with
+// its offset.
+func DecodeHashStrWith(encodedHash string, opts DecodeOptions) (*Hashed, error) {
+	if opts.Strict && opts.LenientBase64 {
+		return nil, errors.New("invalid decode options: Strict can not be combined with LenientBase64")
+	}
+
+	if opts.MemoryUnit != MemoryUnitKiB && opts.MemoryUnit != MemoryUnitMiB {
+		return nil, errors.Errorf("invalid decode options: unknown memory unit %d", opts.MemoryUnit)
+	}
+
+	if opts.MaxInputLength > 0 && len(encodedHash) > opts.MaxInputLength {
+		return nil, newParseError(SegmentWhole, encodedHash, 0, ErrInputTooLong,
+			errors.Errorf("length %d exceeds %d", len(encodedHash), opts.MaxInputLength))
+	}
+
+	// Trim the filler of StringPadded()
+	segs := splitSegments(strings.TrimRight(encodedHash, string(PadFiller)))
+
+	if err := segs.normalize(opts); err != nil {
+		return nil, err
+	}
+
+	vals := segs.vals
+
+	if vals[0] != "" {
+		return nil, segs.error(SegmentPrefix, ErrInvalidFormat, errors.New("missing leading '$'"))
+	}
+
+	variant, err := ParseVariant(vals[1])
+	if err == nil && !opts.allowsVariant(variant) {
+		err = errors.Errorf("variant %q is not allowed", vals[1])
+	}
+
+	if err != nil {
+		return nil, segs.error(SegmentAlgorithm, ErrUnsupportedVariant, err)
+	}
+
+	var version int
+
+	if _, err := fmt.Sscanf(vals[2],
+		"v=%d", &version); err != nil {
+		return nil, segs.error(SegmentVersion, ErrInvalidVersion, err)
+	}
+
+	if version != argon2.Version {
+		return nil, segs.error(SegmentVersion, ErrIncompatibleVersion, nil)
+	}
+
+	var keyID string
+
+	paramStr, data, err := cutTrailingParam(vals[3], "data", opts.Strict)
+	if err == nil {
+		paramStr, keyID, err = cutTrailingParam(paramStr, "keyid", opts.Strict)
+	}
+
+	if err != nil {
+		return nil, segs.error(SegmentParams, ErrMissingParams, err)
+	}
+
+	if segs.hasData {
+		if data, err = segs.decodeData(opts, data); err != nil {
+			return nil, err
+		}
+	}
+
+	params, seen, err := parseParamString(paramStr, false)
+	if err == nil && !(seen["m"] && seen["t"] && seen["p"]) {
+		err = errors.New("m, t and p are required")
+	}
+
+	if err == nil && opts.Strict && string(params.appendParamString(nil, false)) != paramStr {
+		err = errors.New("parameters are not in the canonical form")
+	}
+
+	if err == nil && opts.MemoryUnit == MemoryUnitMiB {
+		if params.MemoryCost > MemoryMiBMax {
+			err = errors.Errorf("memory %d MiB overflows %d MiB", params.MemoryCost, MemoryMiBMax)
+		} else {
+			params.MemoryCost *= 1024
+		}
+	}
+
+	if err != nil {
+		return nil, segs.error(SegmentParams, ErrMissingParams, err)
+	}
+
+	if opts.MaxMemoryCost > 0 && params.MemoryCost > opts.MaxMemoryCost {
+		return nil, segs.error(SegmentParams, ErrMemoryCostTooHigh,
+			errors.Errorf("%d KiB exceeds %d KiB", params.MemoryCost, opts.MaxMemoryCost))
+	}
+
+	params.Variant = variant
+
+	salt, err := opts.decodeBase64(vals[4])
+	if err != nil {
+		return nil, segs.error(SegmentSalt, ErrInvalidSalt, err)
+	}
+
+	hash, err := opts.decodeBase64(vals[5])
+	if err != nil {
+		return nil, segs.error(SegmentHash, ErrInvalidHashValue, err)
+	}
+
+	lenSalt := len(salt)
+	lenHash := len(hash)
+
+	// Salt length must be 8..(2^32 -1) bytes and hash length (tagLength)
+	// must be 4..(2^32 -1) bytes.
+	// Ref: https://en.wikipedia.org/wiki/Argon2#Algorithm
+	if lenSalt >= maxInt32 || (lenSalt < int(SaltLengthMin) && !(opts.AllowEmptySalt && lenSalt == 0)) {
+		return nil, segs.error(SegmentSalt, ErrInvalidLength, nil)
+	}
+
+	if lenHash >= maxInt32 || lenHash < int(KeyLengthMin) {
+		return nil, segs.error(SegmentHash, ErrInvalidLength, nil)
+	}
+
+	params.SaltLength = uint32(lenSalt) //nolint:gosec // int overflow is checked above
+	params.KeyLength = uint32(lenHash)  //nolint:gosec // int overflow is checked above
+
+	hashed := &Hashed{
+		Params: params,
+		Salt:   Salt(salt),
+		Hash:   hash,
+		KeyID:  keyID,
+		Data:   data,
+	}
+
+	if err := hashed.validate(opts.AllowEmptySalt); err != nil {
+		return nil, segs.error(SegmentParams, ErrInvalidParams, err)
+	}
+
+	return hashed, nil
+}
+
+// DecodeHashStrMemoryUnit is similar to DecodeHashStr() but reads the "m=" value
+// in the unit, such as MemoryUnitMiB for the hash strings of the non-standard
+// encoders writing "m=64" for 64 MiB. The decoded memory cost is in KiB as
+// usual, so String() of it is the standard PHC string with "m=65536".
+//
+// It is for the interoperability with such systems only. Do not guess the unit
+// from the value, since the small KiB values are legal as well.
+func DecodeHashStrMemoryUnit(encodedHash string, unit MemoryUnit) (*Hashed, error) {
+	return DecodeHashStrWith(encodedHash, DecodeOptions{MemoryUnit: unit})
+}
+
+// DecodeHashStrStrict is similar to DecodeHashStr() but accepts only the
+// canonical PHC string. See DecodeOptions.Strict for the details.
+func DecodeHashStrStrict(encodedHash string) (*Hashed, error) {
+	return DecodeHashStrWith(encodedHash, DecodeOptions{Strict: true})
+}
+
+// DecodeHashStrLenient is similar to DecodeHashStr() but accepts padded base64
+// and non-zero trailing bits, which some other Argon2 libraries produce.
+func DecodeHashStrLenient(encodedHash string) (*Hashed, error) {
+	return DecodeHashStrWith(encodedHash, DecodeOptions{LenientBase64: true})
+}
+
+// DecodeHashStrPrefix decodes the leading PHC string of s and returns the
+// content following it. It is for the storage formats which append metadata
+// after the hash string separated by whitespace, such as "<hash> <created_at>".
+//
+// The PHC string ends at the first whitespace, since the hash string never
+// contains any. The whitespace separating it from the rest is trimmed. The rest
+// is empty if s has nothing but the PHC string. The PHC string is decoded as
+// in DecodeHashStr().
+func DecodeHashStrPrefix(s string) (*Hashed, string, error) {
+	token, rest := s, ""
+
+	if end := strings.IndexFunc(s, unicode.IsSpace); end >= 0 {
+		token, rest = s[:end], strings.TrimLeftFunc(s[end:], unicode.IsSpace)
+	}
+
+	hashed, err := DecodeHashStr(token)
+	if err != nil {
+		return nil, "", err
+	}
+
+	return hashed, rest, nil
+}
+
+// ----------------------------------------------------------------------------
+//  Private Functions
+// ----------------------------------------------------------------------------
+
+// cutTrailingParam cuts the base64 encoded parameter of the name, such as
+// "keyid" and "data", off the end of the PHC parameter string and returns the
+// rest and the decoded value. The optional parameters must be at the end in the
+// "keyid" then "data" order as in the PHC string format of Argon2. If strict is
+// true, the value must be in the standard base64 alphabet as in decodeBase64().
+func cutTrailingParam(paramStr, name string, strict bool) (string, string, error) {
+	rest, value, found := strings.Cut(paramStr, ","+name+"=")
+	if !found {
+		return paramStr, "", nil
+	}
+
+	if strings.Contains(value, ",") {
+		return "", "", errors.Errorf("%s must be the last parameter", name)
+	}
+
+	enc := base64.RawStdEncoding
+	if !strict {
+		enc = base64EncodingOf(value)
+	}
+
+	decoded, err := enc.Strict().DecodeString(value)
+	if err != nil || len(decoded) == 0 {
+		return "", "", errors.Errorf("bad value of parameter %q", name)
+	}
+
+	return rest, string(decoded), nil
+}
+
+// base64EncodingOf returns the base64 alphabet of the chunk. The URL-safe one
+// of Hashed.StringURLSafe() is detected by the "-" and "_" characters which the
+// standard one does not have.
+func base64EncodingOf(chunk string) *base64.Encoding {
+	if strings.ContainsAny(chunk, "-_") {
+		return base64.RawURLEncoding
+	}
+
+	return base64.RawStdEncoding
+}
+
+// ----------------------------------------------------------------------------
+//  Methods of DecodeOptions (Private)
+// ----------------------------------------------------------------------------
+
+// allowsVariant returns true if the variant is in the AllowedVariants or if
+// AllowedVariants is nil.
+func (opts DecodeOptions) allowsVariant(variant Variant) bool {
+	if opts.AllowedVariants == nil {
+		return true
+	}
+
+	for _, allowed := range opts.AllowedVariants {
+		if allowed.String() == variant.String() {
+			return true
+		}
+	}
+
+	return false
+}
+
+// decodeBase64 decodes the base64 encoded chunk according to LenientBase64. In
+// the Strict mode, the chunk must be the canonical encoding of the result, so
+// that no two different strings decode to the same hash. The URL-safe alphabet
+// is accepted except in the Strict mode, as it is not the PHC compliant one.
+func (opts DecodeOptions) decodeBase64(chunk string) ([]byte, error) {
+	enc := base64.RawStdEncoding
+	if !opts.Strict {
+		enc = base64EncodingOf(chunk)
+	}
+
+	if opts.LenientBase64 {
+		return enc.DecodeString(strings.TrimRight(chunk, "="))
+	}
+
+	decoded, err := enc.Strict().DecodeString(chunk)
+	if err == nil && opts.Strict && base64.RawStdEncoding.EncodeToString(decoded) != chunk {
+		err = errors.New("non-canonical base64 encoding")
+	}
+
+	return decoded, err
+}
+
+// ============================================================================
+//  Type: segments (Private)
+// ============================================================================
+
+// segments is the hash string split into the "$"-delimited segments with their
+// byte offsets, to build the ParseError.
+type segments struct {
+	input   string
+	vals    []string
+	offsets []int
+	// data and offsetData are the trailing data segment cut off by
+	// normalize(), if hasData.
+	data       string
+	offsetData int
+	hasData    bool
+}
+
+// splitSegments splits the hash string into segments.
+func splitSegments(input string) *segments {
+	vals := strings.Split(input, "$")
+	offsets := make([]int, len(vals))
+
+	for i := 1; i < len(vals); i++ {
+		offsets[i] = offsets[i-1] + len(vals[i-1]) + 1
+	}
+
+	return &segments{input: input, vals: vals, offsets: offsets}
+}
+
+// normalize dispatches on the number of segments and normalizes them into the
+// canonical layout of lenDecChunks segments:
+//
+//	5: "$alg$params$salt$hash" without version, treated as the current version
+//	6: "$alg$v=19$params$salt$hash", the canonical layout
+//	7: "$alg$v=19$params$salt$hash$data" with the base64 data segment, which is
+//	   cut off and decoded as the "data=" parameter by decodeData()
+//
+// The layouts of 5 and 7 are rejected in the Strict mode. The "$mac=" segment
+// of SignHash() is rejected as well, since it must be verified with
+// VerifySignedHash().
+func (s *segments) normalize(opts DecodeOptions) error {
+	switch len(s.vals) {
+	case lenDecChunks - 1:
+		if strings.HasPrefix(s.vals[SegmentVersion], "v=") {
+			// The version is there, so the salt or the hash is missing
+			return s.countError()
+		}
+
+		if opts.Strict {
+			return s.error(SegmentWhole, ErrInvalidFormat,
+				errors.New("missing version segment, which Strict requires"))
+		}
+
+		s.insertVersion()
+	case lenDecChunks:
+		// canonical
+	case lenDecChunks + 1:
+		value, offset := s.vals[lenDecChunks], s.offsets[lenDecChunks]
+
+		if strings.HasPrefix(value, strings.TrimPrefix(macSeparator, "$")) {
+			return newParseError(SegmentWhole, value, offset, ErrInvalidFormat,
+				errors.New("signed hash string, use VerifySignedHash()"))
+		}
+
+		if opts.Strict {
+			return newParseError(SegmentWhole, value, offset, ErrInvalidFormat,
+				errors.New("data segment is not canonical, use the data parameter"))
+		}
+
+		s.data, s.offsetData, s.hasData = value, offset, true
+		s.vals, s.offsets = s.vals[:lenDecChunks], s.offsets[:lenDecChunks]
+	default:
+		return s.countError()
+	}
+
+	return nil
+}
+
+// countError returns a ParseError of the unexpected number of segments.
+func (s *segments) countError() *ParseError {
+	return s.error(SegmentWhole, ErrInvalidFormat,
+		errors.Errorf("%d segments, want %d", len(s.vals), lenDecChunks))
+}
+
+// decodeData decodes the data segment cut off by normalize(). The paramData is
+// the value of the "data=" parameter, which must not be given as well.
+func (s *segments) decodeData(opts DecodeOptions, paramData string) (string, error) {
+	var cause error
+
+	decoded, err := opts.decodeBase64(s.data)
+
+	switch {
+	case paramData != "":
+		cause = errors.New("data is given both as the parameter and the segment")
+	case err != nil:
+		cause = errors.Wrap(err, "bad data segment")
+	case len(decoded) == 0:
+		cause = errors.New("data segment is empty")
+	}
+
+	if cause != nil {
+		return "", newParseError(SegmentWhole, s.data, s.offsetData, ErrInvalidFormat, cause)
+	}
+
+	return string(decoded), nil
+}
+
+// insertVersion inserts the current version segment, which is missing in the
+// input. Its offset is the one of the following params segment.
+func (s *segments) insertVersion() {
+	s.vals = slices.Insert(s.vals, int(SegmentVersion), fmt.Sprintf("v=%d", argon2.Version))
+	s.offsets = slices.Insert(s.offsets, int(SegmentVersion), s.offsets[SegmentVersion])
+}
+
+// error returns a ParseError of the segment.
+func (s *segments) error(segment Segment, sentinel, cause error) *ParseError {
+	if segment == SegmentWhole || int(segment) >= len(s.vals) {
+		return newParseError(SegmentWhole, s.input, 0, sentinel, cause)
+	}
+
+	return newParseError(segment, s.vals[segment], s.offsets[segment], sentinel, cause)
+}
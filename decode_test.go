@@ -0,0 +1,460 @@
+package argonize_test
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/KEINOS/go-argonize"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	sampleSaltB64 = "Woo1mErn1s7AHf96ewQ8Uw"
+	sampleHashB64 = "D4TzIwGO4XD2buk96qAP+Ed2baMo/KbTRMqXX00wtsU"
+)
+
+// requireParseError asserts that err is a ParseError of the segment wrapping
+// the sentinel.
+func requireParseError(t *testing.T, err error, sentinel error, segment argonize.Segment) {
+	t.Helper()
+
+	var parseErr *argonize.ParseError
+
+	require.True(t, errors.As(err, &parseErr), "it should be a ParseError: %v", err)
+	require.ErrorIs(t, err, sentinel)
+	require.Equal(t, segment, parseErr.Segment, err.Error())
+}
+
+// ----------------------------------------------------------------------------
+//  DecodeHashStrWith()
+// ----------------------------------------------------------------------------
+
+func TestDecodeHashStrWith_zero_options(t *testing.T) {
+	t.Parallel()
+
+	expect, err := argonize.DecodeHashStr(sampleHashStr)
+	require.NoError(t, err)
+
+	actual, err := argonize.DecodeHashStrWith(sampleHashStr, argonize.DecodeOptions{})
+	require.NoError(t, err)
+	require.Equal(t, expect, actual)
+}
+
+func TestDecodeHashStrWith_max_input_length(t *testing.T) {
+	t.Parallel()
+
+	opts := argonize.DecodeOptions{MaxInputLength: len(sampleHashStr)}
+
+	_, err := argonize.DecodeHashStrWith(sampleHashStr, opts)
+	require.NoError(t, err, "length equal to the limit should be accepted")
+
+	opts.MaxInputLength--
+
+	hashedObj, err := argonize.DecodeHashStrWith(sampleHashStr, opts)
+	require.Nil(t, hashedObj)
+	requireParseError(t, err, argonize.ErrInputTooLong, argonize.SegmentWhole)
+}
+
+func TestDecodeHashStrWith_max_memory_cost(t *testing.T) {
+	t.Parallel()
+
+	_, err := argonize.DecodeHashStrWith(sampleHashStr, argonize.DecodeOptions{MaxMemoryCost: 65536})
+	require.NoError(t, err, "memory cost equal to the limit should be accepted")
+
+	hashedObj, err := argonize.DecodeHashStrWith(sampleHashStr, argonize.DecodeOptions{MaxMemoryCost: 65535})
+	require.Nil(t, hashedObj)
+	requireParseError(t, err, argonize.ErrMemoryCostTooHigh, argonize.SegmentParams)
+}
+
+func TestDecodeHashStrWith_lenient_base64(t *testing.T) {
+	t.Parallel()
+
+	expect, err := argonize.DecodeHashStr(sampleHashStr)
+	require.NoError(t, err)
+
+	opts := argonize.DecodeOptions{LenientBase64: true}
+
+	for _, encoded := range []string{
+		// padded
+		"$argon2id$v=19$m=65536,t=3,p=2$" + sampleSaltB64 + "==$" + sampleHashB64 + "=",
+		// non-zero trailing bits of the salt ('x' instead of 'w')
+		"$argon2id$v=19$m=65536,t=3,p=2$Woo1mErn1s7AHf96ewQ8Ux$" + sampleHashB64,
+	} {
+		_, err := argonize.DecodeHashStr(encoded)
+		require.Error(t, err, "it should be an error by default: %s", encoded)
+
+		actual, err := argonize.DecodeHashStrWith(encoded, opts)
+		require.NoError(t, err, encoded)
+		require.Equal(t, expect, actual, encoded)
+	}
+}
+
+func TestDecodeHashStr_missing_version(t *testing.T) {
+	t.Parallel()
+
+	expect, err := argonize.DecodeHashStr(sampleHashStr)
+	require.NoError(t, err)
+
+	encoded := "$argon2id$m=65536,t=3,p=2$" + sampleSaltB64 + "$" + sampleHashB64
+
+	actual, err := argonize.DecodeHashStr(encoded)
+	require.NoError(t, err, "it should be the current version by default")
+	require.Equal(t, expect, actual)
+	require.Equal(t, sampleHashStr, actual.String())
+
+	_, err = argonize.DecodeHashStrStrict(encoded)
+	requireParseError(t, err, argonize.ErrInvalidFormat, argonize.SegmentWhole)
+	require.ErrorContains(t, err, "missing version segment, which Strict requires")
+
+	// The segment should refer to the canonical layout
+	_, err = argonize.DecodeHashStr("$argon2id$m=65536,t=3,p=2$%%$" + sampleHashB64)
+	requireParseError(t, err, argonize.ErrInvalidSalt, argonize.SegmentSalt)
+}
+
+func TestDecodeHashStr_data_segment(t *testing.T) {
+	t.Parallel()
+
+	expect, err := argonize.DecodeHashStr(sampleHashStr)
+	require.NoError(t, err)
+
+	expect.Data = "data"
+
+	actual, err := argonize.DecodeHashStr(sampleHashStr + "$ZGF0YQ")
+	require.NoError(t, err)
+	require.Equal(t, expect, actual, "the segment should be decoded as the data parameter")
+	require.Equal(t, strings.Replace(sampleHashStr, "p=2$", "p=2,data=ZGF0YQ$", 1), actual.String())
+
+	for _, test := range []struct {
+		encoded    string
+		msgContain string
+		substring  string
+	}{
+		{sampleHashStr + "$%%", "bad data segment", "%%"},
+		{sampleHashStr + "$", "data segment is empty", ""},
+		{
+			strings.Replace(sampleHashStr, "p=2$", "p=2,data=ZGF0YQ$", 1) + "$ZGF0YQ",
+			"data is given both as the parameter and the segment", "ZGF0YQ",
+		},
+	} {
+		_, err := argonize.DecodeHashStr(test.encoded)
+		requireParseError(t, err, argonize.ErrInvalidFormat, argonize.SegmentWhole)
+		require.ErrorContains(t, err, test.msgContain, test.encoded)
+
+		var parseErr *argonize.ParseError
+
+		require.ErrorAs(t, err, &parseErr)
+		require.Equal(t, test.substring, parseErr.Substring)
+		require.Equal(t, strings.LastIndex(test.encoded, "$")+1, parseErr.Offset, "it should point to the data segment")
+	}
+
+	_, err = argonize.DecodeHashStrStrict(sampleHashStr + "$ZGF0YQ")
+	requireParseError(t, err, argonize.ErrInvalidFormat, argonize.SegmentWhole)
+	require.ErrorContains(t, err, "data segment is not canonical")
+}
+
+func TestDecodeHashStrWith_chunk_counts(t *testing.T) {
+	t.Parallel()
+
+	expect, err := argonize.DecodeHashStr(sampleHashStr)
+	require.NoError(t, err)
+
+	withData, err := argonize.DecodeHashStr(sampleHashStr)
+	require.NoError(t, err)
+
+	withData.Data = "data"
+
+	signed, err := argonize.SignHash(expect, []byte("0123456789abcdef0123456789abcdef"), "user:42")
+	require.NoError(t, err)
+
+	for _, test := range []struct {
+		expect     *argonize.Hashed
+		encoded    string
+		opts       argonize.DecodeOptions
+		msgContain string // empty for success
+	}{
+		// 4 chunks and less
+		{nil, "$argon2id$v=19$m=65536,t=3,p=2", argonize.DecodeOptions{}, "4 segments, want 6"},
+		{nil, "$argon2id$m=65536,t=3,p=2$" + sampleSaltB64, argonize.DecodeOptions{}, "4 segments, want 6"},
+		// 5 chunks
+		{expect, "$argon2id$m=65536,t=3,p=2$" + sampleSaltB64 + "$" + sampleHashB64, argonize.DecodeOptions{}, ""},
+		{nil, "$argon2id$m=65536,t=3,p=2$" + sampleSaltB64 + "$" + sampleHashB64, argonize.DecodeOptions{Strict: true}, "missing version segment"},
+		{nil, "$argon2id$v=19$m=65536,t=3,p=2$" + sampleSaltB64, argonize.DecodeOptions{}, "5 segments, want 6"},
+		// 6 chunks
+		{expect, sampleHashStr, argonize.DecodeOptions{}, ""},
+		{expect, sampleHashStr, argonize.DecodeOptions{Strict: true}, ""},
+		// 7 chunks
+		{withData, sampleHashStr + "$ZGF0YQ", argonize.DecodeOptions{}, ""},
+		{nil, sampleHashStr + "$ZGF0YQ", argonize.DecodeOptions{Strict: true}, "data segment is not canonical"},
+		{nil, signed, argonize.DecodeOptions{}, "use VerifySignedHash()"},
+		// 8 chunks and more
+		{nil, sampleHashStr + "$a$b", argonize.DecodeOptions{}, "8 segments, want 6"},
+	} {
+		actual, err := argonize.DecodeHashStrWith(test.encoded, test.opts)
+
+		if test.msgContain == "" {
+			require.NoError(t, err, test.encoded)
+			require.Equal(t, test.expect, actual)
+
+			continue
+		}
+
+		requireParseError(t, err, argonize.ErrInvalidFormat, argonize.SegmentWhole)
+		require.ErrorContains(t, err, test.msgContain, test.encoded)
+	}
+
+	// The offset should point to the trailing segment
+	_, err = argonize.DecodeHashStrStrict(sampleHashStr + "$ZGF0YQ")
+
+	var parseErr *argonize.ParseError
+
+	require.ErrorAs(t, err, &parseErr)
+	require.Equal(t, len(sampleHashStr)+1, parseErr.Offset)
+	require.Equal(t, "ZGF0YQ", parseErr.Substring)
+}
+
+func TestDecodeHashStrWith_strict(t *testing.T) {
+	t.Parallel()
+
+	opts := argonize.DecodeOptions{Strict: true}
+
+	_, err := argonize.DecodeHashStrWith(sampleHashStr, opts)
+	require.NoError(t, err, "canonical string should be accepted")
+
+	for _, test := range []struct {
+		encoded  string
+		sentinel error
+		segment  argonize.Segment
+	}{
+		{"$argon2id$v=19$t=3,m=65536,p=2$" + sampleSaltB64 + "$" + sampleHashB64, argonize.ErrMissingParams, argonize.SegmentParams},
+		{"$argon2id$v=19$m=065536,t=3,p=2$" + sampleSaltB64 + "$" + sampleHashB64, argonize.ErrMissingParams, argonize.SegmentParams},
+	} {
+		_, err := argonize.DecodeHashStr(test.encoded)
+		require.NoError(t, err, "it should be accepted by default: %s", test.encoded)
+
+		_, err = argonize.DecodeHashStrWith(test.encoded, opts)
+		requireParseError(t, err, test.sentinel, test.segment)
+	}
+}
+
+func TestDecodeHashStrWith_allowed_variants(t *testing.T) {
+	t.Parallel()
+
+	for _, test := range []struct {
+		allowed []argonize.Variant
+		ok      bool
+	}{
+		{nil, true},
+		{[]argonize.Variant{argonize.VariantArgon2id}, true},
+		{[]argonize.Variant{""}, true},
+		{[]argonize.Variant{argonize.VariantArgon2i, argonize.VariantArgon2id}, true},
+		{[]argonize.Variant{argonize.VariantArgon2i}, false},
+		{[]argonize.Variant{}, false},
+	} {
+		_, err := argonize.DecodeHashStrWith(sampleHashStr, argonize.DecodeOptions{AllowedVariants: test.allowed})
+
+		if test.ok {
+			require.NoError(t, err, test.allowed)
+
+			continue
+		}
+
+		requireParseError(t, err, argonize.ErrUnsupportedVariant, argonize.SegmentAlgorithm)
+		require.Contains(t, err.Error(), `variant "argon2id" is not allowed`)
+	}
+}
+
+func TestDecodeHashStrWith_conflicting_options(t *testing.T) {
+	t.Parallel()
+
+	for _, opts := range []argonize.DecodeOptions{
+		{Strict: true, LenientBase64: true},
+		{MemoryUnit: argonize.MemoryUnit(99)},
+	} {
+		hashedObj, err := argonize.DecodeHashStrWith(sampleHashStr, opts)
+
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "invalid decode options")
+		require.Nil(t, hashedObj)
+	}
+}
+
+func TestDecodeHashStrWith_combinations(t *testing.T) {
+	t.Parallel()
+
+	lenient := argonize.DecodeOptions{
+		LenientBase64: true,
+		MaxMemoryCost: 32 * 1024,
+	}
+
+	// Lenient input passes the format checks but not the memory limit
+	encoded := "$argon2id$m=65536,t=3,p=2$" + sampleSaltB64 + "==$" + sampleHashB64
+
+	_, err := argonize.DecodeHashStrWith(encoded, lenient)
+	requireParseError(t, err, argonize.ErrMemoryCostTooHigh, argonize.SegmentParams)
+
+	lenient.MaxMemoryCost = 64 * 1024
+
+	_, err = argonize.DecodeHashStrWith(encoded, lenient)
+	require.NoError(t, err)
+
+	// Strict with the variant and length limits
+	strict := argonize.DecodeOptions{
+		Strict:          true,
+		AllowedVariants: []argonize.Variant{argonize.VariantArgon2id},
+		MaxInputLength:  128,
+	}
+
+	_, err = argonize.DecodeHashStrWith(sampleHashStr, strict)
+	require.NoError(t, err)
+
+	_, err = argonize.DecodeHashStrWith(strings.Replace(sampleHashStr, "argon2id", "argon2i", 1), strict)
+	requireParseError(t, err, argonize.ErrUnsupportedVariant, argonize.SegmentAlgorithm)
+
+	_, err = argonize.DecodeHashStrWith(sampleHashStr+strings.Repeat("A", 128), strict)
+	requireParseError(t, err, argonize.ErrInputTooLong, argonize.SegmentWhole)
+}
+
+func TestDecodeHashStrWith_strict_canonical_base64(t *testing.T) {
+	t.Parallel()
+
+	for _, test := range []struct {
+		encoded   string
+		sentinel  error
+		segment   argonize.Segment
+		byLenient bool // accepted by the lenient mode
+	}{
+		// Non-zero trailing bits in the final character ('x' instead of 'w')
+		{"$argon2id$v=19$m=65536,t=3,p=2$Woo1mErn1s7AHf96ewQ8Ux$" + sampleHashB64, argonize.ErrInvalidSalt, argonize.SegmentSalt, true},
+		// Non-zero trailing bits in the final character ('V' instead of 'U')
+		{"$argon2id$v=19$m=65536,t=3,p=2$" + sampleSaltB64 + "$D4TzIwGO4XD2buk96qAP+Ed2baMo/KbTRMqXX00wtsV", argonize.ErrInvalidHashValue, argonize.SegmentHash, true},
+		// Newlines are ignored by the base64 decoder
+		{"$argon2id$v=19$m=65536,t=3,p=2$Woo1mErn1s7A\nHf96ewQ8Uw$" + sampleHashB64, argonize.ErrInvalidSalt, argonize.SegmentSalt, true},
+		{"$argon2id$v=19$m=65536,t=3,p=2$" + sampleSaltB64 + "$D4TzIwGO4XD2buk96qAP+Ed2\r\nbaMo/KbTRMqXX00wtsU", argonize.ErrInvalidHashValue, argonize.SegmentHash, true},
+	} {
+		encoded := strings.NewReplacer(`\n`, "\n", `\r`, "\r").Replace(test.encoded)
+
+		_, err := argonize.DecodeHashStrStrict(encoded)
+		requireParseError(t, err, test.sentinel, test.segment)
+
+		if test.byLenient {
+			decoded, err := argonize.DecodeHashStrLenient(encoded)
+			require.NoError(t, err, "lenient mode should accept it: %q", encoded)
+
+			_, err = argonize.DecodeHashStrStrict(decoded.String())
+			require.NoError(t, err, "the re-encoded string should be canonical")
+		}
+	}
+}
+
+// ----------------------------------------------------------------------------
+//  DecodeHashStrStrict() / DecodeHashStrLenient()
+// ----------------------------------------------------------------------------
+
+func TestDecodeHashStrStrict(t *testing.T) {
+	t.Parallel()
+
+	_, err := argonize.DecodeHashStrStrict(sampleHashStr)
+	require.NoError(t, err)
+
+	_, err = argonize.DecodeHashStrStrict(strings.Replace(sampleHashStr, "t=3", "t=03", 1))
+	requireParseError(t, err, argonize.ErrMissingParams, argonize.SegmentParams)
+}
+
+func TestDecodeHashStrLenient(t *testing.T) {
+	t.Parallel()
+
+	expect, err := argonize.DecodeHashStr(sampleHashStr)
+	require.NoError(t, err)
+
+	actual, err := argonize.DecodeHashStrLenient("$argon2id$m=65536,t=3,p=2$" + sampleSaltB64 + "==$" + sampleHashB64 + "=")
+	require.NoError(t, err)
+	require.Equal(t, expect, actual)
+}
+
+// ----------------------------------------------------------------------------
+//  DecodeHashStrMemoryUnit()
+// ----------------------------------------------------------------------------
+
+func TestDecodeHashStrMemoryUnit(t *testing.T) {
+	t.Parallel()
+
+	hashedObj, err := argonize.HashCustomChecked([]byte("password"), nil, lowCostParams().WithMemoryMiB(1))
+	require.NoError(t, err)
+
+	// As a non-standard encoder writes it
+	inMiB := strings.Replace(hashedObj.String(), "m=1024,", "m=1,", 1)
+
+	decoded, err := argonize.DecodeHashStrMemoryUnit(inMiB, argonize.MemoryUnitMiB)
+	require.NoError(t, err)
+	require.Equal(t, uint32(1024), decoded.Params.MemoryCost, "it should be normalized to KiB")
+	require.Equal(t, hashedObj.String(), decoded.String(), "it should encode as the standard PHC string")
+	require.True(t, decoded.IsValidPassword([]byte("password")))
+
+	// KiB is the default and the same as DecodeHashStr()
+	decoded, err = argonize.DecodeHashStrMemoryUnit(hashedObj.String(), argonize.MemoryUnitKiB)
+	require.NoError(t, err)
+	require.Equal(t, hashedObj, decoded)
+
+	// The limit is still in KiB
+	_, err = argonize.DecodeHashStrWith(strings.Replace(sampleHashStr, "m=65536", "m=64", 1),
+		argonize.DecodeOptions{MemoryUnit: argonize.MemoryUnitMiB, MaxMemoryCost: 65535})
+	requireParseError(t, err, argonize.ErrMemoryCostTooHigh, argonize.SegmentParams)
+
+	// Overflow of uint32 in KiB
+	_, err = argonize.DecodeHashStrMemoryUnit(strings.Replace(sampleHashStr, "m=65536", "m=4194304", 1),
+		argonize.MemoryUnitMiB)
+	requireParseError(t, err, argonize.ErrMissingParams, argonize.SegmentParams)
+	require.ErrorContains(t, err, "memory 4194304 MiB overflows 4194303 MiB")
+
+	require.Equal(t, "MiB", argonize.MemoryUnitMiB.String())
+	require.Equal(t, "KiB", argonize.MemoryUnitKiB.String())
+	require.Equal(t, "unknown", argonize.MemoryUnit(99).String())
+}
+
+// ----------------------------------------------------------------------------
+//  DecodeHashStrPrefix()
+// ----------------------------------------------------------------------------
+
+func TestDecodeHashStrPrefix(t *testing.T) {
+	t.Parallel()
+
+	for _, test := range []struct {
+		input      string
+		expectRest string
+	}{
+		{sampleHashStr, ""},
+		{sampleHashStr + " 2024-01-02T03:04:05Z", "2024-01-02T03:04:05Z"},
+		{sampleHashStr + "\t \tcreated_at=1700000000 by=admin", "created_at=1700000000 by=admin"},
+		{sampleHashStr + "\n", ""},
+		{sampleHashStr + "   ", ""},
+	} {
+		hashed, rest, err := argonize.DecodeHashStrPrefix(test.input)
+		require.NoError(t, err, "input: %q", test.input)
+		require.Equal(t, sampleHashStr, hashed.String())
+		require.Equal(t, test.expectRest, rest)
+	}
+
+	// DecodeHashStr() should not accept the trailing content
+	_, err := argonize.DecodeHashStr(sampleHashStr + " 2024-01-02T03:04:05Z")
+	require.Error(t, err)
+}
+
+func TestDecodeHashStrPrefix_bad_hash(t *testing.T) {
+	t.Parallel()
+
+	for _, input := range []string{
+		"",
+		" " + sampleHashStr,
+		"$argon2id$v=19$m=65536,t=3,p=2$Woo1mErn1s7AHf96ewQ8Uw 2024-01-02",
+		"not a hash",
+	} {
+		hashed, rest, err := argonize.DecodeHashStrPrefix(input)
+		require.Error(t, err, "input: %q", input)
+		require.Nil(t, hashed)
+		require.Empty(t, rest)
+	}
+
+	_, _, err := argonize.DecodeHashStrPrefix("$argon2id$v=19$m=65536,t=3,p=2$!!!$" + sampleHashB64 + " rest")
+	requireParseError(t, err, argonize.ErrInvalidSalt, argonize.SegmentSalt)
+}
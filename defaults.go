@@ -0,0 +1,76 @@
+package argonize
+
+import (
+	"sync/atomic"
+
+	"github.com/pkg/errors"
+)
+
+// globalDefaultParams is the default Params set by SetDefaultParams(). Nil
+// means the baseline of the *Default constants.
+//
+//nolint:gochecknoglobals // set via SetDefaultParams() only
+var globalDefaultParams atomic.Pointer[Params]
+
+// ============================================================================
+//  Functions
+// ============================================================================
+
+// DefaultParams returns a copy of the package-wide default parameters used by
+// NewParams(), Params.SetDefault(), Hash() and the functions depending on them.
+// They are the *Default constants unless changed with SetDefaultParams().
+func DefaultParams() *Params {
+	params := currentDefaultParams()
+
+	return &params
+}
+
+// SetDefaultParams validates and installs a copy of p as the package-wide
+// default parameters, such as the organization-wide memory cost. If p is nil,
+// the baseline of the *Default constants is restored. It is safe for concurrent
+// use.
+//
+// Call it once at the initialization of the application, before hashing. The
+// parameters decoded from the existing hash strings are not affected, but
+// DummyHash() keeps the defaults of its first call. The Variant and MaxThreads
+// are not part of the defaults and must be zero.
+func SetDefaultParams(p *Params) error {
+	if p == nil {
+		globalDefaultParams.Store(nil)
+
+		return nil
+	}
+
+	if p.Variant != "" || p.MaxThreads != 0 {
+		return errors.New("failed to set the default params: variant and max threads must be zero")
+	}
+
+	if err := p.Validate(); err != nil {
+		return errors.Wrap(err, "failed to set the default params")
+	}
+
+	params := *p
+
+	globalDefaultParams.Store(&params)
+
+	return nil
+}
+
+// ----------------------------------------------------------------------------
+//  Private Functions
+// ----------------------------------------------------------------------------
+
+// currentDefaultParams returns the package-wide default parameters.
+func currentDefaultParams() Params {
+	if params := globalDefaultParams.Load(); params != nil {
+		return *params
+	}
+
+	return Params{
+		Iterations:  IterationsDefault,
+		KeyLength:   KeyLengthDefault,
+		MemoryCost:  MemoryCostDefault,
+		SaltLength:  SaltLengthDefault,
+		Parallelism: ParallelismDefault,
+	}
+}
@@ -0,0 +1,87 @@
+package argonize_test
+
+import (
+	"testing"
+
+	"github.com/KEINOS/go-argonize"
+	"github.com/stretchr/testify/require"
+)
+
+// ----------------------------------------------------------------------------
+//  SetDefaultParams()
+// ----------------------------------------------------------------------------
+
+//nolint:paralleltest // disable parallel since it changes the package-wide defaults
+func TestSetDefaultParams(t *testing.T) {
+	defer func() {
+		require.NoError(t, argonize.SetDefaultParams(nil))
+	}()
+
+	orgDefaults := &argonize.Params{
+		Iterations:  2,
+		KeyLength:   64,
+		MemoryCost:  64,
+		SaltLength:  24,
+		Parallelism: 1,
+	}
+
+	require.NoError(t, argonize.SetDefaultParams(orgDefaults))
+
+	// It should install a copy
+	orgDefaults.MemoryCost = 1
+
+	require.Equal(t, uint32(64), argonize.DefaultParams().MemoryCost)
+	require.Equal(t, uint32(64), argonize.NewParams().MemoryCost)
+
+	// DefaultParams should return a copy as well
+	argonize.DefaultParams().MemoryCost = 1
+	require.Equal(t, uint32(64), argonize.DefaultParams().MemoryCost)
+
+	// Hash should use the defaults
+	hashedObj, err := argonize.Hash([]byte("password"))
+	require.NoError(t, err)
+	require.Len(t, hashedObj.Salt, 24)
+	require.Len(t, hashedObj.Hash, 64)
+	require.Contains(t, hashedObj.String(), "$m=64,t=2,p=1$")
+
+	// Decoding should not be affected
+	decoded, err := argonize.DecodeHashStr(sampleHashStr)
+	require.NoError(t, err)
+	require.Equal(t, uint32(32), decoded.Params.KeyLength)
+	require.Equal(t, uint32(16), decoded.Params.SaltLength)
+	require.Equal(t, uint32(65536), decoded.Params.MemoryCost)
+
+	// Nil should restore the baseline
+	require.NoError(t, argonize.SetDefaultParams(nil))
+
+	params := argonize.NewParams()
+	require.Equal(t, argonize.MemoryCostDefault, params.MemoryCost)
+	require.Equal(t, argonize.IterationsDefault, params.Iterations)
+	require.Equal(t, argonize.KeyLengthDefault, params.KeyLength)
+	require.Equal(t, argonize.SaltLengthDefault, params.SaltLength)
+	require.Equal(t, argonize.ParallelismDefault, params.Parallelism)
+}
+
+func TestSetDefaultParams_invalid(t *testing.T) {
+	t.Parallel()
+
+	before := argonize.DefaultParams()
+
+	err := argonize.SetDefaultParams(&argonize.Params{})
+	require.ErrorIs(t, err, argonize.ErrZeroParams)
+	require.ErrorContains(t, err, "failed to set the default params")
+
+	params := argonize.NewParams()
+	params.Iterations = 0
+
+	err = argonize.SetDefaultParams(params)
+	require.ErrorIs(t, err, argonize.ErrIterationsTooLow)
+
+	params = argonize.NewParams()
+	params.Variant = argonize.VariantArgon2i
+
+	err = argonize.SetDefaultParams(params)
+	require.ErrorContains(t, err, "variant and max threads must be zero")
+
+	require.Equal(t, before, argonize.DefaultParams(), "defaults should not change on error")
+}
@@ -0,0 +1,44 @@
+package argonize
+
+import (
+	"crypto/sha256"
+	"io"
+
+	"github.com/pkg/errors"
+	"golang.org/x/crypto/hkdf"
+)
+
+// ============================================================================
+//  Functions
+// ============================================================================
+
+// NewDeterministicSalt returns the salt of the length derived from the seed and
+// the context with HKDF-SHA256, where the seed is the input key material and
+// the context is the info. The same seed, context and length always give the
+// same salt, and the different contexts give independent ones.
+//
+// It is ONLY for the reproducible fixtures, such as the golden files and the
+// examples of the documentation, and for the content-addressed use cases.
+// NEVER use it for the user credentials: the salts of the same seed and context
+// collide, which defeats the purpose of the salt. Use NewSalt() instead.
+//
+// It returns an error if the seed is empty or the length is shorter than
+// SaltLengthMin or longer than 8160 bytes.
+func NewDeterministicSalt(seed []byte, context string, length uint32) (Salt, error) {
+	if len(seed) == 0 {
+		return nil, errors.New("failed to derive salt: the seed is empty")
+	}
+
+	if length < SaltLengthMin || length > hkdfLengthMax {
+		return nil, errors.Errorf("failed to derive salt: length %d is out of range %d..%d",
+			length, SaltLengthMin, hkdfLengthMax)
+	}
+
+	salt := make(Salt, length)
+
+	if _, err := io.ReadFull(hkdf.New(sha256.New, seed, nil, []byte(context)), salt); err != nil {
+		return nil, errors.Wrap(err, "failed to derive salt")
+	}
+
+	return salt, nil
+}
@@ -0,0 +1,64 @@
+package argonize_test
+
+import (
+	"encoding/hex"
+	"testing"
+
+	"github.com/KEINOS/go-argonize"
+	"github.com/stretchr/testify/require"
+)
+
+// ----------------------------------------------------------------------------
+//  NewDeterministicSalt()
+// ----------------------------------------------------------------------------
+
+func TestNewDeterministicSalt(t *testing.T) {
+	t.Parallel()
+
+	// The vectors are pinned forever. They were computed with an independent
+	// HKDF-SHA256 implementation (Python's hmac and hashlib).
+	for _, test := range []struct {
+		context string
+		expect  string
+		length  uint32
+	}{
+		{context: "argonize test vector", length: 16, expect: "a51e8677bf1c4c7b301e671b4c4e67cb"},
+		{
+			context: "argonize test vector",
+			length:  40,
+			expect:  "a51e8677bf1c4c7b301e671b4c4e67cb3dc6886865fbefbc5e6afb0fa85c5644e3132a660f0d9830",
+		},
+	} {
+		salt, err := argonize.NewDeterministicSalt([]byte("seed"), test.context, test.length)
+		require.NoError(t, err)
+		require.Equal(t, test.expect, hex.EncodeToString(salt), "length: %d", test.length)
+	}
+
+	salt1, err := argonize.NewDeterministicSalt([]byte("seed"), "fixture 1", 16)
+	require.NoError(t, err)
+
+	salt1Again, err := argonize.NewDeterministicSalt([]byte("seed"), "fixture 1", 16)
+	require.NoError(t, err)
+	require.Equal(t, salt1, salt1Again, "same inputs should give the same salt")
+
+	salt2, err := argonize.NewDeterministicSalt([]byte("seed"), "fixture 2", 16)
+	require.NoError(t, err)
+	require.NotEqual(t, salt1, salt2, "different contexts should differ")
+
+	salt3, err := argonize.NewDeterministicSalt([]byte("other seed"), "fixture 1", 16)
+	require.NoError(t, err)
+	require.NotEqual(t, salt1, salt3, "different seeds should differ")
+}
+
+func TestNewDeterministicSalt_errors(t *testing.T) {
+	t.Parallel()
+
+	_, err := argonize.NewDeterministicSalt(nil, "context", 16)
+	require.ErrorContains(t, err, "the seed is empty")
+
+	_, err = argonize.NewDeterministicSalt([]byte("seed"), "context", argonize.SaltLengthMin-1)
+	require.ErrorContains(t, err, "length 7 is out of range 8..8160")
+
+	_, err = argonize.NewDeterministicSalt([]byte("seed"), "context", 8161)
+	require.ErrorContains(t, err, "length 8161 is out of range")
+}
@@ -0,0 +1,63 @@
+package argonize
+
+import (
+	"crypto/rand"
+	"sync"
+
+	"github.com/pkg/errors"
+)
+
+// dummyHash computes the Hashed object of DummyHash() once.
+//
+//nolint:gochecknoglobals // computed once and reused
+var dummyHash = sync.OnceValue(func() *Hashed {
+	// Nobody knows the password, so nothing matches the dummy.
+	password := make([]byte, KeyLengthDefault)
+
+	if _, err := rand.Read(password); err != nil {
+		panic(errors.Wrap(err, "failed to create the dummy hash"))
+	}
+
+	hashed, err := Hash(password)
+	if err != nil {
+		panic(errors.Wrap(err, "failed to create the dummy hash"))
+	}
+
+	return hashed
+})
+
+// ============================================================================
+//  Functions
+// ============================================================================
+
+// DummyHash returns a dummy Hashed object with the default parameters of
+// NewParams(), the same ones as Hash(). It is computed once on the first call
+// and every call returns a copy of it.
+//
+// Verify the password against it when the user does not exist, so that the
+// response takes the same time as for an existing user and the user names can
+// not be enumerated by timing:
+//
+//	hashed, found := lookupUser(name)
+//	if !found {
+//		argonize.DummyHash().IsValidPassword(password) // spend the same time
+//
+//		return false
+//	}
+//
+//	return hashed.IsValidPassword(password)
+//
+// The password of the dummy is random and discarded, so no password matches
+// it. Still, always treat an absent user as a failure regardless of the result.
+// Since the parameters are the defaults, it matches the timing only if the real
+// hashes use the defaults as well.
+func DummyHash() *Hashed {
+	dummy := dummyHash()
+	params := *dummy.Params
+
+	return &Hashed{
+		Params: &params,
+		Salt:   append(Salt(nil), dummy.Salt...),
+		Hash:   append([]byte(nil), dummy.Hash...),
+	}
+}
@@ -0,0 +1,35 @@
+package argonize_test
+
+import (
+	"testing"
+
+	"github.com/KEINOS/go-argonize"
+	"github.com/stretchr/testify/require"
+)
+
+// ----------------------------------------------------------------------------
+//  DummyHash()
+// ----------------------------------------------------------------------------
+
+func TestDummyHash(t *testing.T) {
+	t.Parallel()
+
+	dummy1 := argonize.DummyHash()
+
+	require.NoError(t, dummy1.Validate())
+	require.Equal(t, argonize.NewParams(), dummy1.Params, "it should use the same params as Hash()")
+	require.False(t, dummy1.IsValidPassword([]byte("")))
+	require.False(t, dummy1.IsValidPassword([]byte("password")))
+
+	// It should be computed once and reused
+	dummy2 := argonize.DummyHash()
+	require.Equal(t, dummy1.String(), dummy2.String())
+
+	// Every call returns a copy
+	dummy2.Params.Iterations = 1
+	dummy2.Salt[0]++
+	dummy2.Hash[0]++
+
+	require.Equal(t, dummy1.String(), argonize.DummyHash().String(),
+		"modifying the returned object should not affect the others")
+}
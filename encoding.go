@@ -0,0 +1,240 @@
+package argonize
+
+import (
+	"encoding/base64"
+	"encoding/binary"
+	"encoding/json"
+	"strconv"
+
+	"github.com/pkg/errors"
+	"golang.org/x/crypto/argon2"
+)
+
+// ============================================================================
+//  Binary Encoding
+// ============================================================================
+//
+// The binary encoding of Hashed starts with its format version, followed by
+// the big-endian layout of the version. The current BinaryVersion is:
+//
+//	| version (1) = 1 | memory cost (4) | iterations (4) | parallelism (1) |
+//	| variant length (4) | variant (n) | salt length (4) | salt (n) |
+//	| hash length (4) | hash (n) | key ID length (4) | key ID (n) |
+//	| data length (4) | data (n) |
+//
+// The variant is the name as in the PHC string, such as "argon2id". Unlike the
+// compact encoding (see EncodeCompact), it is self-describing and can be
+// decoded without knowing the parameters.
+
+// BinaryVersion is the format version of the binary encoding written by
+// Hashed.AppendBinary() and EncodeBinary().
+const BinaryVersion = uint8(1)
+
+// lenBinHeader is the length of the fixed part of the binary encoding.
+const lenBinHeader = 1 + 4 + 4 + 1 + 4*5
+
+// ============================================================================
+//  Functions
+// ============================================================================
+
+// DecodeBinary decodes the binary encoding of Hashed.AppendBinary() or
+// EncodeBinary().
+// It returns an error if the version is unknown or the data is invalid.
+func DecodeBinary(data []byte) (*Hashed, error) {
+	if len(data) == 0 {
+		return nil, errors.New("failed to binary decode the hash: data is too short")
+	}
+
+	if data[0] != BinaryVersion {
+		return nil, errors.Errorf(
+			"failed to binary decode the hash: unsupported version %d (supported up to %d)",
+			data[0], BinaryVersion)
+	}
+
+	if len(data) < lenBinHeader {
+		return nil, errors.New("failed to binary decode the hash: data is too short")
+	}
+
+	params := NewParams()
+
+	params.MemoryCost = binary.BigEndian.Uint32(data[1:5])
+	params.Iterations = binary.BigEndian.Uint32(data[5:9])
+	params.Parallelism = data[9]
+	rest := data[10:]
+
+	chunks := make([][]byte, 5)
+
+	for index, name := range []string{"variant", "salt", "hash", "key ID", "data"} {
+		var err error
+
+		chunks[index], rest, err = readBinaryChunk(rest)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to binary decode the %s", name)
+		}
+	}
+
+	if len(rest) != 0 {
+		return nil, errors.New("failed to binary decode the hash: trailing data")
+	}
+
+	variant, err := ParseVariant(string(chunks[0]))
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to binary decode the hash")
+	}
+
+	params.Variant = variant
+
+	return newDecodedBinary(params, chunks[1], chunks[2], string(chunks[3]), string(chunks[4]))
+}
+
+// EncodeBinary returns the binary encoding of the hash in the current
+// BinaryVersion. It is the same as h.AppendBinary(nil).
+func EncodeBinary(h *Hashed) ([]byte, error) {
+	return h.AppendBinary(nil)
+}
+
+// hashedJSON is Hashed without its methods, to encode it as the plain object
+// in MarshalJSON().
+type hashedJSON Hashed
+
+// ----------------------------------------------------------------------------
+//  Methods of Hashed
+// ----------------------------------------------------------------------------
+
+// AppendBinary implements the encoding.BinaryAppender interface. It appends the
+// binary encoding of the hash in the current BinaryVersion to b and returns the
+// extended buffer. No extra allocation occurs if b has enough capacity. Use
+// DecodeBinary() to decode it.
+func (h *Hashed) AppendBinary(b []byte) ([]byte, error) {
+	if h == nil || h.Params == nil {
+		return nil, errors.New("failed to binary encode the hash: params are nil")
+	}
+
+	if len(h.Hash) == 0 {
+		return nil, errors.New("failed to binary encode the hash: hash value is empty")
+	}
+
+	if !h.Params.Variant.isSupported() {
+		return nil, errors.Errorf(
+			"failed to binary encode the hash: unsupported variant %q", h.Params.Variant)
+	}
+
+	b = append(b, BinaryVersion)
+	b = binary.BigEndian.AppendUint32(b, h.Params.MemoryCost)
+	b = binary.BigEndian.AppendUint32(b, h.Params.Iterations)
+	b = append(b, h.Params.Parallelism)
+	b = appendBinaryChunk(b, h.Params.Variant.String())
+	b = appendBinaryChunk(b, h.Salt)
+	b = appendBinaryChunk(b, h.Hash)
+	b = appendBinaryChunk(b, h.KeyID)
+	b = appendBinaryChunk(b, h.Data)
+
+	return b, nil
+}
+
+// AppendText implements the encoding.TextAppender interface. It appends the
+// same string as String() to b and returns the extended buffer. No extra
+// allocation occurs if b has enough capacity.
+func (h *Hashed) AppendText(b []byte) ([]byte, error) {
+	if h == nil || h.Params == nil {
+		return nil, errors.New("failed to text encode the hash: params are nil")
+	}
+
+	return h.appendString(b, base64.RawStdEncoding), nil
+}
+
+// MarshalJSON implements the json.Marshaler interface. It pins the JSON of
+// Hashed to the plain object of its fields. Without it, the encoding/json of
+// the newer Go releases would encode Hashed as the string of AppendText().
+func (h *Hashed) MarshalJSON() ([]byte, error) {
+	return json.Marshal((*hashedJSON)(h))
+}
+
+// ----------------------------------------------------------------------------
+//  Methods of Hashed (Private)
+// ----------------------------------------------------------------------------
+
+// appendString appends the standard encoded hash representation of the Argon2
+// algorithm to b with the base64 alphabet of enc. It is the shared formatter of
+// String(), StringURLSafe() and AppendText().
+func (h *Hashed) appendString(b []byte, enc *base64.Encoding) []byte {
+	b = append(b, '$')
+	b = append(b, h.Params.Variant.String()...)
+	b = append(b, "$v="...)
+	b = strconv.AppendInt(b, argon2.Version, 10)
+	b = append(b, '$')
+	b = h.Params.appendParamString(b, false)
+
+	if h.KeyID != "" {
+		b = append(b, ",keyid="...)
+		b = enc.AppendEncode(b, []byte(h.KeyID))
+	}
+
+	if h.Data != "" {
+		b = append(b, ",data="...)
+		b = enc.AppendEncode(b, []byte(h.Data))
+	}
+
+	b = append(b, '$')
+	b = enc.AppendEncode(b, h.Salt)
+	b = append(b, '$')
+	b = enc.AppendEncode(b, h.Hash)
+
+	return b
+}
+
+// ----------------------------------------------------------------------------
+//  Private Functions
+// ----------------------------------------------------------------------------
+
+// appendBinaryChunk appends the uint32 length-prefixed chunk to b.
+func appendBinaryChunk[T ~string | ~[]byte](b []byte, chunk T) []byte {
+	b = binary.BigEndian.AppendUint32(b, uint32(len(chunk))) //nolint:gosec // chunks are never that long
+	b = append(b, chunk...)
+
+	return b
+}
+
+// newDecodedBinary returns the validated Hashed of the decoded binary fields.
+// It copies the salt and the hash, so the decoded buffer can be reused.
+func newDecodedBinary(params *Params, salt, hash []byte, keyID, data string) (*Hashed, error) {
+	if len(salt) < int(SaltLengthMin) || len(hash) == 0 {
+		return nil, errors.New("hash or salt length is too long or too short")
+	}
+
+	params.SaltLength = uint32(len(salt)) //nolint:gosec // length is read from uint32
+	params.KeyLength = uint32(len(hash))  //nolint:gosec // length is read from uint32
+
+	hashed := &Hashed{
+		Params: params,
+		Salt:   Salt(append([]byte{}, salt...)),
+		Hash:   append([]byte{}, hash...),
+		KeyID:  keyID,
+		Data:   data,
+	}
+
+	if err := hashed.Validate(); err != nil {
+		return nil, errors.Wrap(err, "failed to binary decode the hash")
+	}
+
+	return hashed, nil
+}
+
+// readBinaryChunk reads a uint32 length-prefixed chunk from data and returns
+// the chunk and the rest of the data.
+func readBinaryChunk(data []byte) ([]byte, []byte, error) {
+	const lenPrefix = 4
+
+	if len(data) < lenPrefix {
+		return nil, nil, errors.New("missing length prefix")
+	}
+
+	lenChunk := uint64(binary.BigEndian.Uint32(data))
+	data = data[lenPrefix:]
+
+	if uint64(len(data)) < lenChunk {
+		return nil, nil, errors.New("data is shorter than the length prefix")
+	}
+
+	return data[:lenChunk], data[lenChunk:], nil
+}
@@ -0,0 +1,13 @@
+//go:build go1.24
+
+package argonize
+
+import "encoding"
+
+// Compile-time checks of the appender interfaces added in Go 1.24. Hashed does
+// not implement the marshaler interfaces, so encoding/gob and encoding/json
+// keep encoding it as a plain struct.
+var (
+	_ encoding.BinaryAppender = (*Hashed)(nil)
+	_ encoding.TextAppender   = (*Hashed)(nil)
+)
@@ -0,0 +1,232 @@
+package argonize_test
+
+import (
+	"encoding"
+	"encoding/gob"
+	"encoding/hex"
+	"encoding/json"
+	"testing"
+
+	"github.com/KEINOS/go-argonize"
+	"github.com/stretchr/testify/require"
+)
+
+const sampleHashStr = "$argon2id$v=19$m=65536,t=3,p=2$Woo1mErn1s7AHf96ewQ8Uw$D4TzIwGO4XD2buk96qAP+Ed2baMo/KbTRMqXX00wtsU"
+
+// sampleBinaryHex is the binary encoding of sampleHashStr in BinaryVersion 1.
+const sampleBinaryHex = "01" + // version 1
+	"00010000" + "00000003" + "02" + // m=65536, t=3, p=2
+	"00000008" + "6172676f6e326964" + // variant "argon2id"
+	"00000010" + "5a8a35984ae7d6cec01dff7a7b043c53" + // salt
+	"00000020" + "0f84f323018ee170f66ee93deaa00ff847766da328fca6d344ca975f4d30b6c5" + // hash
+	"00000000" + // key ID
+	"00000000" // data
+
+// ----------------------------------------------------------------------------
+//  Hashed
+// ----------------------------------------------------------------------------
+
+func TestHashed_encoding_shape(t *testing.T) {
+	t.Parallel()
+
+	hashedObj, err := argonize.DecodeHashStr(sampleHashStr)
+	require.NoError(t, err)
+
+	// Hashed must not implement the encoding interfaces, which would change its
+	// gob and JSON representations from the plain struct. The appenders are
+	// ignored by gob, and MarshalJSON() keeps the JSON a plain object.
+	var value any = hashedObj
+
+	for name, implemented := range map[string]bool{
+		"BinaryMarshaler":   func() bool { _, ok := value.(encoding.BinaryMarshaler); return ok }(),
+		"BinaryUnmarshaler": func() bool { _, ok := value.(encoding.BinaryUnmarshaler); return ok }(),
+		"TextMarshaler":     func() bool { _, ok := value.(encoding.TextMarshaler); return ok }(),
+		"TextUnmarshaler":   func() bool { _, ok := value.(encoding.TextUnmarshaler); return ok }(),
+		"GobEncoder":        func() bool { _, ok := value.(gob.GobEncoder); return ok }(),
+	} {
+		require.False(t, implemented, "Hashed should not implement %s", name)
+	}
+
+	jsonEnc, err := json.Marshal(hashedObj)
+	require.NoError(t, err)
+
+	var decoded argonize.Hashed
+
+	require.NoError(t, json.Unmarshal(jsonEnc, &decoded))
+	require.Equal(t, hashedObj, &decoded, "JSON should round trip as a plain struct")
+	require.Contains(t, string(jsonEnc), `"Params":{`)
+}
+
+// ----------------------------------------------------------------------------
+//  Hashed.AppendBinary() and DecodeBinary()
+// ----------------------------------------------------------------------------
+
+func TestHashed_AppendBinary_round_trip(t *testing.T) {
+	t.Parallel()
+
+	hashedObj, err := argonize.DecodeHashStr(sampleHashStr)
+	require.NoError(t, err)
+
+	prefix := []byte("prefix")
+
+	out, err := hashedObj.AppendBinary(prefix)
+	require.NoError(t, err)
+	require.Equal(t, prefix, out[:len(prefix)], "it should keep the given buffer")
+	require.Equal(t, sampleBinaryHex, hex.EncodeToString(out[len(prefix):]),
+		"the current version should match its fixture")
+
+	decoded, err := argonize.DecodeBinary(out[len(prefix):])
+	require.NoError(t, err)
+	require.Equal(t, hashedObj, decoded)
+}
+
+func TestHashed_AppendBinary_all_fields(t *testing.T) {
+	t.Parallel()
+
+	for _, hashStr := range []string{
+		"$argon2i$v=19$m=65536,t=3,p=2$" + sampleSaltB64 + "$" + sampleHashB64,
+		"$argon2id$v=19$m=65536,t=3,p=2,keyid=djE,data=cHJvZmlsZQ$" + sampleSaltB64 + "$" + sampleHashB64,
+	} {
+		hashedObj, err := argonize.DecodeHashStr(hashStr)
+		require.NoError(t, err)
+
+		out, err := argonize.EncodeBinary(hashedObj)
+		require.NoError(t, err)
+
+		decoded, err := argonize.DecodeBinary(out)
+		require.NoError(t, err)
+		require.Equal(t, hashedObj, decoded)
+		require.Equal(t, hashStr, decoded.String())
+	}
+}
+
+func TestDecodeBinary_bad_data(t *testing.T) {
+	t.Parallel()
+
+	valid, err := hex.DecodeString(sampleBinaryHex)
+	require.NoError(t, err)
+
+	const lenFixed = 1 + 4 + 4 + 1
+
+	badVariant := append([]byte{}, valid...)
+	copy(badVariant[lenFixed+4:], "argon2x")
+
+	for _, test := range []struct {
+		data       []byte
+		msgContain string
+	}{
+		{nil, "data is too short"},
+		{[]byte{argonize.BinaryVersion + 1}, "unsupported version 2 (supported up to 1)"},
+		{[]byte{0x00, 0x01}, "unsupported version 0"},
+		{valid[:lenFixed], "data is too short"},
+		{valid[:len(valid)-1], "failed to binary decode the data: missing length prefix"},
+		{valid[:lenFixed+4+8+4+16+4+1], "failed to binary decode the hash: data is shorter than the length prefix"},
+		{append(append([]byte{}, valid...), 0x00), "trailing data"},
+		{badVariant, `unsupported algorithm variant "argon2xd"`},
+	} {
+		decoded, err := argonize.DecodeBinary(test.data)
+
+		require.Error(t, err)
+		require.Contains(t, err.Error(), test.msgContain)
+		require.Nil(t, decoded)
+	}
+}
+
+func TestHashed_AppendBinary_empty(t *testing.T) {
+	t.Parallel()
+
+	out, err := new(argonize.Hashed).AppendBinary(nil)
+
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "params are nil")
+	require.Nil(t, out)
+
+	out, err = argonize.EncodeBinary(&argonize.Hashed{Params: argonize.NewParams()})
+
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "hash value is empty")
+	require.Nil(t, out)
+
+	hashedObj, err := argonize.DecodeHashStr(sampleHashStr)
+	require.NoError(t, err)
+
+	hashedObj.Params.Variant = "unknown"
+
+	out, err = argonize.EncodeBinary(hashedObj)
+
+	require.ErrorContains(t, err, `unsupported variant "unknown"`)
+	require.Nil(t, out)
+}
+
+// ----------------------------------------------------------------------------
+//  Hashed.AppendText()
+// ----------------------------------------------------------------------------
+
+func TestHashed_AppendText(t *testing.T) {
+	t.Parallel()
+
+	hashedObj, err := argonize.DecodeHashStr(sampleHashStr)
+	require.NoError(t, err)
+
+	out, err := hashedObj.AppendText([]byte("hash: "))
+	require.NoError(t, err)
+	require.Equal(t, "hash: "+sampleHashStr, string(out))
+
+	out, err = new(argonize.Hashed).AppendText(nil)
+	require.Error(t, err)
+	require.Nil(t, out)
+}
+
+// ----------------------------------------------------------------------------
+//  Benchmarks
+// ----------------------------------------------------------------------------
+
+func BenchmarkHashed_AppendText(b *testing.B) {
+	hashedObj, err := argonize.DecodeHashStr(sampleHashStr)
+	if err != nil {
+		b.Fatal(err)
+	}
+
+	buf := make([]byte, 0, 256)
+
+	b.ReportAllocs()
+	b.ResetTimer()
+
+	for range b.N {
+		if _, err := hashedObj.AppendText(buf[:0]); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkHashed_AppendBinary(b *testing.B) {
+	hashedObj, err := argonize.DecodeHashStr(sampleHashStr)
+	if err != nil {
+		b.Fatal(err)
+	}
+
+	buf := make([]byte, 0, 256)
+
+	b.ReportAllocs()
+	b.ResetTimer()
+
+	for range b.N {
+		if _, err := hashedObj.AppendBinary(buf[:0]); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkHashed_String(b *testing.B) {
+	hashedObj, err := argonize.DecodeHashStr(sampleHashStr)
+	if err != nil {
+		b.Fatal(err)
+	}
+
+	b.ReportAllocs()
+	b.ResetTimer()
+
+	for range b.N {
+		_ = hashedObj.String()
+	}
+}
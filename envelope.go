@@ -0,0 +1,104 @@
+package argonize
+
+import (
+	"bytes"
+	"fmt"
+
+	"github.com/pkg/errors"
+)
+
+// ============================================================================
+//  Envelope Format
+// ============================================================================
+//
+// The envelope wraps the binary encoding of Hashed for the long-term storage,
+// so that the format can change without breaking the stored data:
+//
+//	| magic "ARGE" (4) | envelope version (1) | payload (n) |
+//
+// The payload of each version is:
+//
+//	1: the binary encoding of Hashed.AppendBinary() without its leading
+//	   version byte, i.e. the envelope version doubles as the BinaryVersion
+//
+// Any change of the binary format goes through a new envelope version, and the
+// old versions remain decodable forever. Each version has its checked-in
+// fixtures in the tests.
+
+// EnvelopeVersion is the envelope version written by EncodeEnvelope().
+const EnvelopeVersion = BinaryVersion
+
+const (
+	// envelopeMagic is the magic number at the beginning of the envelope.
+	envelopeMagic = "ARGE"
+	// lenEnvelopeHeader is the length of the header of the envelope.
+	lenEnvelopeHeader = len(envelopeMagic) + 1
+)
+
+// ============================================================================
+//  Type: EnvelopeVersionError
+// ============================================================================
+
+// EnvelopeVersionError is the error of DecodeEnvelope() for an envelope of an
+// unknown version, such as the one written by a newer release of the package.
+type EnvelopeVersionError struct {
+	// Version is the version seen in the envelope.
+	Version uint8
+}
+
+// Error implements the error interface.
+func (e *EnvelopeVersionError) Error() string {
+	return fmt.Sprintf("unsupported envelope version %d (supported up to %d)", e.Version, EnvelopeVersion)
+}
+
+// ============================================================================
+//  Functions
+// ============================================================================
+
+// EncodeEnvelope returns the envelope of the hash in the current
+// EnvelopeVersion. Use DecodeEnvelope() to decode it.
+//
+// The payload carries the variant, KeyID and Data of the hash as well. It
+// returns an error if the hash can not be binary encoded.
+func EncodeEnvelope(h *Hashed) ([]byte, error) {
+	b := make([]byte, 0, len(envelopeMagic)+lenBinHeader+64)
+	b = append(b, envelopeMagic...)
+
+	// Hashed.AppendBinary() writes the version byte of the envelope
+	b, err := h.AppendBinary(b)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to encode the envelope")
+	}
+
+	return b, nil
+}
+
+// DecodeEnvelope decodes the envelope of EncodeEnvelope() of any version
+// released so far. It returns an error wrapping *EnvelopeVersionError if the
+// version is unknown, and an error if the data is not an envelope or the
+// payload is invalid.
+func DecodeEnvelope(data []byte) (*Hashed, error) {
+	if len(data) < lenEnvelopeHeader || !bytes.HasPrefix(data, []byte(envelopeMagic)) {
+		return nil, errors.New("failed to decode the envelope: bad magic number")
+	}
+
+	version := data[len(envelopeMagic)]
+
+	var (
+		hashed *Hashed
+		err    error
+	)
+
+	switch version {
+	case 1:
+		hashed, err = DecodeBinary(data[len(envelopeMagic):])
+	default:
+		err = &EnvelopeVersionError{Version: version}
+	}
+
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to decode the envelope")
+	}
+
+	return hashed, nil
+}
@@ -0,0 +1,122 @@
+package argonize_test
+
+import (
+	"encoding/hex"
+	"errors"
+	"testing"
+
+	"github.com/KEINOS/go-argonize"
+	"github.com/stretchr/testify/require"
+)
+
+// envelopeFixtures are the envelopes of sampleHashStr written by each envelope
+// version. They must remain decodable forever. Add the fixture of a new
+// version here instead of replacing the old ones.
+//
+//nolint:gochecknoglobals // test fixtures
+var envelopeFixtures = map[uint8]string{
+	1: "41524745" + sampleBinaryHex, // magic "ARGE" and the binary encoding of version 1
+}
+
+// ----------------------------------------------------------------------------
+//  EncodeEnvelope()
+// ----------------------------------------------------------------------------
+
+func TestEncodeEnvelope(t *testing.T) {
+	t.Parallel()
+
+	hashedObj, err := argonize.DecodeHashStr(sampleHashStr)
+	require.NoError(t, err)
+
+	envelope, err := argonize.EncodeEnvelope(hashedObj)
+	require.NoError(t, err)
+	require.Equal(t, envelopeFixtures[argonize.EnvelopeVersion], hex.EncodeToString(envelope),
+		"the current version should match its fixture")
+
+	decoded, err := argonize.DecodeEnvelope(envelope)
+	require.NoError(t, err)
+	require.Equal(t, hashedObj, decoded)
+}
+
+func TestEncodeEnvelope_unsupported(t *testing.T) {
+	t.Parallel()
+
+	hashedObj, err := argonize.DecodeHashStr(sampleHashStr)
+	require.NoError(t, err)
+
+	hashedObj.Params.Variant = "unknown"
+
+	envelope, err := argonize.EncodeEnvelope(hashedObj)
+	require.ErrorContains(t, err, "failed to encode the envelope")
+	require.ErrorContains(t, err, `unsupported variant "unknown"`)
+	require.Nil(t, envelope)
+
+	envelope, err = argonize.EncodeEnvelope(nil)
+	require.ErrorContains(t, err, "params are nil")
+	require.Nil(t, envelope)
+}
+
+// ----------------------------------------------------------------------------
+//  DecodeEnvelope()
+// ----------------------------------------------------------------------------
+
+func TestDecodeEnvelope_fixtures(t *testing.T) {
+	t.Parallel()
+
+	for version := uint8(1); version <= argonize.EnvelopeVersion; version++ {
+		fixture, ok := envelopeFixtures[version]
+		require.True(t, ok, "missing fixture of envelope version %d", version)
+
+		envelope, err := hex.DecodeString(fixture)
+		require.NoError(t, err)
+
+		hashedObj, err := argonize.DecodeEnvelope(envelope)
+		require.NoError(t, err, "version %d", version)
+		require.Equal(t, sampleHashStr, hashedObj.String(), "version %d", version)
+	}
+}
+
+func TestDecodeEnvelope_unknown_version(t *testing.T) {
+	t.Parallel()
+
+	envelope, err := hex.DecodeString(envelopeFixtures[1])
+	require.NoError(t, err)
+
+	envelope[4] = 99
+
+	hashedObj, err := argonize.DecodeEnvelope(envelope)
+	require.Nil(t, hashedObj)
+	require.EqualError(t, err, "failed to decode the envelope: unsupported envelope version 99 (supported up to 1)")
+
+	var versionErr *argonize.EnvelopeVersionError
+
+	require.True(t, errors.As(err, &versionErr))
+	require.Equal(t, uint8(99), versionErr.Version)
+}
+
+func TestDecodeEnvelope_invalid(t *testing.T) {
+	t.Parallel()
+
+	valid, err := hex.DecodeString(envelopeFixtures[1])
+	require.NoError(t, err)
+
+	for _, test := range []struct {
+		input      []byte
+		msgContain string
+	}{
+		{input: nil, msgContain: "bad magic number"},
+		{input: []byte("ARGE"), msgContain: "bad magic number"},
+		{input: append([]byte("ARGZ"), valid[4:]...), msgContain: "bad magic number"},
+		{input: valid[:len(valid)-1], msgContain: "failed to decode the envelope"},
+		{input: append(append([]byte{}, valid...), 0), msgContain: "trailing data"},
+	} {
+		hashedObj, err := argonize.DecodeEnvelope(test.input)
+
+		require.ErrorContains(t, err, test.msgContain, "input: %x", test.input)
+		require.Nil(t, hashedObj)
+
+		var versionErr *argonize.EnvelopeVersionError
+
+		require.False(t, errors.As(err, &versionErr))
+	}
+}
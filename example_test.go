@@ -2,10 +2,12 @@ package argonize_test
 
 import (
 	"bytes"
+	"errors"
 	"fmt"
 	"log"
 
 	"github.com/KEINOS/go-argonize"
+	"golang.org/x/crypto/scrypt"
 )
 
 func Example() {
@@ -310,3 +312,131 @@ func ExampleSalt_AddPepper() {
 
 	// Output: OK
 }
+
+// ----------------------------------------------------------------------------
+//  SecretBytes
+// ----------------------------------------------------------------------------
+
+func ExampleSecretBytes() {
+	// Wrap the password as soon as it is received.
+	password := argonize.NewSecretBytes([]byte("my password"))
+
+	// It is redacted if printed or logged by accident.
+	fmt.Println(password)
+	fmt.Printf("%x\n", password)
+
+	hashedObj, err := argonize.Hash(password.Reveal())
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	fmt.Println(hashedObj.IsValidSecret(password))
+
+	// Output:
+	// [REDACTED]
+	// [REDACTED]
+	// true
+}
+
+// ----------------------------------------------------------------------------
+//  DummyHash()
+// ----------------------------------------------------------------------------
+
+func ExampleDummyHash() {
+	users := map[string]*argonize.Hashed{}
+
+	login := func(name string, password []byte) bool {
+		hashedObj, found := users[name]
+		if !found {
+			// Spend the same time as for an existing user to prevent the user
+			// enumeration via response timing.
+			_ = argonize.DummyHash().IsValidPassword(password)
+
+			return false
+		}
+
+		return hashedObj.IsValidPassword(password)
+	}
+
+	fmt.Println(login("nobody", []byte("password")))
+
+	// Output: false
+}
+
+// ----------------------------------------------------------------------------
+//  RegisterKDF()
+// ----------------------------------------------------------------------------
+
+// exampleScrypt is a NamedKDF of scrypt. The memory cost of the params is used
+// as the CPU/memory cost parameter N of scrypt.
+type exampleScrypt struct{}
+
+func (exampleScrypt) Name() string {
+	return "scrypt"
+}
+
+func (exampleScrypt) Derive(password, salt []byte, params *argonize.Params) ([]byte, error) {
+	return scrypt.Key(password, salt, int(params.MemoryCost), 8, int(params.Parallelism), int(params.KeyLength))
+}
+
+func ExampleRegisterKDF() {
+	if err := argonize.RegisterKDF(exampleScrypt{}); err != nil {
+		log.Fatal(err)
+	}
+
+	params := argonize.NewParams()
+	params.Variant = "scrypt"
+	params.MemoryCost = 1 << 10
+	params.Parallelism = 1
+
+	hashedObj, err := argonize.HashWithSalt([]byte("my password"), nil, params)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	// Decode and verify in the same way as Argon2id
+	decoded, err := argonize.DecodeHashStr(hashedObj.String())
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	fmt.Println(decoded.Params.Variant)
+	fmt.Println(decoded.IsValidPassword([]byte("my password")))
+
+	// Output:
+	// scrypt
+	// true
+}
+
+func ExampleCompareHashAndPassword() {
+	// Before, with "golang.org/x/crypto/bcrypt":
+	//
+	//   if err := bcrypt.CompareHashAndPassword(stored, password); err != nil {
+	//       if errors.Is(err, bcrypt.ErrMismatchedHashAndPassword) { ... }
+	//   }
+	//
+	// After, only the package name changes:
+	hashedObj, err := argonize.Hash([]byte("my password"))
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	stored := []byte(hashedObj.String())
+
+	for _, password := range []string{"my password", "wrong password"} {
+		err := argonize.CompareHashAndPassword(stored, []byte(password))
+
+		switch {
+		case err == nil:
+			fmt.Println(password, "-> match")
+		case errors.Is(err, argonize.ErrMismatchedHashAndPassword):
+			fmt.Println(password, "-> mismatch")
+		default:
+			log.Fatal(err) // malformed hash
+		}
+	}
+
+	// Output:
+	// my password -> match
+	// wrong password -> mismatch
+}
@@ -0,0 +1,39 @@
+package argonize
+
+// ============================================================================
+//  Type: ParamField
+// ============================================================================
+
+// ParamField is a named numeric value of Params. See Params.Fields().
+type ParamField struct {
+	Name  string
+	Value uint64
+}
+
+// numParamFields is the number of the fields returned by Params.Fields().
+const numParamFields = 5
+
+// ----------------------------------------------------------------------------
+//  Methods of Params
+// ----------------------------------------------------------------------------
+
+// Fields returns the numeric parameters in a fixed order, for exporters of
+// metrics that need deterministic labels without reflection:
+//
+//	memory, iterations, parallelism, key_length, salt_length
+//
+// The names are the same as the YAML and TOML keys. The memory is in KiB. It
+// allocates only the returned slice. It returns nil if p is nil.
+func (p *Params) Fields() []ParamField {
+	if p == nil {
+		return nil
+	}
+
+	return append(make([]ParamField, 0, numParamFields),
+		ParamField{Name: "memory", Value: uint64(p.MemoryCost)},
+		ParamField{Name: "iterations", Value: uint64(p.Iterations)},
+		ParamField{Name: "parallelism", Value: uint64(p.Parallelism)},
+		ParamField{Name: "key_length", Value: uint64(p.KeyLength)},
+		ParamField{Name: "salt_length", Value: uint64(p.SaltLength)},
+	)
+}
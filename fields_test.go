@@ -0,0 +1,42 @@
+package argonize_test
+
+import (
+	"testing"
+
+	"github.com/KEINOS/go-argonize"
+	"github.com/stretchr/testify/require"
+)
+
+// ----------------------------------------------------------------------------
+//  Params.Fields()
+// ----------------------------------------------------------------------------
+
+func TestParams_Fields(t *testing.T) {
+	t.Parallel()
+
+	params := argonize.NewParams()
+	params.Iterations = 3
+
+	require.Equal(t, []argonize.ParamField{
+		{Name: "memory", Value: 65536},
+		{Name: "iterations", Value: 3},
+		{Name: "parallelism", Value: 2},
+		{Name: "key_length", Value: 32},
+		{Name: "salt_length", Value: 16},
+	}, params.Fields())
+
+	var nilParams *argonize.Params
+
+	require.Nil(t, nilParams.Fields())
+}
+
+//nolint:paralleltest // AllocsPerRun must not run in parallel
+func TestParams_Fields_allocs(t *testing.T) {
+	params := argonize.NewParams()
+
+	allocs := testing.AllocsPerRun(100, func() {
+		_ = params.Fields()
+	})
+
+	require.LessOrEqual(t, allocs, 1.0, "it should allocate only the returned slice")
+}
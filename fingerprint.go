@@ -0,0 +1,54 @@
+package argonize
+
+import (
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"log/slog"
+)
+
+// lenFingerprint is the number of bytes of the SHA-256 digest in the
+// fingerprint.
+const lenFingerprint = 8
+
+// ----------------------------------------------------------------------------
+//  Methods of Hashed
+// ----------------------------------------------------------------------------
+
+// Fingerprint returns a short and stable identifier of the hash for the
+// deduplication, cache keys and correlating log lines. E.g. "9752cb5578b61c4b".
+//
+// It is the hex encoded first 8 bytes of the SHA-256 digest of String(). The
+// digest is not reversible, so the fingerprint can not be used to attack the
+// hash. It is stable across the processes and the releases as long as String()
+// is. It does not panic on partially populated objects: nil Params are encoded
+// as the zero value Params, and a nil h returns the empty string.
+//
+// Since String() is the canonical form, the same hash decoded from its
+// different encodings, such as the padded or URL-safe base64 and the reordered
+// parameters accepted by DecodeHashStrLenient(), has the same fingerprint. It
+// helps to detect the duplicate imports of the same hash.
+//
+// Note that the fingerprint is not a verifier. It identifies the hash and not
+// the password, so the same password with another salt has a different
+// fingerprint. Use IsValidPassword() to verify passwords.
+func (h *Hashed) Fingerprint() string {
+	if h == nil {
+		return ""
+	}
+
+	hashed := *h
+	if hashed.Params == nil {
+		hashed.Params = new(Params)
+	}
+
+	digest := sha256.Sum256(hashed.appendString(nil, base64.RawStdEncoding))
+
+	return hex.EncodeToString(digest[:lenFingerprint])
+}
+
+// LogValue implements slog.LogValuer. It logs the fingerprint instead of the
+// encoded hash, so that the hashes are not leaked to the logs.
+func (h *Hashed) LogValue() slog.Value {
+	return slog.GroupValue(slog.String("fingerprint", h.Fingerprint()))
+}
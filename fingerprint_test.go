@@ -0,0 +1,90 @@
+package argonize_test
+
+import (
+	"bytes"
+	"log/slog"
+	"testing"
+
+	"github.com/KEINOS/go-argonize"
+	"github.com/stretchr/testify/require"
+)
+
+// ----------------------------------------------------------------------------
+//  Hashed.Fingerprint()
+// ----------------------------------------------------------------------------
+
+func TestHashed_Fingerprint_golden(t *testing.T) {
+	t.Parallel()
+
+	hashedObj, err := argonize.DecodeHashStr(sampleHashStr)
+	require.NoError(t, err)
+
+	// Golden value. It must not change across releases.
+	// echo -n "<sampleHashStr>" | sha256sum | cut -c1-16
+	require.Equal(t, "9752cb5578b61c4b", hashedObj.Fingerprint())
+}
+
+func TestHashed_Fingerprint(t *testing.T) {
+	t.Parallel()
+
+	hashed1 := argonize.HashCustom([]byte("password"), []byte("0123456789abcdef"), lowCostParams())
+	hashed2 := argonize.HashCustom([]byte("password"), []byte("fedcba9876543210"), lowCostParams())
+
+	require.Len(t, hashed1.Fingerprint(), 16)
+	require.Equal(t, hashed1.Fingerprint(), hashed1.Fingerprint(), "it should be stable")
+	require.NotEqual(t, hashed1.Fingerprint(), hashed2.Fingerprint(), "different salts should differ")
+	require.NotContains(t, hashed1.String(), hashed1.Fingerprint())
+}
+
+func TestHashed_Fingerprint_canonical(t *testing.T) {
+	t.Parallel()
+
+	for _, input := range []string{
+		"$argon2id$v=19$m=65536,t=3,p=2$Woo1mErn1s7AHf96ewQ8Uw==$D4TzIwGO4XD2buk96qAP+Ed2baMo/KbTRMqXX00wtsU=",
+		"$argon2id$v=19$m=65536,t=3,p=2$Woo1mErn1s7AHf96ewQ8Uw$D4TzIwGO4XD2buk96qAP-Ed2baMo_KbTRMqXX00wtsU",
+		"$argon2id$v=19$p=2,t=3,m=65536$Woo1mErn1s7AHf96ewQ8Uw$D4TzIwGO4XD2buk96qAP+Ed2baMo/KbTRMqXX00wtsU",
+	} {
+		hashedObj, err := argonize.DecodeHashStrLenient(input)
+		require.NoError(t, err, "input: %q", input)
+		require.Equal(t, "9752cb5578b61c4b", hashedObj.Fingerprint(), "input: %q", input)
+	}
+}
+
+func TestHashed_Fingerprint_partial(t *testing.T) {
+	t.Parallel()
+
+	var nilHashed *argonize.Hashed
+
+	require.Empty(t, nilHashed.Fingerprint())
+
+	for _, hashedObj := range []*argonize.Hashed{
+		{},
+		{Salt: []byte("0123456789abcdef")},
+		{Hash: []byte("hash")},
+		{Params: argonize.NewParams()},
+	} {
+		require.NotPanics(t, func() {
+			require.Len(t, hashedObj.Fingerprint(), 16)
+		})
+	}
+}
+
+// ----------------------------------------------------------------------------
+//  Hashed.LogValue()
+// ----------------------------------------------------------------------------
+
+func TestHashed_LogValue(t *testing.T) {
+	t.Parallel()
+
+	hashedObj, err := argonize.DecodeHashStr(sampleHashStr)
+	require.NoError(t, err)
+
+	var buf bytes.Buffer
+
+	logger := slog.New(slog.NewTextHandler(&buf, nil))
+	logger.Info("login", "hash", hashedObj)
+
+	require.Contains(t, buf.String(), "hash.fingerprint=9752cb5578b61c4b")
+	require.NotContains(t, buf.String(), sampleSaltB64)
+	require.NotContains(t, buf.String(), sampleHashB64)
+}
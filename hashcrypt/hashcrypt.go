@@ -0,0 +1,149 @@
+/*
+Package hashcrypt encrypts argonize.Hashed at rest with AES-256-GCM.
+
+A password hash is already one-way, but encrypting it with a key kept outside
+of the database, such as in a KMS, makes a leaked database useless for the
+offline attacks without the key as well. It is a separate package to keep the
+core argonize package minimal.
+
+# Layout
+
+	| version (1) | nonce (12) | ciphertext and GCM tag (n + 16) |
+
+The plaintext is the envelope of argonize.EncodeEnvelope(), which carries the
+variant, KeyID and Data of the hash as well. The nonce is random for each encryption. The version byte is bound to the
+ciphertext as the additional authenticated data, so it can not be altered
+without failing the authentication.
+*/
+package hashcrypt
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+
+	"github.com/KEINOS/go-argonize"
+	"github.com/pkg/errors"
+)
+
+const (
+	// Version is the format version written by EncryptHash().
+	Version = uint8(1)
+	// KeyLength is the required key length in bytes (AES-256).
+	KeyLength = 32
+)
+
+const (
+	// lenNonce is the nonce length of AES-GCM.
+	lenNonce = 12
+	// lenTag is the authentication tag length of AES-GCM.
+	lenTag = 16
+	// additionalDataPrefix is the prefix of the additional authenticated data,
+	// followed by the version byte.
+	additionalDataPrefix = "argonize/hashcrypt"
+)
+
+// ErrAuthentication is the error of DecryptHash() if the ciphertext was not
+// encrypted with the key or was tampered with. Use errors.Is() to check it.
+var ErrAuthentication = errors.New("message authentication failed")
+
+// RandRead is a copy of `crypto.rand.Read` to read the nonce. It is a variable
+// to ease testing.
+//
+//nolint:gochecknoglobals // allow global variable for testing
+var RandRead = rand.Read
+
+// ============================================================================
+//  Functions
+// ============================================================================
+
+// EncryptHash encrypts the hash with the 32-byte key and returns the
+// ciphertext in the current Version. Use DecryptHash() with the same key to
+// decrypt it.
+//
+// It returns an error if the key length is not KeyLength, or if the hash can
+// not be encoded with argonize.EncodeEnvelope().
+func EncryptHash(h *argonize.Hashed, key []byte) ([]byte, error) {
+	aead, err := newAEAD(key)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to encrypt the hash")
+	}
+
+	plaintext, err := argonize.EncodeEnvelope(h)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to encrypt the hash")
+	}
+
+	out := make([]byte, 1+lenNonce, 1+lenNonce+len(plaintext)+lenTag)
+	out[0] = Version
+
+	if _, err := RandRead(out[1:]); err != nil {
+		return nil, errors.Wrap(err, "failed to encrypt the hash: failed to read the nonce")
+	}
+
+	return aead.Seal(out, out[1:], plaintext, additionalData(Version)), nil
+}
+
+// DecryptHash decrypts the ciphertext of EncryptHash() with the key.
+//
+// It returns an error wrapping ErrAuthentication if the key is wrong or the
+// ciphertext was modified, and an error if the key length is not KeyLength,
+// the ciphertext is too short or its version is unknown.
+func DecryptHash(ciphertext, key []byte) (*argonize.Hashed, error) {
+	aead, err := newAEAD(key)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to decrypt the hash")
+	}
+
+	if len(ciphertext) < 1+lenNonce+lenTag {
+		return nil, errors.New("failed to decrypt the hash: ciphertext is too short")
+	}
+
+	version := ciphertext[0]
+	if version != Version {
+		return nil, errors.Errorf("failed to decrypt the hash: unsupported version %d (supported up to %d)",
+			version, Version)
+	}
+
+	nonce, sealed := ciphertext[1:1+lenNonce], ciphertext[1+lenNonce:]
+
+	plaintext, err := aead.Open(nil, nonce, sealed, additionalData(version))
+	if err != nil {
+		return nil, errors.Wrap(ErrAuthentication, "failed to decrypt the hash")
+	}
+
+	hashed, err := argonize.DecodeEnvelope(plaintext)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to decrypt the hash")
+	}
+
+	return hashed, nil
+}
+
+// ----------------------------------------------------------------------------
+//  Private Functions
+// ----------------------------------------------------------------------------
+
+// additionalData returns the additional authenticated data of the version.
+func additionalData(version uint8) []byte {
+	return append([]byte(additionalDataPrefix), version)
+}
+
+// newAEAD returns the AES-256-GCM cipher of the key.
+func newAEAD(key []byte) (cipher.AEAD, error) {
+	if len(key) != KeyLength {
+		return nil, errors.Errorf("invalid key length %d, must be %d bytes", len(key), KeyLength)
+	}
+
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to create the cipher")
+	}
+
+	aead, err := cipher.NewGCM(block)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to create the GCM")
+	}
+
+	return aead, nil
+}
@@ -0,0 +1,147 @@
+package hashcrypt_test
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/KEINOS/go-argonize"
+	"github.com/KEINOS/go-argonize/hashcrypt"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/require"
+)
+
+const sampleHashStr = "$argon2id$v=19$m=65536,t=3,p=2$Woo1mErn1s7AHf96ewQ8Uw$D4TzIwGO4XD2buk96qAP+Ed2baMo/KbTRMqXX00wtsU"
+
+// sampleHashed returns the decoded sampleHashStr.
+func sampleHashed(t *testing.T) *argonize.Hashed {
+	t.Helper()
+
+	hashed, err := argonize.DecodeHashStr(sampleHashStr)
+	require.NoError(t, err)
+
+	return hashed
+}
+
+// ----------------------------------------------------------------------------
+//  EncryptHash() and DecryptHash()
+// ----------------------------------------------------------------------------
+
+func TestEncryptHash_round_trip(t *testing.T) {
+	t.Parallel()
+
+	key := bytes.Repeat([]byte{0x01}, hashcrypt.KeyLength)
+
+	ciphertext, err := hashcrypt.EncryptHash(sampleHashed(t), key)
+	require.NoError(t, err)
+	require.Equal(t, hashcrypt.Version, ciphertext[0], "it should start with the version")
+	require.NotContains(t, string(ciphertext), "argon2id")
+
+	decrypted, err := hashcrypt.DecryptHash(ciphertext, key)
+	require.NoError(t, err)
+	require.Equal(t, sampleHashStr, decrypted.String())
+
+	// The nonce should be random
+	again, err := hashcrypt.EncryptHash(sampleHashed(t), key)
+	require.NoError(t, err)
+	require.NotEqual(t, ciphertext, again)
+}
+
+func TestDecryptHash_authentication_failure(t *testing.T) {
+	t.Parallel()
+
+	key := bytes.Repeat([]byte{0x01}, hashcrypt.KeyLength)
+	wrongKey := bytes.Repeat([]byte{0x02}, hashcrypt.KeyLength)
+
+	ciphertext, err := hashcrypt.EncryptHash(sampleHashed(t), key)
+	require.NoError(t, err)
+
+	decrypted, err := hashcrypt.DecryptHash(ciphertext, wrongKey)
+	require.ErrorIs(t, err, hashcrypt.ErrAuthentication, "wrong key should fail")
+	require.Nil(t, decrypted)
+
+	// Flip each byte after the version, i.e. the nonce, the ciphertext and the tag
+	for index := 1; index < len(ciphertext); index++ {
+		tampered := bytes.Clone(ciphertext)
+		tampered[index] ^= 0x01
+
+		_, err := hashcrypt.DecryptHash(tampered, key)
+		require.ErrorIs(t, err, hashcrypt.ErrAuthentication, "tampered byte at index %d should fail", index)
+	}
+
+	_, err = hashcrypt.DecryptHash(ciphertext[:len(ciphertext)-1], key)
+	require.ErrorIs(t, err, hashcrypt.ErrAuthentication, "truncated ciphertext should fail")
+}
+
+func TestDecryptHash_unsupported_version(t *testing.T) {
+	t.Parallel()
+
+	key := bytes.Repeat([]byte{0x01}, hashcrypt.KeyLength)
+
+	ciphertext, err := hashcrypt.EncryptHash(sampleHashed(t), key)
+	require.NoError(t, err)
+
+	ciphertext[0] = hashcrypt.Version + 1
+
+	_, err = hashcrypt.DecryptHash(ciphertext, key)
+	require.ErrorContains(t, err, "unsupported version 2 (supported up to 1)")
+}
+
+func TestDecryptHash_too_short(t *testing.T) {
+	t.Parallel()
+
+	key := bytes.Repeat([]byte{0x01}, hashcrypt.KeyLength)
+
+	_, err := hashcrypt.DecryptHash([]byte{hashcrypt.Version, 0x00}, key)
+	require.ErrorContains(t, err, "ciphertext is too short")
+}
+
+func TestEncryptHash_invalid_key_length(t *testing.T) {
+	t.Parallel()
+
+	for _, length := range []int{0, 16, 24, 31, 33} {
+		key := make([]byte, length)
+
+		_, err := hashcrypt.EncryptHash(sampleHashed(t), key)
+		require.ErrorContains(t, err, "invalid key length", "key length: %d", length)
+
+		_, err = hashcrypt.DecryptHash(make([]byte, 64), key)
+		require.ErrorContains(t, err, "invalid key length", "key length: %d", length)
+	}
+}
+
+func TestEncryptHash_all_fields(t *testing.T) {
+	t.Parallel()
+
+	key := bytes.Repeat([]byte{0x01}, hashcrypt.KeyLength)
+
+	hashed := sampleHashed(t)
+	hashed.Params.Variant = argonize.VariantArgon2i
+	hashed.KeyID = "v1"
+	hashed.Data = "profile"
+
+	ciphertext, err := hashcrypt.EncryptHash(hashed, key)
+	require.NoError(t, err)
+
+	decrypted, err := hashcrypt.DecryptHash(ciphertext, key)
+	require.NoError(t, err)
+	require.Equal(t, hashed, decrypted, "it should keep the variant, key ID and data")
+
+	_, err = hashcrypt.EncryptHash(nil, key)
+	require.Error(t, err)
+}
+
+//nolint:paralleltest // disable parallel since it replaces RandRead
+func TestEncryptHash_rand_failure(t *testing.T) {
+	oldRandRead := hashcrypt.RandRead
+	defer func() {
+		hashcrypt.RandRead = oldRandRead
+	}()
+
+	hashcrypt.RandRead = func([]byte) (int, error) {
+		return 0, errors.New("forced failure")
+	}
+
+	_, err := hashcrypt.EncryptHash(sampleHashed(t), bytes.Repeat([]byte{0x01}, hashcrypt.KeyLength))
+	require.ErrorContains(t, err, "failed to read the nonce")
+	require.ErrorContains(t, err, "forced failure")
+}
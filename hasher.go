@@ -0,0 +1,268 @@
+package argonize
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// ============================================================================
+//  Type: Hasher
+// ============================================================================
+
+// Hasher hashes and verifies passwords with the preconfigured parameters and
+// options.
+//
+// The WithXxx methods return a modified copy, so a Hasher can be configured in
+// a chain and shared across goroutines once configured.
+//
+//	hasher := argonize.NewHasher(params).WithPepperProvider(provider)
+type Hasher struct {
+	params           *Params
+	pepper           PepperProvider
+	randomness       Randomness
+	kdf              KDF
+	profile          string
+	constantDuration time.Duration
+	pepperMode       PepperMode
+}
+
+// ----------------------------------------------------------------------------
+//  Constructor of Hasher
+// ----------------------------------------------------------------------------
+
+// NewHasher returns a new Hasher with a copy of the parameters. If params is
+// nil, the default parameters are used.
+func NewHasher(params *Params) *Hasher {
+	if params == nil {
+		params = NewParams()
+	}
+
+	paramsCopy := *params
+
+	return &Hasher{params: &paramsCopy}
+}
+
+// ----------------------------------------------------------------------------
+//  Methods of Hasher
+// ----------------------------------------------------------------------------
+
+// WithPepperProvider returns a copy of the Hasher that peppers the passwords
+// with the pepper of the provider.
+//
+// On hashing, the current pepper is mixed into the salt and its ID is recorded
+// in Hashed.KeyID, which is encoded as the "keyid" parameter of the PHC string.
+// The stored salt is the bare one. On verification, the pepper is resolved by
+// the Hashed.KeyID through the provider.
+func (h *Hasher) WithPepperProvider(provider PepperProvider) *Hasher {
+	hasher := *h
+	hasher.pepper = provider
+
+	return &hasher
+}
+
+// WithPepperMode returns a copy of the Hasher that mixes the pepper into the
+// salt in the given mode. The default is AppendPepper.
+func (h *Hasher) WithPepperMode(mode PepperMode) *Hasher {
+	hasher := *h
+	hasher.pepperMode = mode
+
+	return &hasher
+}
+
+// WithRandomness returns a copy of the Hasher that reads the salts from the
+// given Randomness instead of the package-wide one. It allows tests and
+// libraries to inject determinism without affecting the other users of the
+// package. If r is nil, the package-wide Randomness is used.
+func (h *Hasher) WithRandomness(r Randomness) *Hasher {
+	hasher := *h
+	hasher.randomness = r
+
+	return &hasher
+}
+
+// WithKDF returns a copy of the Hasher that hashes and verifies through the
+// given KDF instead of the package-wide one. Both Hash() and Verify() use it, so
+// the hashes of the Hasher are always verified with the same backend. If k is
+// nil, the package-wide KDF is used.
+func (h *Hasher) WithKDF(k KDF) *Hasher {
+	hasher := *h
+	hasher.kdf = k
+
+	return &hasher
+}
+
+// WithConstantDuration returns a copy of the Hasher whose Verify() and
+// VerifyContext() take at least the target duration, regardless of the path
+// taken. The early-exit paths, such as a hash without parameters or an
+// unavailable pepper, would otherwise return in microseconds and tell a remote
+// attacker why the authentication failed.
+//
+// Choose a target longer than a regular verification on a loaded machine. If
+// the verification takes longer than the target, it returns without waiting.
+// Zero or a negative target disables it, which is the default.
+func (h *Hasher) WithConstantDuration(target time.Duration) *Hasher {
+	hasher := *h
+	hasher.constantDuration = target
+
+	return &hasher
+}
+
+// WithProfileName returns a copy of the Hasher that records the name of the
+// parameter profile, such as "interactive-2024", in Hashed.Data of the hashes.
+// It is encoded as the "data" parameter of the PHC string and used by
+// Hashed.NeedsRehashProfile() to detect the hashes of an outdated profile.
+//
+// The name must be 1 to ProfileNameMax characters of ASCII letters, digits,
+// ".", "_" and "-". An invalid name makes Hash() return an error. An empty name
+// records no profile, which is the default.
+func (h *Hasher) WithProfileName(name string) *Hasher {
+	hasher := *h
+	hasher.profile = name
+
+	return &hasher
+}
+
+// Hash returns a Hashed object of the password with a new random salt. Like the
+// Hash() function, it returns an error if the password is empty unless
+// AllowEmptyPassword is set.
+//
+// If the Hasher has a PepperProvider, it returns an error wrapping
+// ErrPepperUnavailable if the current pepper could not be obtained.
+func (h *Hasher) Hash(password []byte) (*Hashed, error) {
+	params := *h.params
+
+	if err := params.Validate(); err != nil {
+		return nil, err
+	}
+
+	if h.profile != "" {
+		if err := validateProfileName(h.profile); err != nil {
+			return nil, errors.Wrap(err, "failed to hash the password")
+		}
+	}
+
+	if err := checkEmptyPassword(password); err != nil {
+		return nil, errors.Wrap(err, "failed to hash the password")
+	}
+
+	randomness := h.randomness
+	if randomness == nil {
+		randomness = currentRandomness()
+	}
+
+	salt, err := newSaltFrom(randomness, params.SaltLength)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to hash the password")
+	}
+
+	if h.pepper == nil {
+		hashed, err := hashWithSalt(h.currentKDF(), password, salt, &params)
+		if err != nil {
+			return nil, err
+		}
+
+		hashed.Data = h.profile
+
+		return hashed, nil
+	}
+
+	keyID, secret, err := h.pepper.CurrentPepper()
+	if err == nil && (keyID == "" || len(secret) == 0) {
+		err = errors.New("empty id or secret")
+	}
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to hash the password: %w: %w", ErrPepperUnavailable, err)
+	}
+
+	peppered := append(Salt(nil), salt...)
+	peppered.AddPepperMode(secret, h.pepperMode)
+
+	hashed, err := hashWithSalt(h.currentKDF(), password, peppered, &params)
+	if err != nil {
+		return nil, err
+	}
+
+	hashed.Salt = salt
+	hashed.KeyID = keyID
+	hashed.Data = h.profile
+
+	return hashed, nil
+}
+
+// Verify returns true if the password matches the hashed one.
+//
+// If the hash has a KeyID, the pepper is resolved through the PepperProvider.
+// It returns an error wrapping ErrPepperUnavailable if the Hasher has no
+// provider or if the provider failed, so that it is not mistaken for a wrong
+// password. Hashes without a KeyID are verified without pepper.
+func (h *Hasher) Verify(hashed *Hashed, password []byte) (bool, error) {
+	return h.VerifyContext(context.Background(), hashed, password)
+}
+
+// VerifyContext is similar to Verify() but stops waiting for the constant
+// duration set by WithConstantDuration() if the context is done. In that case,
+// it returns false and an error wrapping the error of the context. Note that
+// the key derivation itself is not interrupted.
+func (h *Hasher) VerifyContext(ctx context.Context, hashed *Hashed, password []byte) (bool, error) {
+	start := time.Now()
+
+	isValid, err := h.verify(hashed, password)
+
+	if h.constantDuration > 0 {
+		timer := time.NewTimer(time.Until(start.Add(h.constantDuration)))
+		defer timer.Stop()
+
+		select {
+		case <-timer.C:
+		case <-ctx.Done():
+			return false, fmt.Errorf("failed to verify password: %w", ctx.Err())
+		}
+	}
+
+	return isValid, err
+}
+
+// ----------------------------------------------------------------------------
+//  Methods of Hasher (Private)
+// ----------------------------------------------------------------------------
+
+// verify is the implementation of Verify() without the constant duration.
+func (h *Hasher) verify(hashed *Hashed, password []byte) (bool, error) {
+	if hashed == nil || hashed.Params == nil {
+		return false, errors.New("failed to verify password: the hash has no parameters")
+	}
+
+	if hashed.IsZero() {
+		return false, errors.New("failed to verify password: the hash is uninitialized")
+	}
+
+	if hashed.KeyID == "" {
+		return hashed.isValidPasswordWith(h.currentKDF(), password, hashed.Salt), nil
+	}
+
+	if h.pepper == nil {
+		return false, fmt.Errorf("failed to verify password: %w: no pepper provider for key ID %q",
+			ErrPepperUnavailable, hashed.KeyID)
+	}
+
+	secret, err := h.pepper.PepperByID(hashed.KeyID)
+	if err != nil {
+		return false, fmt.Errorf("failed to verify password: %w: key ID %q: %w",
+			ErrPepperUnavailable, hashed.KeyID, err)
+	}
+
+	return hashed.isValidPasswordPepperedWith(h.currentKDF(), password, secret, h.pepperMode), nil
+}
+
+// currentKDF returns the KDF of the Hasher or the package-wide one if not set.
+func (h *Hasher) currentKDF() KDF {
+	if h.kdf != nil {
+		return h.kdf
+	}
+
+	return currentKDF()
+}
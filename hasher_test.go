@@ -0,0 +1,288 @@
+package argonize_test
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/KEINOS/go-argonize"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/require"
+)
+
+// lowCostParams returns the parameters cheap enough for the tests.
+func lowCostParams() *argonize.Params {
+	params := argonize.NewParams()
+	params.MemoryCost = 64
+	params.Parallelism = 1
+
+	return params
+}
+
+// failingPepperProvider is a PepperProvider that always fails.
+type failingPepperProvider struct{}
+
+func (failingPepperProvider) CurrentPepper() (string, []byte, error) {
+	return "", nil, errors.New("secrets manager is down")
+}
+
+func (failingPepperProvider) PepperByID(string) ([]byte, error) {
+	return nil, errors.New("secrets manager is down")
+}
+
+// ----------------------------------------------------------------------------
+//  NewHasher()
+// ----------------------------------------------------------------------------
+
+func TestNewHasher(t *testing.T) {
+	t.Parallel()
+
+	params := lowCostParams()
+	hasher := argonize.NewHasher(params)
+
+	params.Iterations = 0 // should not affect the hasher
+
+	hashed, err := hasher.Hash([]byte("password"))
+	require.NoError(t, err)
+	require.Equal(t, uint32(1), hashed.Params.Iterations)
+	require.Empty(t, hashed.KeyID)
+
+	ok, err := hasher.Verify(hashed, []byte("password"))
+	require.NoError(t, err)
+	require.True(t, ok)
+
+	ok, err = hasher.Verify(hashed, []byte("wrong"))
+	require.NoError(t, err)
+	require.False(t, ok)
+
+	// Nil params should be the default ones
+	require.NotNil(t, argonize.NewHasher(nil))
+}
+
+// ----------------------------------------------------------------------------
+//  Hasher.Hash()
+// ----------------------------------------------------------------------------
+
+func TestHasher_Hash_empty_password(t *testing.T) {
+	t.Parallel()
+
+	hasher := argonize.NewHasher(lowCostParams())
+
+	for _, password := range [][]byte{nil, {}} {
+		hashed, err := hasher.Hash(password)
+
+		require.ErrorContains(t, err, "failed to hash the password: the password is empty")
+		require.Nil(t, hashed, "it should be nil on error")
+	}
+}
+
+// ----------------------------------------------------------------------------
+//  Hasher.WithPepperProvider()
+// ----------------------------------------------------------------------------
+
+func TestHasher_WithPepperProvider(t *testing.T) {
+	t.Parallel()
+
+	provider, err := argonize.NewMemoryPepperProvider("v1", []byte("pepper1"))
+	require.NoError(t, err)
+
+	plain := argonize.NewHasher(lowCostParams())
+	hasher := plain.WithPepperProvider(provider)
+
+	hashed, err := hasher.Hash([]byte("password"))
+	require.NoError(t, err)
+	require.Equal(t, "v1", hashed.KeyID)
+	require.False(t, hashed.IsValidPassword([]byte("password")), "it should require the pepper")
+
+	// The key ID should survive the PHC string
+	encoded := hashed.String()
+
+	require.Contains(t, encoded, ",keyid=djE$", "keyid should be the base64 of the ID")
+	require.NotContains(t, encoded, "pepper1")
+
+	decoded, err := argonize.DecodeHashStr(encoded)
+	require.NoError(t, err)
+	require.Equal(t, "v1", decoded.KeyID)
+
+	// Rotate the pepper. The old hash should still be verifiable.
+	require.NoError(t, provider.Add("v2", []byte("pepper2")))
+	require.NoError(t, provider.SetCurrent("v2"))
+
+	ok, err := hasher.Verify(decoded, []byte("password"))
+	require.NoError(t, err)
+	require.True(t, ok)
+
+	ok, err = hasher.Verify(decoded, []byte("wrong"))
+	require.NoError(t, err)
+	require.False(t, ok, "wrong password should not be an error")
+
+	rehashed, err := hasher.Hash([]byte("password"))
+	require.NoError(t, err)
+	require.Equal(t, "v2", rehashed.KeyID)
+
+	// The original hasher should not be modified
+	_, err = plain.Verify(decoded, []byte("password"))
+	require.ErrorIs(t, err, argonize.ErrPepperUnavailable)
+}
+
+func TestHasher_WithPepperProvider_errors(t *testing.T) {
+	t.Parallel()
+
+	hasher := argonize.NewHasher(lowCostParams()).WithPepperProvider(failingPepperProvider{})
+
+	hashed, err := hasher.Hash([]byte("password"))
+	require.ErrorIs(t, err, argonize.ErrPepperUnavailable)
+	require.ErrorContains(t, err, "secrets manager is down")
+	require.Nil(t, hashed)
+
+	stored := &argonize.Hashed{
+		Params: lowCostParams(),
+		Salt:   make(argonize.Salt, 16),
+		Hash:   make([]byte, 32),
+		KeyID:  "v1",
+	}
+
+	ok, err := hasher.Verify(stored, []byte("password"))
+	require.ErrorIs(t, err, argonize.ErrPepperUnavailable)
+	require.ErrorContains(t, err, `key ID "v1"`)
+	require.False(t, ok)
+
+	_, err = hasher.Verify(nil, []byte("password"))
+	require.ErrorContains(t, err, "the hash has no parameters")
+}
+
+func TestHasher_WithPepperMode(t *testing.T) {
+	t.Parallel()
+
+	provider, err := argonize.NewMemoryPepperProvider("v1", []byte("pepper"))
+	require.NoError(t, err)
+
+	hasher := argonize.NewHasher(lowCostParams()).
+		WithPepperProvider(provider).
+		WithPepperMode(argonize.PrependPepper)
+
+	hashed, err := hasher.Hash([]byte("password"))
+	require.NoError(t, err)
+
+	require.True(t, hashed.IsValidPasswordPeppered([]byte("password"), []byte("pepper"), argonize.PrependPepper))
+	require.False(t, hashed.IsValidPasswordPeppered([]byte("password"), []byte("pepper"), argonize.AppendPepper))
+
+	ok, err := hasher.Verify(hashed, []byte("password"))
+	require.NoError(t, err)
+	require.True(t, ok)
+}
+
+// ----------------------------------------------------------------------------
+//  DecodeHashStr() with keyid
+// ----------------------------------------------------------------------------
+
+func TestDecodeHashStr_keyid(t *testing.T) {
+	t.Parallel()
+
+	withKeyID := strings.Replace(sampleHashStr, "p=2$", "p=2,keyid=djE$", 1)
+
+	decoded, err := argonize.DecodeHashStrStrict(withKeyID)
+	require.NoError(t, err)
+	require.Equal(t, "v1", decoded.KeyID)
+	require.Equal(t, withKeyID, decoded.String())
+
+	for _, bad := range []string{
+		strings.Replace(sampleHashStr, "p=2$", "p=2,keyid=%%$", 1),
+		strings.Replace(sampleHashStr, "p=2$", "p=2,keyid=$", 1),
+		strings.Replace(sampleHashStr, "p=2$", "p=2,keyid=djE,t=3$", 1),
+	} {
+		_, err := argonize.DecodeHashStr(bad)
+		requireParseError(t, err, argonize.ErrMissingParams, argonize.SegmentParams)
+	}
+
+	out, err := argonize.EncodeBinary(decoded)
+	require.NoError(t, err)
+
+	decoded, err = argonize.DecodeBinary(out)
+	require.NoError(t, err)
+	require.Equal(t, "v1", decoded.KeyID, "binary encoding should keep the key ID")
+}
+
+func TestDecodeHashStr_keyid_ignored_without_pepper(t *testing.T) {
+	t.Parallel()
+
+	// A hash of an encoder emitting the keyid for its own use, not as a pepper
+	hashedObj, err := argonize.HashCustomChecked([]byte("password"), nil, lowCostParams())
+	require.NoError(t, err)
+
+	hashedObj.KeyID = "external-key"
+
+	decoded, err := argonize.DecodeHashStr(hashedObj.String())
+	require.NoError(t, err)
+	require.Equal(t, "external-key", decoded.KeyID, "keyid should be preserved")
+	require.Equal(t, hashedObj.String(), decoded.String())
+
+	require.True(t, decoded.IsValidPassword([]byte("password")), "keyid should be ignored")
+	require.False(t, decoded.IsValidPassword([]byte("wrong password")))
+}
+
+// ----------------------------------------------------------------------------
+//  Hasher.WithConstantDuration()
+// ----------------------------------------------------------------------------
+
+func TestHasher_WithConstantDuration(t *testing.T) {
+	t.Parallel()
+
+	const target = 100 * time.Millisecond
+
+	password := []byte("password")
+	hasher := argonize.NewHasher(lowCostParams()).WithConstantDuration(target)
+
+	hashed, err := hasher.Hash(password)
+	require.NoError(t, err)
+
+	for name, verify := range map[string]func() (bool, error){
+		"match":    func() (bool, error) { return hasher.Verify(hashed, password) },
+		"mismatch": func() (bool, error) { return hasher.Verify(hashed, []byte("wrong")) },
+		"nil hash": func() (bool, error) { return hasher.Verify(nil, password) },
+		"no pepper provider": func() (bool, error) {
+			return hasher.Verify(&argonize.Hashed{Params: hashed.Params, Salt: hashed.Salt, Hash: hashed.Hash, KeyID: "k1"}, password)
+		},
+	} {
+		start := time.Now()
+		isValid, err := verify()
+		elapsed := time.Since(start)
+
+		require.GreaterOrEqual(t, elapsed, target, "%s: it should take at least the target", name)
+		require.Less(t, elapsed, target+time.Second, "%s: it should not wait much longer than the target", name)
+		require.Equal(t, name == "match", isValid, name)
+		require.Equal(t, name == "match" || name == "mismatch", err == nil, name)
+	}
+}
+
+func TestHasher_WithConstantDuration_disabled(t *testing.T) {
+	t.Parallel()
+
+	hasher := argonize.NewHasher(lowCostParams()).WithConstantDuration(time.Hour).WithConstantDuration(0)
+
+	start := time.Now()
+	_, err := hasher.Verify(nil, []byte("password"))
+
+	require.Error(t, err)
+	require.Less(t, time.Since(start), time.Minute, "zero should disable the constant duration")
+}
+
+func TestHasher_VerifyContext_canceled(t *testing.T) {
+	t.Parallel()
+
+	hasher := argonize.NewHasher(lowCostParams()).WithConstantDuration(time.Hour)
+
+	hashed, err := hasher.WithConstantDuration(0).Hash([]byte("password"))
+	require.NoError(t, err)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	start := time.Now()
+	isValid, err := hasher.VerifyContext(ctx, hashed, []byte("password"))
+
+	require.ErrorIs(t, err, context.DeadlineExceeded)
+	require.False(t, isValid, "it should not report a match if canceled")
+	require.Less(t, time.Since(start), 10*time.Second, "it should stop waiting on cancellation")
+}
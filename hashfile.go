@@ -0,0 +1,161 @@
+package argonize
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+// ============================================================================
+//  Type: HashFileOptions
+// ============================================================================
+
+// HashFileOptions configures ParseHashFileWith() and ScanHashFile().
+type HashFileOptions struct {
+	// Separator separates the user name and the hash string of the lines in
+	// the "username:hash" form, such as ":". If empty, the lines are the hash
+	// strings only.
+	Separator string
+}
+
+// ============================================================================
+//  Type: HashRecord
+// ============================================================================
+
+// HashRecord is a record of the hash file read by ScanHashFile().
+type HashRecord struct {
+	// Hashed is the decoded hash. It is nil if Err is not nil.
+	Hashed *Hashed
+	// Err is the error of the record, such as the *ParseError of
+	// DecodeHashStr() or a missing separator.
+	Err error
+	// User is the user name if HashFileOptions.Separator is set.
+	User string
+	// Line is the 1-based line number of the record.
+	Line int
+}
+
+// ============================================================================
+//  Type: ParseIssue
+// ============================================================================
+
+// ParseIssue is a bad record of the hash file reported by ParseHashFile().
+type ParseIssue struct {
+	// Err is the error of the record, such as the *ParseError of
+	// DecodeHashStr() or a missing separator.
+	Err error
+	// User is the user name if HashFileOptions.Separator is set.
+	User string
+	// Line is the 1-based line number of the record.
+	Line int
+}
+
+// Error implements the error interface. E.g. "line 3: invalid hash format".
+func (i ParseIssue) Error() string {
+	return fmt.Sprintf("line %d: %v", i.Line, i.Err)
+}
+
+// Unwrap returns the error of the record.
+func (i ParseIssue) Unwrap() error {
+	return i.Err
+}
+
+// ============================================================================
+//  Functions
+// ============================================================================
+
+// ParseHashFile reads the newline separated hash strings from r, such as a
+// dump for migrations and audits. It is a shorthand of ParseHashFileWith() with
+// the zero HashFileOptions.
+func ParseHashFile(r io.Reader) ([]*Hashed, []ParseIssue, error) {
+	return ParseHashFileWith(r, HashFileOptions{})
+}
+
+// ParseHashFileWith reads the records of the hash file from r with the options
+// and returns the decoded hashes and the issues of the bad records. A bad
+// record does not abort the reading.
+//
+// The error is returned only if r could not be read or a line exceeds the
+// bufio.MaxScanTokenSize. The memory use is proportional to the number of the
+// records. Use ScanHashFile() for very large files.
+func ParseHashFileWith(r io.Reader, opts HashFileOptions) ([]*Hashed, []ParseIssue, error) {
+	var (
+		hashes []*Hashed
+		issues []ParseIssue
+	)
+
+	err := ScanHashFile(r, opts, func(record HashRecord) error {
+		if record.Err != nil {
+			issues = append(issues, ParseIssue{Err: record.Err, User: record.User, Line: record.Line})
+		} else {
+			hashes = append(hashes, record.Hashed)
+		}
+
+		return nil
+	})
+
+	return hashes, issues, err
+}
+
+// ScanHashFile reads the records of the hash file from r line by line and
+// calls fn with each of them, so that the memory use does not depend on the
+// size of the file.
+//
+// The lines are trimmed and the blank lines and the comment lines starting with
+// "#" are skipped. With HashFileOptions.Separator, a line is split at the first
+// separator into the user name and the hash string. The bad records are passed
+// to fn with HashRecord.Err set. If fn returns an error, the scan stops and the
+// error is returned as is.
+func ScanHashFile(r io.Reader, opts HashFileOptions, fn func(HashRecord) error) error {
+	if r == nil || fn == nil {
+		return errors.New("failed to scan the hash file: reader or callback is nil")
+	}
+
+	scanner := bufio.NewScanner(r)
+
+	for lineNum := 1; scanner.Scan(); lineNum++ {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || line[0] == '#' {
+			continue
+		}
+
+		if err := fn(opts.parseRecord(line, lineNum)); err != nil {
+			return err
+		}
+	}
+
+	if err := scanner.Err(); err != nil {
+		return errors.Wrap(err, "failed to scan the hash file")
+	}
+
+	return nil
+}
+
+// ----------------------------------------------------------------------------
+//  Methods of HashFileOptions (Private)
+// ----------------------------------------------------------------------------
+
+// parseRecord parses the trimmed non-blank line into a record.
+func (opts HashFileOptions) parseRecord(line string, lineNum int) HashRecord {
+	record := HashRecord{Line: lineNum}
+	encoded := line
+
+	if opts.Separator != "" {
+		user, rest, found := strings.Cut(line, opts.Separator)
+		if !found {
+			record.Err = errors.Errorf("missing separator %q", opts.Separator)
+
+			return record
+		}
+
+		record.User = strings.TrimSpace(user)
+		encoded = strings.TrimSpace(rest)
+	}
+
+	record.Hashed, record.Err = DecodeHashStr(encoded)
+
+	return record
+}
@@ -0,0 +1,132 @@
+package argonize_test
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"testing/iotest"
+
+	"github.com/KEINOS/go-argonize"
+	"github.com/stretchr/testify/require"
+)
+
+// ----------------------------------------------------------------------------
+//  ParseHashFile()
+// ----------------------------------------------------------------------------
+
+func TestParseHashFile(t *testing.T) {
+	t.Parallel()
+
+	input := "# audit dump\n" +
+		sampleHashStr + "\n" +
+		"\n" +
+		"$argon2id$v=19$m=65536,t=3,p=2$!!!$" + sampleHashB64 + "\r\n" +
+		"   \n" +
+		"  " + sampleHashStr + "\n" +
+		"not a hash"
+
+	hashes, issues, err := argonize.ParseHashFile(strings.NewReader(input))
+	require.NoError(t, err)
+
+	require.Len(t, hashes, 2)
+	require.Equal(t, sampleHashStr, hashes[0].String())
+	require.Equal(t, sampleHashStr, hashes[1].String())
+
+	require.Len(t, issues, 2, "bad records should not abort the reading")
+	require.Equal(t, 4, issues[0].Line)
+	require.ErrorIs(t, issues[0], argonize.ErrInvalidSalt)
+	require.EqualError(t, issues[0], "line 4: failed to decode salt value: illegal base64 data at input byte 0")
+	require.Equal(t, 7, issues[1].Line)
+
+	var parseErr *argonize.ParseError
+	require.True(t, errors.As(issues[1], &parseErr))
+	require.Equal(t, argonize.SegmentWhole, parseErr.Segment)
+}
+
+func TestParseHashFileWith_separator(t *testing.T) {
+	t.Parallel()
+
+	input := "alice:" + sampleHashStr + "\n" +
+		"bob : " + sampleHashStr + "\n" +
+		sampleHashStr + "\n" +
+		"carol:$argon2id$broken\n"
+
+	hashes, issues, err := argonize.ParseHashFileWith(strings.NewReader(input), argonize.HashFileOptions{Separator: ":"})
+	require.NoError(t, err)
+	require.Len(t, hashes, 2)
+
+	require.Len(t, issues, 2)
+	require.Equal(t, 3, issues[0].Line)
+	require.ErrorContains(t, issues[0], `missing separator ":"`)
+	require.Equal(t, 4, issues[1].Line)
+	require.Equal(t, "carol", issues[1].User)
+	require.ErrorIs(t, issues[1], argonize.ErrInvalidFormat)
+}
+
+func TestParseHashFile_read_error(t *testing.T) {
+	t.Parallel()
+
+	_, _, err := argonize.ParseHashFile(iotest.ErrReader(errors.New("forced failure")))
+	require.ErrorContains(t, err, "forced failure")
+
+	_, _, err = argonize.ParseHashFile(strings.NewReader(strings.Repeat("a", 70*1024)))
+	require.ErrorContains(t, err, "token too long")
+
+	_, _, err = argonize.ParseHashFile(nil)
+	require.ErrorContains(t, err, "reader or callback is nil")
+}
+
+// ----------------------------------------------------------------------------
+//  ScanHashFile()
+// ----------------------------------------------------------------------------
+
+func TestScanHashFile(t *testing.T) {
+	t.Parallel()
+
+	input := "alice:" + sampleHashStr + "\n" +
+		"bob:broken\n" +
+		"carol:" + sampleHashStr + "\n"
+
+	var records []argonize.HashRecord
+
+	err := argonize.ScanHashFile(strings.NewReader(input), argonize.HashFileOptions{Separator: ":"},
+		func(record argonize.HashRecord) error {
+			records = append(records, record)
+
+			return nil
+		})
+	require.NoError(t, err)
+	require.Len(t, records, 3)
+
+	require.Equal(t, "alice", records[0].User)
+	require.Equal(t, 1, records[0].Line)
+	require.NoError(t, records[0].Err)
+	require.NotNil(t, records[0].Hashed)
+
+	require.Equal(t, "bob", records[1].User)
+	require.Error(t, records[1].Err)
+	require.Nil(t, records[1].Hashed)
+
+	require.Equal(t, 3, records[2].Line)
+}
+
+func TestScanHashFile_stop(t *testing.T) {
+	t.Parallel()
+
+	input := strings.Repeat(sampleHashStr+"\n", 10)
+	errStop := errors.New("stop")
+	count := 0
+
+	err := argonize.ScanHashFile(strings.NewReader(input), argonize.HashFileOptions{},
+		func(argonize.HashRecord) error {
+			count++
+			if count == 3 {
+				return errStop
+			}
+
+			return nil
+		})
+
+	require.ErrorIs(t, err, errStop, "the error of the callback should be returned as is")
+	require.Equal(t, 3, count)
+}
@@ -0,0 +1,46 @@
+package argonize
+
+import (
+	"bytes"
+
+	"github.com/pkg/errors"
+)
+
+// ============================================================================
+//  Functions
+// ============================================================================
+
+// DecodeHashList decodes the newline delimited hash strings in data, such as a
+// password file, in one call.
+//
+// Each line is decoded with DecodeHashStr() after trimming the surrounding
+// whitespace, including the "\r" of CRLF line endings. Blank lines and comment
+// lines starting with "#" are skipped.
+//
+// The returned slices are parallel and have an element per decoded line. For
+// each line, either the Hashed object or the error is nil. The errors are
+// prefixed with the 1-based line number and unwrap to the *ParseError of
+// DecodeHashStr(). Use NewBatchError() to get them as a single error.
+func DecodeHashList(data []byte) ([]*Hashed, []error) {
+	var (
+		hashes []*Hashed
+		errs   []error
+	)
+
+	for index, line := range bytes.Split(data, []byte("\n")) {
+		line = bytes.TrimSpace(line)
+		if len(line) == 0 || line[0] == '#' {
+			continue
+		}
+
+		hashed, err := DecodeHashStr(string(line))
+		if err != nil {
+			err = errors.Wrapf(err, "line %d", index+1)
+		}
+
+		hashes = append(hashes, hashed)
+		errs = append(errs, err)
+	}
+
+	return hashes, errs
+}
@@ -0,0 +1,62 @@
+package argonize_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/KEINOS/go-argonize"
+	"github.com/stretchr/testify/require"
+)
+
+// ----------------------------------------------------------------------------
+//  DecodeHashList()
+// ----------------------------------------------------------------------------
+
+func TestDecodeHashList(t *testing.T) {
+	t.Parallel()
+
+	other := argonize.HashCustom([]byte("password"), []byte("0123456789abcdef"), lowCostParams())
+	require.NotNil(t, other)
+
+	data := []byte("# exported password file\n" +
+		sampleHashStr + "\n" +
+		"\n" +
+		"   \t\n" +
+		"  # indented comment\n" +
+		other.String() + "\r\n" +
+		"$argon2id$v=19$m=65536,t=3,p=2$!!!$" + sampleHashB64 + "\n" +
+		"  " + sampleHashStr + "  \n")
+
+	hashes, errs := argonize.DecodeHashList(data)
+
+	require.Len(t, hashes, 4, "blank and comment lines should be skipped")
+	require.Len(t, errs, 4, "it should be parallel to the hashes")
+
+	require.NoError(t, errs[0])
+	require.Equal(t, sampleHashStr, hashes[0].String())
+
+	require.NoError(t, errs[1], "CRLF should be accepted")
+	require.Equal(t, other.String(), hashes[1].String())
+
+	require.Nil(t, hashes[2])
+	require.ErrorContains(t, errs[2], "line 7")
+	require.ErrorIs(t, errs[2], argonize.ErrInvalidSalt)
+
+	var parseErr *argonize.ParseError
+	require.True(t, errors.As(errs[2], &parseErr))
+	require.Equal(t, argonize.SegmentSalt, parseErr.Segment)
+
+	require.NoError(t, errs[3], "surrounding whitespace should be trimmed")
+	require.Equal(t, sampleHashStr, hashes[3].String())
+}
+
+func TestDecodeHashList_empty(t *testing.T) {
+	t.Parallel()
+
+	for _, data := range [][]byte{nil, []byte(""), []byte("\n\n# comment only\n")} {
+		hashes, errs := argonize.DecodeHashList(data)
+
+		require.Empty(t, hashes)
+		require.Empty(t, errs)
+	}
+}
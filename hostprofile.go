@@ -0,0 +1,156 @@
+package argonize
+
+import (
+	"runtime"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// ============================================================================
+//  Type: ProfileEntry
+// ============================================================================
+
+// ProfileEntry is the measurement of a parameter set by Profile().
+type ProfileEntry struct {
+	// Params is the measured parameter set.
+	Params *Params
+	// Duration is the time taken to derive a key with the Params.
+	Duration time.Duration
+	// AllocBytes is the memory allocated by the Go heap during the derivation.
+	// It is approximate, since the other goroutines allocate as well, but is
+	// dominated by the MemoryCost.
+	AllocBytes uint64
+}
+
+// ============================================================================
+//  Functions
+// ============================================================================
+
+// DefaultProfileSweep returns a new copy of the parameter sets measured by
+// Profile(), in the ascending order of the cost:
+//
+//   - m=19MiB, t=2, p=1: the OWASP minimum
+//   - m=32MiB, t=2, p=1
+//   - m=64MiB, t=3, p=4: PresetRFC9106Second
+//   - m=128MiB, t=3, p=4
+//   - m=256MiB, t=3, p=4
+//
+// The presets of more than 256 MiB, such as PresetRFC9106First of 2 GiB, are
+// not included so that the profiling at the startup does not exhaust the
+// memory of small hosts. Pass a custom sweep to ProfileWith() to measure them.
+func DefaultProfileSweep() []*Params {
+	sweep := []struct {
+		memoryCost  uint32
+		iterations  uint32
+		parallelism uint8
+	}{
+		{memoryCost: 19 * 1024, iterations: 2, parallelism: 1},
+		{memoryCost: 32 * 1024, iterations: 2, parallelism: 1},
+		{memoryCost: 64 * 1024, iterations: 3, parallelism: 4},
+		{memoryCost: 128 * 1024, iterations: 3, parallelism: 4},
+		{memoryCost: 256 * 1024, iterations: 3, parallelism: 4},
+	}
+
+	result := make([]*Params, 0, len(sweep))
+
+	for _, item := range sweep {
+		params := NewParams()
+		params.MemoryCost = item.memoryCost
+		params.Iterations = item.iterations
+		params.Parallelism = item.parallelism
+
+		result = append(result, params)
+	}
+
+	return result
+}
+
+// Profile is similar to ProfileWith() with DefaultProfileSweep().
+func Profile(maxDuration time.Duration) ([]ProfileEntry, error) {
+	return ProfileWith(maxDuration, DefaultProfileSweep())
+}
+
+// ProfileWith measures the key derivation of each parameter set of the sweep on
+// the current host, in order, and returns the entries measured within the
+// maxDuration budget. Use it at the startup or the first run of the application
+// to choose the parameters fitting the hardware, such as the most expensive
+// entry within the latency target of the logins.
+//
+// Before each measurement, the duration is estimated from the previous entry
+// by the ratio of the memory cost times the iterations. The sweep stops early,
+// without an error, if the estimate exceeds the remaining budget, so order the
+// sweep by the ascending cost. The first set is always measured.
+//
+// It returns an error if maxDuration is not positive, if the sweep is empty or
+// has invalid params, or if a derivation failed. The measurements are done one
+// at a time, so run it before serving the requests for stable results.
+func ProfileWith(maxDuration time.Duration, sweep []*Params) ([]ProfileEntry, error) {
+	if maxDuration <= 0 {
+		return nil, errors.Errorf("failed to profile: max duration %v must be positive", maxDuration)
+	}
+
+	if len(sweep) == 0 {
+		return nil, errors.New("failed to profile: the sweep is empty")
+	}
+
+	for index, params := range sweep {
+		if err := params.Validate(); err != nil {
+			return nil, errors.Wrapf(err, "failed to profile: invalid params at index %d", index)
+		}
+	}
+
+	deadline := time.Now().Add(maxDuration)
+	entries := make([]ProfileEntry, 0, len(sweep))
+
+	for _, params := range sweep {
+		if len(entries) > 0 && time.Until(deadline) < estimateDuration(entries[len(entries)-1], params) {
+			break
+		}
+
+		paramsCopy := *params
+
+		entry, err := profileParams(&paramsCopy)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to profile %s", paramsCopy.EncodeParams())
+		}
+
+		entries = append(entries, entry)
+	}
+
+	return entries, nil
+}
+
+// ----------------------------------------------------------------------------
+//  Private Functions
+// ----------------------------------------------------------------------------
+
+// estimateDuration estimates the duration of the params from the measured
+// entry by the ratio of the memory cost times the iterations.
+func estimateDuration(measured ProfileEntry, params *Params) time.Duration {
+	measuredCost := float64(measured.Params.MemoryCost) * float64(measured.Params.Iterations)
+	cost := float64(params.MemoryCost) * float64(params.Iterations)
+
+	return time.Duration(float64(measured.Duration) * cost / measuredCost)
+}
+
+// profileParams measures the duration and the heap allocation of a trial hash
+// with the params.
+func profileParams(params *Params) (ProfileEntry, error) {
+	var before, after runtime.MemStats
+
+	runtime.ReadMemStats(&before)
+
+	elapsed, err := trialHash(params)
+	if err != nil {
+		return ProfileEntry{}, err
+	}
+
+	runtime.ReadMemStats(&after)
+
+	return ProfileEntry{
+		Params:     params,
+		Duration:   elapsed,
+		AllocBytes: after.TotalAlloc - before.TotalAlloc,
+	}, nil
+}
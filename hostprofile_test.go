@@ -0,0 +1,88 @@
+package argonize_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/KEINOS/go-argonize"
+	"github.com/stretchr/testify/require"
+)
+
+// ----------------------------------------------------------------------------
+//  DefaultProfileSweep()
+// ----------------------------------------------------------------------------
+
+func TestDefaultProfileSweep(t *testing.T) {
+	t.Parallel()
+
+	sweep := argonize.DefaultProfileSweep()
+	require.Len(t, sweep, 5)
+
+	for index, params := range sweep {
+		require.NoError(t, params.Validate())
+		require.Empty(t, argonize.WarnIfWeak(params), "sweep should not have weak params: %v", params)
+
+		if index > 0 {
+			require.Equal(t, -1, argonize.CompareCost(sweep[index-1], params), "sweep should be ascending")
+		}
+	}
+
+	require.Equal(t, argonize.PresetRFC9106Second.Params(), sweep[2])
+
+	// It should return a copy
+	sweep[0].MemoryCost = 1
+	require.Equal(t, uint32(19*1024), argonize.DefaultProfileSweep()[0].MemoryCost)
+}
+
+// ----------------------------------------------------------------------------
+//  ProfileWith()
+// ----------------------------------------------------------------------------
+
+func TestProfileWith(t *testing.T) {
+	t.Parallel()
+
+	sweep := []*argonize.Params{lowCostParams(), lowCostParams(), lowCostParams()}
+	sweep[1].MemoryCost = 256
+	sweep[2].MemoryCost = 1024
+
+	entries, err := argonize.ProfileWith(time.Minute, sweep)
+	require.NoError(t, err)
+	require.Len(t, entries, len(sweep))
+
+	for index, entry := range entries {
+		require.Equal(t, sweep[index], entry.Params)
+		require.NotSame(t, sweep[index], entry.Params, "it should hold a copy")
+		require.Positive(t, entry.Duration)
+		require.GreaterOrEqual(t, entry.AllocBytes, uint64(entry.Params.MemoryCost)*1024)
+	}
+}
+
+func TestProfileWith_budget(t *testing.T) {
+	t.Parallel()
+
+	// The second set costs about 16384 times the first one
+	expensive := lowCostParams()
+	expensive.MemoryCost = 1024 * 1024
+	expensive.Iterations = 16
+
+	start := time.Now()
+
+	entries, err := argonize.ProfileWith(10*time.Millisecond, []*argonize.Params{lowCostParams(), expensive})
+	require.NoError(t, err)
+	require.Len(t, entries, 1, "the expensive set should be skipped")
+	require.Less(t, time.Since(start), time.Second)
+}
+
+func TestProfileWith_errors(t *testing.T) {
+	t.Parallel()
+
+	_, err := argonize.ProfileWith(0, argonize.DefaultProfileSweep())
+	require.ErrorContains(t, err, "max duration 0s must be positive")
+
+	_, err = argonize.ProfileWith(time.Second, nil)
+	require.ErrorContains(t, err, "the sweep is empty")
+
+	_, err = argonize.ProfileWith(time.Second, []*argonize.Params{lowCostParams(), nil})
+	require.ErrorIs(t, err, argonize.ErrNilParams)
+	require.ErrorContains(t, err, "invalid params at index 1")
+}
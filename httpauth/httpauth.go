@@ -0,0 +1,166 @@
+/*
+Package httpauth provides a net/http middleware for the HTTP Basic
+authentication (RFC 7617) against argonize.Hashed credentials.
+
+It takes care of the timing details which are easy to get wrong:
+
+  - An unknown user is verified against a dummy hash, so that the response
+    takes the same time as for a known user with a wrong password and the user
+    names can not be enumerated by timing.
+  - The password is compared by the key derivation only, never as a string.
+  - With WithBudget(), every verification takes at least the budget, which
+    hides the remaining differences, such as the parameters of old hashes.
+
+The dummy hash has the default parameters of argonize.NewParams(). If the
+stored hashes use other parameters, give a dummy with the same ones via
+WithDummy().
+*/
+package httpauth
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/KEINOS/go-argonize"
+)
+
+// RealmDefault is the default realm of the WWW-Authenticate header.
+const RealmDefault = "Restricted"
+
+// ============================================================================
+//  Type: Option
+// ============================================================================
+
+// Option configures BasicAuth().
+type Option func(*config)
+
+// config is the configuration of BasicAuth().
+type config struct {
+	dummy  *argonize.Hashed
+	hasher *argonize.Hasher
+	realm  string
+	budget time.Duration
+}
+
+// ----------------------------------------------------------------------------
+//  Constructors of Option
+// ----------------------------------------------------------------------------
+
+// WithRealm sets the realm of the WWW-Authenticate header. The default is
+// RealmDefault.
+func WithRealm(realm string) Option {
+	return func(c *config) {
+		c.realm = realm
+	}
+}
+
+// WithBudget sets the per-request verification budget. Every verification,
+// including the ones of unknown users, takes at least the budget. Choose one
+// longer than a regular verification on a loaded machine. Zero disables it,
+// which is the default.
+func WithBudget(budget time.Duration) Option {
+	return func(c *config) {
+		c.budget = budget
+	}
+}
+
+// WithDummy sets the hash verified for unknown users. Use a hash with the same
+// parameters as the stored ones. The default is argonize.DummyHash().
+func WithDummy(dummy *argonize.Hashed) Option {
+	return func(c *config) {
+		c.dummy = dummy
+	}
+}
+
+// WithHasher sets the Hasher to verify the passwords, such as the one with a
+// PepperProvider. The default is argonize.NewHasher(nil).
+func WithHasher(hasher *argonize.Hasher) Option {
+	return func(c *config) {
+		c.hasher = hasher
+	}
+}
+
+// ============================================================================
+//  Functions
+// ============================================================================
+
+// BasicAuth returns a handler which calls next only if the request has the
+// Basic authentication credentials matching the hash of the user returned by
+// lookup. Otherwise, it responds with 401 Unauthorized and the
+// WWW-Authenticate header asking for the credentials.
+//
+// lookup returns the hash of the user and true, or false if the user does not
+// exist. It must be safe for concurrent use. If the verification fails for a
+// reason other than a wrong password, such as an unavailable pepper, it
+// responds with 500 Internal Server Error.
+func BasicAuth(next http.Handler, lookup func(user string) (*argonize.Hashed, bool), opts ...Option) http.Handler {
+	conf := config{realm: RealmDefault}
+
+	for _, opt := range opts {
+		opt(&conf)
+	}
+
+	if conf.dummy == nil {
+		conf.dummy = argonize.DummyHash()
+	}
+
+	if conf.hasher == nil {
+		conf.hasher = argonize.NewHasher(nil)
+	}
+
+	hasher := conf.hasher.WithConstantDuration(conf.budget)
+	challenge := `Basic realm="` + quoteEscaper.Replace(conf.realm) + `", charset="UTF-8"`
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		user, password, ok := r.BasicAuth()
+		if !ok {
+			unauthorized(w, challenge)
+
+			return
+		}
+
+		hashed, found := lookup(user)
+		if !found || hashed == nil {
+			found = false
+			hashed = conf.dummy // spend the same time as a known user
+		}
+
+		passwordBytes := []byte(password)
+		isValid, err := hasher.VerifyContext(r.Context(), hashed, passwordBytes)
+
+		clear(passwordBytes)
+
+		switch {
+		case errors.Is(err, context.Canceled), errors.Is(err, context.DeadlineExceeded):
+			return // the client is gone
+		case err != nil:
+			http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+
+			return
+		case !isValid || !found:
+			unauthorized(w, challenge)
+
+			return
+		}
+
+		next.ServeHTTP(w, r)
+	})
+}
+
+// ----------------------------------------------------------------------------
+//  Private Functions
+// ----------------------------------------------------------------------------
+
+// quoteEscaper escapes the realm as a quoted-string of RFC 9110.
+//
+//nolint:gochecknoglobals // immutable
+var quoteEscaper = strings.NewReplacer(`\`, `\\`, `"`, `\"`)
+
+// unauthorized responds with 401 Unauthorized and the challenge.
+func unauthorized(w http.ResponseWriter, challenge string) {
+	w.Header().Set("WWW-Authenticate", challenge)
+	http.Error(w, http.StatusText(http.StatusUnauthorized), http.StatusUnauthorized)
+}
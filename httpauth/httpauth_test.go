@@ -0,0 +1,194 @@
+package httpauth_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/KEINOS/go-argonize"
+	"github.com/KEINOS/go-argonize/httpauth"
+	"github.com/stretchr/testify/require"
+)
+
+// lowCostParams returns the params cheap enough for tests.
+func lowCostParams() *argonize.Params {
+	params := argonize.NewParams()
+	params.MemoryCost = 64
+	params.Parallelism = 1
+
+	return params
+}
+
+// newTestHandler returns the BasicAuth handler with the user "alice" whose
+// password is "secret".
+func newTestHandler(t *testing.T, opts ...httpauth.Option) http.Handler {
+	t.Helper()
+
+	hashed, err := argonize.HashCustomChecked([]byte("secret"), nil, lowCostParams())
+	require.NoError(t, err)
+
+	dummy, err := argonize.HashCustomChecked([]byte("dummy"), nil, lowCostParams())
+	require.NoError(t, err)
+
+	users := map[string]*argonize.Hashed{"alice": hashed}
+
+	next := http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		_, _ = w.Write([]byte("welcome"))
+	})
+
+	lookup := func(user string) (*argonize.Hashed, bool) {
+		hashed, found := users[user]
+
+		return hashed, found
+	}
+
+	return httpauth.BasicAuth(next, lookup, append([]httpauth.Option{httpauth.WithDummy(dummy)}, opts...)...)
+}
+
+// serve sends a request with the credentials to the handler. Empty user means
+// no credentials.
+func serve(handler http.Handler, user, password string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+	if user != "" {
+		req.SetBasicAuth(user, password)
+	}
+
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, req)
+
+	return rec
+}
+
+// ----------------------------------------------------------------------------
+//  BasicAuth()
+// ----------------------------------------------------------------------------
+
+func TestBasicAuth(t *testing.T) {
+	t.Parallel()
+
+	handler := newTestHandler(t)
+
+	rec := serve(handler, "alice", "secret")
+
+	require.Equal(t, http.StatusOK, rec.Code)
+	require.Equal(t, "welcome", rec.Body.String())
+	require.Empty(t, rec.Header().Get("WWW-Authenticate"))
+}
+
+func TestBasicAuth_unauthorized(t *testing.T) {
+	t.Parallel()
+
+	handler := newTestHandler(t, httpauth.WithRealm(`admin "area"`))
+
+	for _, test := range []struct {
+		name     string
+		user     string
+		password string
+	}{
+		{name: "wrong password", user: "alice", password: "wrong"},
+		{name: "unknown user", user: "bob", password: "secret"},
+		{name: "unknown user with the dummy password", user: "bob", password: "dummy"},
+		{name: "no credentials"},
+	} {
+		rec := serve(handler, test.user, test.password)
+
+		require.Equal(t, http.StatusUnauthorized, rec.Code, test.name)
+		require.Equal(t, `Basic realm="admin \"area\"", charset="UTF-8"`, rec.Header().Get("WWW-Authenticate"))
+		require.NotContains(t, rec.Body.String(), "welcome")
+	}
+}
+
+func TestBasicAuth_default_realm(t *testing.T) {
+	t.Parallel()
+
+	rec := serve(newTestHandler(t), "", "")
+
+	require.Equal(t, http.StatusUnauthorized, rec.Code)
+	require.Equal(t, `Basic realm="Restricted", charset="UTF-8"`, rec.Header().Get("WWW-Authenticate"))
+}
+
+func TestBasicAuth_nil_hash(t *testing.T) {
+	t.Parallel()
+
+	next := http.HandlerFunc(func(http.ResponseWriter, *http.Request) {
+		require.Fail(t, "next should not be called")
+	})
+
+	dummy, err := argonize.HashCustomChecked([]byte("dummy"), nil, lowCostParams())
+	require.NoError(t, err)
+
+	// A lookup returning true with a nil hash should be treated as unknown
+	handler := httpauth.BasicAuth(next, func(string) (*argonize.Hashed, bool) {
+		return nil, true
+	}, httpauth.WithDummy(dummy))
+
+	rec := serve(handler, "alice", "dummy")
+	require.Equal(t, http.StatusUnauthorized, rec.Code)
+}
+
+func TestBasicAuth_verification_error(t *testing.T) {
+	t.Parallel()
+
+	hashed, err := argonize.HashCustomChecked([]byte("secret"), nil, lowCostParams())
+	require.NoError(t, err)
+
+	// A peppered hash without a PepperProvider can not be verified
+	hashed.KeyID = "k1"
+
+	handler := httpauth.BasicAuth(http.NotFoundHandler(), func(string) (*argonize.Hashed, bool) {
+		return hashed, true
+	})
+
+	rec := serve(handler, "alice", "secret")
+	require.Equal(t, http.StatusInternalServerError, rec.Code)
+}
+
+func TestBasicAuth_canceled(t *testing.T) {
+	t.Parallel()
+
+	handler := newTestHandler(t, httpauth.WithBudget(time.Minute))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	req := httptest.NewRequest(http.MethodGet, "/", nil).WithContext(ctx)
+	req.SetBasicAuth("alice", "secret")
+
+	rec := httptest.NewRecorder()
+	start := time.Now()
+
+	handler.ServeHTTP(rec, req)
+
+	require.Less(t, time.Since(start), 10*time.Second, "it should not wait for the budget")
+	require.NotContains(t, rec.Body.String(), "welcome")
+}
+
+// It checks that the unknown users are not answered faster than the known
+// ones, which would reveal the user names.
+func TestBasicAuth_timing(t *testing.T) {
+	t.Parallel()
+
+	const budget = 50 * time.Millisecond
+
+	handler := newTestHandler(t, httpauth.WithBudget(budget))
+
+	for _, test := range []struct {
+		name     string
+		user     string
+		password string
+		wantCode int
+	}{
+		{name: "correct", user: "alice", password: "secret", wantCode: http.StatusOK},
+		{name: "wrong password", user: "alice", password: "wrong", wantCode: http.StatusUnauthorized},
+		{name: "unknown user", user: "bob", password: "secret", wantCode: http.StatusUnauthorized},
+	} {
+		start := time.Now()
+		rec := serve(handler, test.user, test.password)
+		elapsed := time.Since(start)
+
+		require.Equal(t, test.wantCode, rec.Code, test.name)
+		require.GreaterOrEqual(t, elapsed, budget, "%s should take at least the budget", test.name)
+	}
+}
@@ -0,0 +1,193 @@
+package argonize
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/binary"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+const (
+	// macSeparator separates the PHC string and the base64 encoded MAC.
+	macSeparator = "$mac="
+	// macDomain is the domain separation prefix of the bound MAC input.
+	macDomain = "argonize-mac-v1\x00"
+)
+
+// ============================================================================
+//  Public Variables
+// ============================================================================
+
+// ErrTampered is the error wrapped by ErrBadSignature. It matches when the MAC
+// does not match, such as the parameters of the stored hash were modified or
+// the hash was moved from another record. Check it with errors.Is().
+//
+//nolint:gochecknoglobals // sentinel error
+var ErrTampered = errors.New("the hash has been tampered with")
+
+// ErrBadSignature is the error returned by VerifySignedHash() when the MAC of
+// the signed hash does not match any of the keys. It wraps ErrTampered, so
+// errors.Is() matches both.
+//
+//nolint:gochecknoglobals // sentinel error
+var ErrBadSignature = errors.Wrap(ErrTampered, "bad signature")
+
+// ============================================================================
+//  Functions
+// ============================================================================
+
+// SignHash returns the encoded hash string with the HMAC-SHA256 of it and the
+// recordID appended as "$mac=<base64>". E.g.
+// "$argon2id$v=19$...$<hash>$mac=<mac>". Use VerifySignedHash() with the same
+// recordID to verify the signed hash and the password at once.
+//
+// It protects the integrity of the stored hash, such as the parameters being
+// lowered by an attacker with write access to the database. Keep the key
+// secret and apart from the database.
+//
+// The recordID binds the signature to the row of the credential, such as the
+// user ID or the primary key. Without it, an attacker with write access to the
+// credential store could copy the signed hash of their own account into the
+// row of the victim and log in with their own password.
+//
+// It returns an error if the hash is uninitialized, or the key or the recordID
+// is empty.
+func SignHash(h *Hashed, macKey []byte, recordID string) (string, error) {
+	if h.IsZero() {
+		return "", errors.New("failed to sign the hash: the hash is uninitialized")
+	}
+
+	encoded := h.String()
+
+	mac, err := computeBoundMAC(encoded, macKey, recordID)
+	if err != nil {
+		return "", errors.Wrap(err, "failed to sign the hash")
+	}
+
+	return encoded + macSeparator + base64.RawStdEncoding.EncodeToString(mac), nil
+}
+
+// VerifySignedHash is similar to VerifySignedHashWithKeys() with a single key.
+func VerifySignedHash(signed string, macKey []byte, recordID string, password []byte) (bool, error) {
+	isValid, _, err := VerifySignedHashWithKeys(signed, [][]byte{macKey}, recordID, password)
+
+	return isValid, err
+}
+
+// VerifySignedHashWithKeys verifies the MAC of the signed hash of SignHash()
+// with the keys in order and the recordID, then the password against the hash.
+// It detects a hash swapped for the one of a known password by someone with
+// write access to the credential store, without the MAC key, including the
+// signed hash of another row.
+//
+// The MAC is checked in constant time before any Argon2 work, so a forged hash
+// costs no key derivation. It returns false and an error wrapping
+// ErrBadSignature if the MAC is missing or matches none of the keys for the
+// recordID, and false and an error if no keys are given, a key or the recordID
+// is empty, or the hash is malformed. Otherwise, it returns whether the
+// password matches and the index of the matched key.
+//
+// To rotate the MAC key, pass the new key first followed by the old ones, and
+// re-sign the hash with the new key if keyIndex is not 0.
+func VerifySignedHashWithKeys(
+	signed string,
+	macKeys [][]byte,
+	recordID string,
+	password []byte,
+) (ok bool, keyIndex int, err error) {
+	encoded, keyIndex, err := verifyBoundMAC(signed, macKeys, recordID)
+	if err != nil {
+		return false, -1, errors.Wrap(err, "failed to verify the signed hash")
+	}
+
+	hashed, err := DecodeHashStr(encoded)
+	if err != nil {
+		return false, -1, errors.Wrap(err, "failed to verify the signed hash")
+	}
+
+	isValid, err := new(Hasher).verify(hashed, password)
+	if err != nil {
+		return false, -1, errors.Wrap(err, "failed to verify the signed hash")
+	}
+
+	return isValid, keyIndex, nil
+}
+
+// ----------------------------------------------------------------------------
+//  Private Functions
+// ----------------------------------------------------------------------------
+
+// splitMAC splits the signed hash string into the encoded hash string and
+// the decoded MAC. It returns false if the MAC is missing or malformed.
+func splitMAC(signed string) (encoded string, mac []byte, found bool) {
+	idx := strings.LastIndex(signed, macSeparator)
+	if idx < 0 {
+		return "", nil, false
+	}
+
+	mac, err := base64.RawStdEncoding.Strict().DecodeString(signed[idx+len(macSeparator):])
+	if err != nil {
+		return "", nil, false
+	}
+
+	return signed[:idx], mac, true
+}
+
+// computeBoundMAC returns the HMAC-SHA256 of the encoded hash string bound to
+// the context, such as the record ID. The context is length-prefixed, so that
+// the boundary between it and the encoded hash string is unambiguous.
+func computeBoundMAC(encoded string, key []byte, context string) ([]byte, error) {
+	if len(key) == 0 {
+		return nil, errors.New("the key is empty")
+	}
+
+	if context == "" {
+		return nil, errors.New("the context is empty")
+	}
+
+	mac := hmac.New(sha256.New, key)
+	mac.Write([]byte(macDomain))
+	mac.Write(binary.BigEndian.AppendUint32(nil, uint32(len(context)))) //nolint:gosec // context is never that long
+	mac.Write([]byte(context))
+	mac.Write([]byte(encoded))
+
+	return mac.Sum(nil), nil
+}
+
+// verifyBoundMAC verifies the MAC of the signed hash string with the keys in
+// order and the context, and returns the encoded hash string and the index of
+// the matched key. The MACs are compared in constant time. It returns an error
+// wrapping ErrBadSignature if the MAC is missing or matches none of the keys.
+func verifyBoundMAC(signed string, keys [][]byte, context string) (string, int, error) {
+	if len(keys) == 0 {
+		return "", -1, errors.New("no keys given")
+	}
+
+	for index, key := range keys {
+		if len(key) == 0 {
+			return "", -1, errors.Errorf("key at index %d: the key is empty", index)
+		}
+	}
+
+	encoded, mac, found := splitMAC(signed)
+
+	for index, key := range keys {
+		expect, err := computeBoundMAC(encoded, key, context)
+		if err != nil {
+			return "", -1, errors.Wrapf(err, "key at index %d", index)
+		}
+
+		if found && hmac.Equal(mac, expect) {
+			return encoded, index, nil
+		}
+	}
+
+	if !found {
+		return "", -1, errors.Wrap(ErrBadSignature, "missing MAC")
+	}
+
+	return "", -1, errors.Wrap(ErrBadSignature, "MAC mismatch")
+}
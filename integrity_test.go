@@ -0,0 +1,207 @@
+package argonize_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/KEINOS/go-argonize"
+	"github.com/stretchr/testify/require"
+)
+
+// ----------------------------------------------------------------------------
+//  SignHash() / VerifySignedHash()
+// ----------------------------------------------------------------------------
+
+func TestSignHash(t *testing.T) {
+	t.Parallel()
+
+	key := []byte("my secret hmac key")
+	password := []byte("my password")
+
+	hashedObj, err := argonize.NewHasher(lowCostParams()).Hash(password)
+	require.NoError(t, err)
+
+	signed, err := argonize.SignHash(hashedObj, key, "user:42")
+	require.NoError(t, err)
+	require.True(t, strings.HasPrefix(signed, hashedObj.String()+"$mac="), signed)
+
+	// The signed string should not be decodable without verification
+	_, err = argonize.DecodeHashStr(signed)
+	require.Error(t, err)
+
+	isValid, err := argonize.VerifySignedHash(signed, key, "user:42", password)
+	require.NoError(t, err)
+	require.True(t, isValid)
+
+	isValid, err = argonize.VerifySignedHash(signed, key, "user:42", []byte("wrong password"))
+	require.NoError(t, err, "wrong password should not be an error")
+	require.False(t, isValid)
+
+	isValid, err = argonize.VerifySignedHash(signed, []byte("other key"), "user:42", password)
+	require.ErrorIs(t, err, argonize.ErrBadSignature)
+	require.ErrorIs(t, err, argonize.ErrTampered, "it should match ErrTampered as well")
+	require.False(t, isValid)
+}
+
+func TestSignHash_errors(t *testing.T) {
+	t.Parallel()
+
+	hashedObj, err := argonize.DecodeHashStr(sampleHashStr)
+	require.NoError(t, err)
+
+	_, err = argonize.SignHash(hashedObj, nil, "user:42")
+	require.ErrorContains(t, err, "the key is empty")
+
+	_, err = argonize.SignHash(hashedObj, []byte("key"), "")
+	require.ErrorContains(t, err, "the context is empty")
+
+	_, err = argonize.SignHash(nil, []byte("key"), "user:42")
+	require.ErrorContains(t, err, "the hash is uninitialized")
+
+	_, err = argonize.SignHash(new(argonize.Hashed), []byte("key"), "user:42")
+	require.ErrorContains(t, err, "the hash is uninitialized")
+}
+
+func TestVerifySignedHash_tampered(t *testing.T) {
+	t.Parallel()
+
+	key := []byte("my secret hmac key")
+	password := []byte("password")
+
+	hashedObj, err := argonize.DecodeHashStr(sampleHashStr)
+	require.NoError(t, err)
+
+	signed, err := argonize.SignHash(hashedObj, key, "user:42")
+	require.NoError(t, err)
+
+	for _, tampered := range []string{
+		strings.Replace(signed, "t=3", "t=1", 1),
+		strings.Replace(signed, "m=65536", "m=8", 1),
+		strings.Replace(signed, "Woo1", "Woo2", 1),
+		signed[:len(signed)-1] + "A",
+		sampleHashStr,
+		sampleHashStr + "$mac=",
+		sampleHashStr + "$mac=%%",
+	} {
+		isValid, err := argonize.VerifySignedHash(tampered, key, "user:42", password)
+
+		require.ErrorIs(t, err, argonize.ErrTampered, tampered)
+		require.False(t, isValid)
+	}
+}
+
+// A signed hash copied into the row of another user should not verify, even
+// with the password of the copied hash.
+func TestVerifySignedHash_moved_to_another_row(t *testing.T) {
+	t.Parallel()
+
+	key := []byte("my secret hmac key")
+
+	attacker, err := argonize.NewHasher(lowCostParams()).Hash([]byte("attacker password"))
+	require.NoError(t, err)
+
+	attackerRow, err := argonize.SignHash(attacker, key, "user:666")
+	require.NoError(t, err)
+
+	isValid, err := argonize.VerifySignedHash(attackerRow, key, "user:666", []byte("attacker password"))
+	require.NoError(t, err)
+	require.True(t, isValid, "it should verify in its own row")
+
+	// Copied into the row of the victim
+	isValid, err = argonize.VerifySignedHash(attackerRow, key, "user:42", []byte("attacker password"))
+	require.ErrorIs(t, err, argonize.ErrBadSignature)
+	require.False(t, isValid)
+
+	// The length prefix should keep the record ID apart from the hash string
+	isValid, err = argonize.VerifySignedHash(attackerRow, key, "user:66", []byte("attacker password"))
+	require.ErrorIs(t, err, argonize.ErrBadSignature)
+	require.False(t, isValid)
+}
+
+func TestVerifySignedHash_bit_flipped(t *testing.T) {
+	t.Parallel()
+
+	key := []byte("my secret hmac key")
+	password := []byte("my password")
+
+	hashedObj, err := argonize.NewHasher(lowCostParams()).Hash(password)
+	require.NoError(t, err)
+
+	signed, err := argonize.SignHash(hashedObj, key, "user:42")
+	require.NoError(t, err)
+
+	for index := range len(signed) {
+		for _, bit := range []byte{0x01, 0x02, 0x20} {
+			flipped := []byte(signed)
+			flipped[index] ^= bit
+
+			isValid, err := argonize.VerifySignedHash(string(flipped), key, "user:42", password)
+
+			require.ErrorIs(t, err, argonize.ErrBadSignature, "index %d, bit %#x: %s", index, bit, flipped)
+			require.False(t, isValid)
+		}
+	}
+
+	// A hash swapped for the one of a known password should be detected
+	swapped, err := argonize.NewHasher(lowCostParams()).Hash([]byte("attacker password"))
+	require.NoError(t, err)
+
+	_, macPart, found := strings.Cut(signed, "$mac=")
+	require.True(t, found)
+
+	isValid, err := argonize.VerifySignedHash(swapped.String()+"$mac="+macPart, key, "user:42",
+		[]byte("attacker password"))
+	require.ErrorIs(t, err, argonize.ErrBadSignature)
+	require.False(t, isValid)
+
+	// Unsigned hash
+	_, err = argonize.VerifySignedHash(hashedObj.String(), key, "user:42", password)
+	require.ErrorIs(t, err, argonize.ErrBadSignature)
+}
+
+func TestVerifySignedHashWithKeys_rotation(t *testing.T) {
+	t.Parallel()
+
+	oldKey := []byte("old hmac key")
+	newKey := []byte("new hmac key")
+	password := []byte("my password")
+
+	hashedObj, err := argonize.NewHasher(lowCostParams()).Hash(password)
+	require.NoError(t, err)
+
+	signedOld, err := argonize.SignHash(hashedObj, oldKey, "user:42")
+	require.NoError(t, err)
+
+	isValid, keyIndex, err := argonize.VerifySignedHashWithKeys(signedOld, [][]byte{newKey, oldKey}, "user:42", password)
+	require.NoError(t, err)
+	require.True(t, isValid)
+	require.Equal(t, 1, keyIndex, "old key should be matched")
+
+	signedNew, err := argonize.SignHash(hashedObj, newKey, "user:42")
+	require.NoError(t, err)
+
+	isValid, keyIndex, err = argonize.VerifySignedHashWithKeys(signedNew, [][]byte{newKey, oldKey}, "user:42", password)
+	require.NoError(t, err)
+	require.True(t, isValid)
+	require.Equal(t, 0, keyIndex)
+
+	// Retired key
+	isValid, keyIndex, err = argonize.VerifySignedHashWithKeys(signedOld, [][]byte{newKey}, "user:42", password)
+	require.ErrorIs(t, err, argonize.ErrBadSignature)
+	require.False(t, isValid)
+	require.Equal(t, -1, keyIndex)
+
+	// No keys, empty keys and empty record ID should be rejected
+	_, _, err = argonize.VerifySignedHashWithKeys(signedNew, nil, "user:42", password)
+	require.ErrorContains(t, err, "no keys given")
+	require.NotErrorIs(t, err, argonize.ErrBadSignature)
+
+	_, _, err = argonize.VerifySignedHashWithKeys(signedNew, [][]byte{newKey, nil}, "user:42", password)
+	require.ErrorContains(t, err, "key at index 1: the key is empty")
+
+	_, err = argonize.VerifySignedHash(signedNew, nil, "user:42", password)
+	require.ErrorContains(t, err, "the key is empty")
+
+	_, err = argonize.VerifySignedHash(signedNew, newKey, "", password)
+	require.ErrorContains(t, err, "the context is empty")
+}
@@ -0,0 +1,268 @@
+package argonize
+
+import (
+	"sync"
+	"sync/atomic"
+
+	"github.com/pkg/errors"
+	"golang.org/x/crypto/argon2"
+)
+
+// ============================================================================
+//  Type: KDF
+// ============================================================================
+
+// KDF is the key derivation backend computing the Argon2 hash. It allows
+// routing the hashing through another implementation, such as a FIPS validated
+// module or an optimized one.
+//
+// Derive must return params.KeyLength bytes derived with the Variant and costs
+// of params. The params are validated before Derive is called. Implementations
+// must be safe for concurrent use.
+type KDF interface {
+	Derive(password, salt []byte, params *Params) ([]byte, error)
+}
+
+// XCryptoKDF is the KDF of the "golang.org/x/crypto/argon2" package. It is the
+// default.
+type XCryptoKDF struct{}
+
+// Derive implements KDF with argon2.IDKey() or argon2.Key() according to the
+// Variant of the params.
+func (XCryptoKDF) Derive(password, salt []byte, params *Params) ([]byte, error) {
+	switch params.Variant {
+	case "", VariantArgon2id:
+		return argon2.IDKey(
+			password,
+			salt,
+			params.Iterations,
+			params.MemoryCost,
+			params.Parallelism,
+			params.KeyLength,
+		), nil
+	case VariantArgon2i:
+		return argon2.Key(
+			password,
+			salt,
+			params.Iterations,
+			params.MemoryCost,
+			params.Parallelism,
+			params.KeyLength,
+		), nil
+	default:
+		return nil, errors.Errorf("unsupported algorithm variant %q", params.Variant)
+	}
+}
+
+// ============================================================================
+//  Type: NamedKDF
+// ============================================================================
+
+// NamedKDF is a KDF of an algorithm other than Argon2, such as scrypt, which
+// can be registered with RegisterKDF() to hash and verify behind the same API.
+//
+// Name is the algorithm identifier used as the Params.Variant and thus as the
+// prefix of the PHC string, e.g. "$scrypt$v=19$m=...". The rest of the string is
+// the same as Argon2, and Derive interprets the memory cost, iterations and
+// parallelism of the params in its own way.
+type NamedKDF interface {
+	KDF
+	Name() string
+}
+
+//nolint:gochecknoglobals // set via RegisterKDF() only
+var (
+	kdfRegistry   = make(map[Variant]NamedKDF)
+	kdfRegistryMu sync.RWMutex
+)
+
+// kdfBox holds a KDF to store various implementations in an atomic.Value, which
+// requires the same concrete type.
+type kdfBox struct {
+	kdf KDF
+}
+
+// globalKDF is the KDF set by SetKDF().
+//
+//nolint:gochecknoglobals // set via SetKDF() only
+var globalKDF atomic.Value
+
+// ============================================================================
+//  Functions
+// ============================================================================
+
+// SetKDF sets the package-wide KDF used by the hashing and verifying functions,
+// such as HashCustom() and Hashed.IsValidPassword(). It is safe for concurrent
+// use.
+//
+// If k is nil, the default XCryptoKDF is restored. Note that the hashes must be
+// verified with a KDF compatible with the one that created them. To use another
+// KDF without affecting the other users of the package, use Hasher.WithKDF()
+// instead.
+func SetKDF(k KDF) {
+	globalKDF.Store(kdfBox{kdf: k})
+}
+
+// DeriveKeyInto derives the key of the password and salt with the params and
+// the package-wide KDF into dst, then returns dst[:params.KeyLength]. If dst is
+// too short, a new slice is allocated and returned instead. It is the low-level
+// building block for verifying many candidates against the same salt, such as
+// the password spraying checks, without the overhead of HashWithSalt().
+//
+// Unlike HashWithSalt(), the salt is used as is without a copy, and no Hashed
+// object is allocated. Note that the KDF itself still allocates on every call:
+// argon2.IDKey() allocates the MemoryCost KiB of blocks, which dominates the
+// cost, and its own output slice. The latter is copied into dst and zeroed, so
+// reusing dst saves the allocations of the result but not of the derivation.
+func DeriveKeyInto(dst, password, salt []byte, params *Params) ([]byte, error) {
+	if err := params.Validate(); err != nil {
+		return nil, err
+	}
+
+	if len(salt) < int(SaltLengthMin) {
+		return nil, errors.Errorf(
+			"failed to derive the key: salt length %d is shorter than the minimum %d",
+			len(salt), SaltLengthMin,
+		)
+	}
+
+	key, err := deriveKey(password, salt, params)
+	if err != nil {
+		return nil, err
+	}
+
+	defer clear(key)
+
+	if cap(dst) < len(key) {
+		dst = make([]byte, len(key))
+	}
+
+	dst = dst[:len(key)]
+	copy(dst, key)
+
+	return dst, nil
+}
+
+// RegisterKDF registers the KDF under its Name, so that the Params whose
+// Variant is the name are hashed and verified with it. E.g. to A/B test another
+// algorithm against Argon2id:
+//
+//	err := argonize.RegisterKDF(myScryptKDF{}) // Name() returns "scrypt"
+//
+//	params := argonize.NewParams()
+//	params.Variant = "scrypt"
+//
+//	hashedObj, err := argonize.HashWithSalt(password, nil, params)
+//
+// The hashes are decoded and verified with the registered KDF as well, so the
+// KDF must be registered before decoding them. Argon2id remains the default.
+//
+// The name must be 1 to 32 characters of lowercase letters, digits and "-" as
+// in the PHC string format. It returns an error if the name is invalid, is the
+// name of a built-in variant or is already registered. It is safe for
+// concurrent use.
+func RegisterKDF(kdf NamedKDF) error {
+	if kdf == nil {
+		return errors.New("failed to register the KDF: the KDF is nil")
+	}
+
+	name := kdf.Name()
+
+	if !isValidKDFName(name) {
+		return errors.Errorf("failed to register the KDF: invalid name %q", name)
+	}
+
+	if Variant(name) == VariantArgon2id || Variant(name) == VariantArgon2i {
+		return errors.Errorf("failed to register the KDF: %q is a built-in variant", name)
+	}
+
+	kdfRegistryMu.Lock()
+	defer kdfRegistryMu.Unlock()
+
+	if _, ok := kdfRegistry[Variant(name)]; ok {
+		return errors.Errorf("failed to register the KDF: %q is already registered", name)
+	}
+
+	kdfRegistry[Variant(name)] = kdf
+
+	return nil
+}
+
+// ----------------------------------------------------------------------------
+//  Private Functions
+// ----------------------------------------------------------------------------
+
+// registeredKDF returns the KDF registered under the name of the variant.
+func registeredKDF(variant Variant) (NamedKDF, bool) {
+	kdfRegistryMu.RLock()
+	defer kdfRegistryMu.RUnlock()
+
+	kdf, ok := kdfRegistry[variant]
+
+	return kdf, ok
+}
+
+// isValidKDFName returns true if the name is a valid algorithm identifier of
+// the PHC string format.
+func isValidKDFName(name string) bool {
+	if name == "" || len(name) > 32 {
+		return false
+	}
+
+	for _, char := range name {
+		if (char < 'a' || char > 'z') && (char < '0' || char > '9') && char != '-' {
+			return false
+		}
+	}
+
+	return true
+}
+
+// currentKDF returns the package-wide KDF.
+func currentKDF() KDF {
+	if box, ok := globalKDF.Load().(kdfBox); ok && box.kdf != nil {
+		return box.kdf
+	}
+
+	return XCryptoKDF{}
+}
+
+// deriveKey derives the key from the password and salt with the package-wide
+// KDF.
+func deriveKey(password, salt []byte, params *Params) ([]byte, error) {
+	return deriveKeyWith(currentKDF(), password, salt, params)
+}
+
+// deriveKeyWith derives the key from the password and salt with the variant
+// and costs of the parameters through the given KDF.
+func deriveKeyWith(kdf KDF, password, salt []byte, params *Params) ([]byte, error) {
+	// Guard the values the argon2 package panics with.
+	switch {
+	case params == nil:
+		return nil, invalidParams(ErrNilParams)
+	case params.Iterations < 1:
+		return nil, invalidParams(ErrIterationsTooLow)
+	case params.Parallelism < 1:
+		return nil, invalidParams(ErrParallelismTooLow)
+	}
+
+	// The registered KDF of the variant takes precedence over the Argon2 one.
+	if named, ok := registeredKDF(params.Variant); ok {
+		kdf = named
+	}
+
+	release := acquireThreads(params)
+	defer release()
+
+	key, err := kdf.Derive(password, salt, params)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to derive the key")
+	}
+
+	if len(key) != int(params.KeyLength) {
+		return nil, errors.Errorf("failed to derive the key: the KDF returned %d bytes, want %d",
+			len(key), params.KeyLength)
+	}
+
+	return key, nil
+}
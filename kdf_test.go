@@ -0,0 +1,328 @@
+package argonize_test
+
+import (
+	"crypto/sha256"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+
+	"github.com/KEINOS/go-argonize"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/crypto/scrypt"
+)
+
+// fakeKDF is a cheap KDF counting the derivations. It is not Argon2.
+type fakeKDF struct {
+	calls atomic.Int32
+}
+
+func (f *fakeKDF) Derive(password, salt []byte, params *argonize.Params) ([]byte, error) {
+	f.calls.Add(1)
+
+	sum := sha256.Sum256(append(append([]byte("fake"), password...), salt...))
+	key := make([]byte, params.KeyLength)
+
+	for i := range key {
+		key[i] = sum[i%len(sum)]
+	}
+
+	return key, nil
+}
+
+// failingKDF is a KDF that always fails.
+type failingKDF struct{}
+
+func (failingKDF) Derive([]byte, []byte, *argonize.Params) ([]byte, error) {
+	return nil, errors.New("forced failure")
+}
+
+// shortKDF is a KDF returning fewer bytes than the KeyLength.
+type shortKDF struct{}
+
+func (shortKDF) Derive([]byte, []byte, *argonize.Params) ([]byte, error) {
+	return []byte("short"), nil
+}
+
+// ----------------------------------------------------------------------------
+//  SetKDF()
+// ----------------------------------------------------------------------------
+
+//nolint:paralleltest // disable parallel since it changes the package-wide KDF
+func TestSetKDF(t *testing.T) {
+	defer argonize.SetKDF(nil)
+
+	salt := []byte("0123456789abcdef")
+	password := []byte("password")
+
+	kdf := new(fakeKDF)
+	argonize.SetKDF(kdf)
+
+	hashed := argonize.HashCustom(password, salt, lowCostParams())
+	require.NotNil(t, hashed)
+	require.Equal(t, int32(1), kdf.calls.Load())
+
+	require.True(t, hashed.IsValidPassword(password))
+	require.False(t, hashed.IsValidPassword([]byte("wrong")))
+	require.Equal(t, int32(3), kdf.calls.Load(), "verification should call the KDF")
+
+	// Nil restores the default, which does not match the fake one
+	argonize.SetKDF(nil)
+
+	require.False(t, hashed.IsValidPassword(password))
+
+	expect := argonize.HashCustom(password, salt, lowCostParams())
+	require.NotEqual(t, expect.Hash, hashed.Hash)
+	require.Equal(t, int32(3), kdf.calls.Load())
+}
+
+// ----------------------------------------------------------------------------
+//  DeriveKeyInto()
+// ----------------------------------------------------------------------------
+
+func TestDeriveKeyInto(t *testing.T) {
+	t.Parallel()
+
+	salt := []byte("0123456789abcdef")
+	hashedObj, err := argonize.HashWithSalt([]byte("password"), salt, lowCostParams())
+	require.NoError(t, err)
+
+	// Reused if long enough
+	dst := make([]byte, 64)
+
+	key, err := argonize.DeriveKeyInto(dst, []byte("password"), salt, lowCostParams())
+	require.NoError(t, err)
+	require.Equal(t, hashedObj.Hash, key)
+	require.Same(t, &dst[0], &key[0], "dst should be reused")
+
+	// Allocated if too short
+	key, err = argonize.DeriveKeyInto(make([]byte, 8), []byte("password"), salt, lowCostParams())
+	require.NoError(t, err)
+	require.Equal(t, hashedObj.Hash, key)
+
+	key, err = argonize.DeriveKeyInto(nil, []byte("wrong"), salt, lowCostParams())
+	require.NoError(t, err)
+	require.NotEqual(t, hashedObj.Hash, key)
+}
+
+func TestDeriveKeyInto_errors(t *testing.T) {
+	t.Parallel()
+
+	key, err := argonize.DeriveKeyInto(nil, []byte("password"), []byte("0123456789abcdef"), nil)
+	require.ErrorIs(t, err, argonize.ErrNilParams)
+	require.Nil(t, key)
+
+	key, err = argonize.DeriveKeyInto(nil, []byte("password"), []byte("short"), lowCostParams())
+	require.ErrorContains(t, err, "salt length 5 is shorter than the minimum")
+	require.Nil(t, key)
+}
+
+func BenchmarkDeriveKeyInto(b *testing.B) {
+	password := []byte("password")
+	salt := []byte("0123456789abcdef")
+	params := lowCostParams()
+	dst := make([]byte, params.KeyLength)
+
+	b.ReportAllocs()
+	b.ResetTimer()
+
+	for range b.N {
+		if _, err := argonize.DeriveKeyInto(dst, password, salt, params); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+// BenchmarkHashWithSalt is the baseline of BenchmarkDeriveKeyInto.
+func BenchmarkHashWithSalt(b *testing.B) {
+	password := []byte("password")
+	salt := []byte("0123456789abcdef")
+	params := lowCostParams()
+
+	b.ReportAllocs()
+	b.ResetTimer()
+
+	for range b.N {
+		if _, err := argonize.HashWithSalt(password, salt, params); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+// ----------------------------------------------------------------------------
+//  XCryptoKDF
+// ----------------------------------------------------------------------------
+
+func TestXCryptoKDF(t *testing.T) {
+	t.Parallel()
+
+	salt := []byte("0123456789abcdef")
+	hashed := argonize.HashCustom([]byte("password"), salt, lowCostParams())
+
+	key, err := argonize.XCryptoKDF{}.Derive([]byte("password"), salt, lowCostParams())
+	require.NoError(t, err)
+	require.Equal(t, hashed.Hash, key, "it should be the default KDF")
+
+	params := lowCostParams()
+	params.Variant = "argon2d"
+
+	_, err = argonize.XCryptoKDF{}.Derive([]byte("password"), []byte("0123456789abcdef"), params)
+	require.ErrorContains(t, err, "unsupported algorithm variant")
+}
+
+// ----------------------------------------------------------------------------
+//  Hasher.WithKDF()
+// ----------------------------------------------------------------------------
+
+func TestHasher_WithKDF(t *testing.T) {
+	t.Parallel()
+
+	password := []byte("password")
+
+	kdf := new(fakeKDF)
+	hasher := argonize.NewHasher(lowCostParams()).WithKDF(kdf)
+
+	hashed, err := hasher.Hash(password)
+	require.NoError(t, err)
+	require.Equal(t, int32(1), kdf.calls.Load())
+
+	ok, err := hasher.Verify(hashed, password)
+	require.NoError(t, err)
+	require.True(t, ok)
+	require.Equal(t, int32(2), kdf.calls.Load())
+
+	ok, err = hasher.Verify(hashed, []byte("wrong"))
+	require.NoError(t, err)
+	require.False(t, ok)
+
+	// The package-wide KDF does not verify the hash of the fake one
+	require.False(t, hashed.IsValidPassword(password))
+	require.Equal(t, int32(3), kdf.calls.Load())
+}
+
+func TestHasher_WithKDF_pepper(t *testing.T) {
+	t.Parallel()
+
+	password := []byte("password")
+	provider, err := argonize.NewMemoryPepperProvider("k1", []byte("pepper"))
+	require.NoError(t, err)
+
+	kdf := new(fakeKDF)
+	hasher := argonize.NewHasher(lowCostParams()).WithPepperProvider(provider).WithKDF(kdf)
+
+	hashed, err := hasher.Hash(password)
+	require.NoError(t, err)
+
+	ok, err := hasher.Verify(hashed, password)
+	require.NoError(t, err)
+	require.True(t, ok)
+	require.Equal(t, int32(2), kdf.calls.Load())
+
+	// The same hasher without the fake KDF should not verify it
+	ok, err = hasher.WithKDF(nil).Verify(hashed, password)
+	require.NoError(t, err)
+	require.False(t, ok)
+}
+
+func TestHasher_WithKDF_errors(t *testing.T) {
+	t.Parallel()
+
+	for _, kdf := range []argonize.KDF{failingKDF{}, shortKDF{}} {
+		hasher := argonize.NewHasher(lowCostParams()).WithKDF(kdf)
+
+		_, err := hasher.Hash([]byte("password"))
+		require.ErrorContains(t, err, "failed to derive the key")
+
+		hashed, err := argonize.NewHasher(lowCostParams()).Hash([]byte("password"))
+		require.NoError(t, err)
+
+		ok, err := hasher.Verify(hashed, []byte("password"))
+		require.NoError(t, err)
+		require.False(t, ok, "it should not verify if the KDF fails")
+	}
+}
+
+// scryptKDF is a NamedKDF of scrypt. The memory cost is used as the CPU/memory
+// cost parameter N, which must be a power of two.
+type scryptKDF string
+
+func (s scryptKDF) Name() string {
+	return string(s)
+}
+
+func (scryptKDF) Derive(password, salt []byte, params *argonize.Params) ([]byte, error) {
+	return scrypt.Key(password, salt, int(params.MemoryCost), 8, int(params.Parallelism), int(params.KeyLength))
+}
+
+// registerScryptTest registers the scryptKDF named "scrypt-test" once.
+var registerScryptTest = sync.OnceValue(func() error {
+	return argonize.RegisterKDF(scryptKDF("scrypt-test"))
+})
+
+// ----------------------------------------------------------------------------
+//  RegisterKDF()
+// ----------------------------------------------------------------------------
+
+func TestRegisterKDF(t *testing.T) {
+	t.Parallel()
+
+	require.NoError(t, registerScryptTest())
+
+	password := []byte("password")
+
+	params := lowCostParams()
+	params.Variant = "scrypt-test"
+
+	hashedObj, err := argonize.HashWithSalt(password, []byte("0123456789abcdef"), params)
+	require.NoError(t, err)
+	require.True(t, strings.HasPrefix(hashedObj.String(), "$scrypt-test$"), "the prefix should be the name of the KDF")
+
+	expect, err := scrypt.Key(password, []byte("0123456789abcdef"), 64, 8, 1, 32)
+	require.NoError(t, err)
+	require.Equal(t, expect, hashedObj.Hash, "it should be hashed with the registered KDF")
+
+	// Verify behind the same API
+	decoded, err := argonize.DecodeHashStr(hashedObj.String())
+	require.NoError(t, err)
+	require.Equal(t, argonize.Variant("scrypt-test"), decoded.Params.Variant)
+	require.True(t, decoded.IsValidPassword(password))
+	require.False(t, decoded.IsValidPassword([]byte("wrong")))
+
+	// The Argon2 backend of the Hasher does not override the registered KDF
+	ok, err := argonize.NewHasher(nil).WithKDF(new(fakeKDF)).Verify(decoded, password)
+	require.NoError(t, err)
+	require.True(t, ok)
+
+	// Argon2id remains the default
+	hashedObj, err = argonize.HashWithSalt(password, nil, lowCostParams())
+	require.NoError(t, err)
+	require.True(t, strings.HasPrefix(hashedObj.String(), "$argon2id$"))
+}
+
+func TestRegisterKDF_errors(t *testing.T) {
+	t.Parallel()
+
+	require.NoError(t, registerScryptTest())
+
+	for _, test := range []struct {
+		kdf        argonize.NamedKDF
+		msgContain string
+	}{
+		{nil, "the KDF is nil"},
+		{scryptKDF(""), `invalid name ""`},
+		{scryptKDF("Scrypt"), `invalid name "Scrypt"`},
+		{scryptKDF("s$crypt"), `invalid name "s$crypt"`},
+		{scryptKDF(strings.Repeat("a", 33)), "invalid name"},
+		{scryptKDF("argon2id"), `"argon2id" is a built-in variant`},
+		{scryptKDF("argon2i"), `"argon2i" is a built-in variant`},
+		{scryptKDF("scrypt-test"), `"scrypt-test" is already registered`},
+	} {
+		err := argonize.RegisterKDF(test.kdf)
+		require.ErrorContains(t, err, test.msgContain)
+	}
+
+	// Unregistered names are still unsupported
+	_, err := argonize.ParseVariant("scrypt-unknown")
+	require.ErrorIs(t, err, argonize.ErrUnsupportedVariant)
+}
@@ -0,0 +1,227 @@
+package argonize
+
+import (
+	"bufio"
+	"bytes"
+	"io/fs"
+	"math"
+	"os"
+	"path"
+	"runtime/debug"
+	"strconv"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+// ErrMemoryUnknown is the error of AvailableMemory() when no memory limit could
+// be detected, such as on the platforms without /proc. Fall back to the default
+// parameters in that case.
+//
+//nolint:gochecknoglobals // sentinel error
+var ErrMemoryUnknown = errors.New("memory limit is unknown")
+
+// unlimitedMemory is the threshold of the memory limits treated as unlimited.
+// The cgroup v1 reports an unlimited limit as a huge page-aligned number close
+// to math.MaxInt64.
+const unlimitedMemory = uint64(1) << 62
+
+// ============================================================================
+//  Type: MemoryProbe
+// ============================================================================
+
+// MemoryProbe detects the memory limits of the environment for
+// AvailableMemoryWith(). Each method returns the limit in bytes and true, or
+// false if there is no limit or it is unknown.
+type MemoryProbe interface {
+	// CgroupLimit returns the memory limit of the cgroup of the process.
+	CgroupLimit() (uint64, bool)
+	// GoMemLimit returns the soft memory limit of the Go runtime, such as the
+	// one set by the GOMEMLIMIT environment variable.
+	GoMemLimit() (uint64, bool)
+	// SystemMemory returns the total memory of the system.
+	SystemMemory() (uint64, bool)
+}
+
+// ============================================================================
+//  Type: FSMemoryProbe
+// ============================================================================
+
+// FSMemoryProbe is the MemoryProbe reading the Linux pseudo files of FS, which
+// is the root directory, such as os.DirFS("/"). Use fstest.MapFS with the
+// fixtures of the files to test without containers.
+//
+// The cgroup limit is read from "memory.max" of cgroup v2 or
+// "memory.limit_in_bytes" of cgroup v1, of the cgroup in /proc/self/cgroup or
+// else of the root of /sys/fs/cgroup. The system memory is the MemTotal of
+// /proc/meminfo. On the other platforms the files do not exist, so it reports
+// no limit.
+type FSMemoryProbe struct {
+	FS fs.FS
+}
+
+// ----------------------------------------------------------------------------
+//  Constructor of FSMemoryProbe
+// ----------------------------------------------------------------------------
+
+// NewFSMemoryProbe returns a FSMemoryProbe of the root directory of the system.
+func NewFSMemoryProbe() FSMemoryProbe {
+	return FSMemoryProbe{FS: os.DirFS("/")}
+}
+
+// ----------------------------------------------------------------------------
+//  Methods of FSMemoryProbe
+// ----------------------------------------------------------------------------
+
+// CgroupLimit implements MemoryProbe.
+func (p FSMemoryProbe) CgroupLimit() (uint64, bool) {
+	const (
+		rootV1 = "sys/fs/cgroup/memory"
+		rootV2 = "sys/fs/cgroup"
+	)
+
+	dirV1, dirV2 := rootV1, rootV2
+
+	if data, err := fs.ReadFile(p.FS, "proc/self/cgroup"); err == nil {
+		// E.g. "0::/kubepods/pod1" for v2 and "4:memory:/kubepods/pod1" for v1
+		for _, line := range strings.Split(string(data), "\n") {
+			parts := strings.SplitN(line, ":", 3)
+			if len(parts) != 3 {
+				continue
+			}
+
+			cgroupPath := strings.TrimPrefix(parts[2], "/")
+
+			switch {
+			case parts[0] == "0" && parts[1] == "":
+				dirV2 = path.Join(rootV2, cgroupPath)
+			case containsField(parts[1], "memory"):
+				dirV1 = path.Join(rootV1, cgroupPath)
+			}
+		}
+	}
+
+	for _, name := range []string{
+		path.Join(dirV2, "memory.max"),
+		path.Join(dirV1, "memory.limit_in_bytes"),
+		path.Join(rootV2, "memory.max"),
+		path.Join(rootV1, "memory.limit_in_bytes"),
+	} {
+		if limit, ok := p.readLimit(name); ok {
+			return limit, true
+		}
+	}
+
+	return 0, false
+}
+
+// GoMemLimit implements MemoryProbe. It reads the limit of the Go runtime with
+// debug.SetMemoryLimit() without changing it.
+func (p FSMemoryProbe) GoMemLimit() (uint64, bool) {
+	limit := debug.SetMemoryLimit(-1)
+	if limit <= 0 || uint64(limit) >= unlimitedMemory {
+		return 0, false
+	}
+
+	return uint64(limit), true
+}
+
+// SystemMemory implements MemoryProbe.
+func (p FSMemoryProbe) SystemMemory() (uint64, bool) {
+	data, err := fs.ReadFile(p.FS, "proc/meminfo")
+	if err != nil {
+		return 0, false
+	}
+
+	// E.g. "MemTotal:       16314152 kB"
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) != 3 || fields[0] != "MemTotal:" || fields[2] != "kB" {
+			continue
+		}
+
+		kib, err := strconv.ParseUint(fields[1], 10, 64)
+		if err != nil || kib > math.MaxUint64/1024 {
+			return 0, false
+		}
+
+		return kib * 1024, true
+	}
+
+	return 0, false
+}
+
+// ----------------------------------------------------------------------------
+//  Methods of FSMemoryProbe (Private)
+// ----------------------------------------------------------------------------
+
+// readLimit reads a memory limit file of the cgroup. "max" and the huge values
+// mean no limit.
+func (p FSMemoryProbe) readLimit(name string) (uint64, bool) {
+	data, err := fs.ReadFile(p.FS, name)
+	if err != nil {
+		return 0, false
+	}
+
+	limit, err := strconv.ParseUint(strings.TrimSpace(string(data)), 10, 64)
+	if err != nil || limit == 0 || limit >= unlimitedMemory {
+		return 0, false
+	}
+
+	return limit, true
+}
+
+// ============================================================================
+//  Functions
+// ============================================================================
+
+// AvailableMemory returns the memory in bytes available to the process. It is
+// a shorthand of AvailableMemoryWith() with NewFSMemoryProbe().
+func AvailableMemory() (uint64, error) {
+	return AvailableMemoryWith(NewFSMemoryProbe())
+}
+
+// AvailableMemoryWith returns the minimum of the cgroup limit, the Go memory
+// limit and the system memory detected by the probe. The minimum matters in
+// containers, where the node may have 64 GiB but the pod only 512 MiB.
+//
+// Use the result with MaxConcurrent() or to choose the memory cost. It returns
+// ErrMemoryUnknown if the probe detected none of them.
+func AvailableMemoryWith(probe MemoryProbe) (uint64, error) {
+	if probe == nil {
+		return 0, errors.New("failed to detect available memory: probe is nil")
+	}
+
+	available, found := uint64(math.MaxUint64), false
+
+	for _, detect := range []func() (uint64, bool){probe.CgroupLimit, probe.GoMemLimit, probe.SystemMemory} {
+		if limit, ok := detect(); ok {
+			available = min(available, limit)
+			found = true
+		}
+	}
+
+	if !found {
+		return 0, errors.Wrap(ErrMemoryUnknown, "failed to detect available memory")
+	}
+
+	return available, nil
+}
+
+// ----------------------------------------------------------------------------
+//  Private Functions
+// ----------------------------------------------------------------------------
+
+// containsField returns true if the comma-separated list contains the field,
+// such as the controllers "cpu,memory" of /proc/self/cgroup.
+func containsField(list, field string) bool {
+	for _, item := range strings.Split(list, ",") {
+		if item == field {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,172 @@
+package argonize_test
+
+import (
+	"testing"
+	"testing/fstest"
+
+	"github.com/KEINOS/go-argonize"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	mib = uint64(1024 * 1024)
+	gib = 1024 * mib
+)
+
+// fakeMemoryProbe is a MemoryProbe with fixed limits. Zero means no limit.
+type fakeMemoryProbe struct {
+	cgroup, goMem, system uint64
+}
+
+func (f fakeMemoryProbe) CgroupLimit() (uint64, bool)  { return f.cgroup, f.cgroup != 0 }
+func (f fakeMemoryProbe) GoMemLimit() (uint64, bool)   { return f.goMem, f.goMem != 0 }
+func (f fakeMemoryProbe) SystemMemory() (uint64, bool) { return f.system, f.system != 0 }
+
+// ----------------------------------------------------------------------------
+//  AvailableMemoryWith()
+// ----------------------------------------------------------------------------
+
+func TestAvailableMemoryWith(t *testing.T) {
+	t.Parallel()
+
+	for _, test := range []struct {
+		name  string
+		probe fakeMemoryProbe
+		want  uint64
+	}{
+		{name: "pod on a large node", probe: fakeMemoryProbe{cgroup: 512 * mib, system: 64 * gib}, want: 512 * mib},
+		{name: "GOMEMLIMIT is the lowest", probe: fakeMemoryProbe{cgroup: gib, goMem: 256 * mib, system: 64 * gib}, want: 256 * mib},
+		{name: "no cgroup", probe: fakeMemoryProbe{system: 8 * gib}, want: 8 * gib},
+		{name: "cgroup only", probe: fakeMemoryProbe{cgroup: gib}, want: gib},
+	} {
+		available, err := argonize.AvailableMemoryWith(test.probe)
+
+		require.NoError(t, err, test.name)
+		require.Equal(t, test.want, available, test.name)
+	}
+}
+
+func TestAvailableMemoryWith_unknown(t *testing.T) {
+	t.Parallel()
+
+	_, err := argonize.AvailableMemoryWith(fakeMemoryProbe{})
+	require.ErrorIs(t, err, argonize.ErrMemoryUnknown)
+
+	_, err = argonize.AvailableMemoryWith(nil)
+	require.ErrorContains(t, err, "probe is nil")
+}
+
+// ----------------------------------------------------------------------------
+//  FSMemoryProbe
+// ----------------------------------------------------------------------------
+
+const fixtureMeminfo = "MemTotal:       67108864 kB\nMemFree:         1048576 kB\nMemAvailable:   33554432 kB\n"
+
+func TestFSMemoryProbe_CgroupLimit(t *testing.T) {
+	t.Parallel()
+
+	for _, test := range []struct {
+		files     fstest.MapFS
+		name      string
+		wantLimit uint64
+		wantOK    bool
+	}{
+		{
+			name: "cgroup v2 of the process",
+			files: fstest.MapFS{
+				"proc/self/cgroup":                        {Data: []byte("0::/kubepods/pod1\n")},
+				"sys/fs/cgroup/kubepods/pod1/memory.max":  {Data: []byte("536870912\n")},
+				"sys/fs/cgroup/memory.max":                {Data: []byte("max\n")},
+				"sys/fs/cgroup/kubepods/pod1/memory.high": {Data: []byte("max\n")},
+			},
+			wantLimit: 512 * mib, wantOK: true,
+		},
+		{
+			name: "cgroup v2 in a cgroup namespace",
+			files: fstest.MapFS{
+				"proc/self/cgroup":         {Data: []byte("0::/\n")},
+				"sys/fs/cgroup/memory.max": {Data: []byte("268435456\n")},
+			},
+			wantLimit: 256 * mib, wantOK: true,
+		},
+		{
+			name: "cgroup v2 unlimited",
+			files: fstest.MapFS{
+				"proc/self/cgroup":         {Data: []byte("0::/\n")},
+				"sys/fs/cgroup/memory.max": {Data: []byte("max\n")},
+			},
+		},
+		{
+			name: "cgroup v1 of the process",
+			files: fstest.MapFS{
+				"proc/self/cgroup": {Data: []byte(
+					"5:cpu,cpuacct:/docker/abc\n4:memory:/docker/abc\n1:name=systemd:/docker/abc\n")},
+				"sys/fs/cgroup/memory/docker/abc/memory.limit_in_bytes": {Data: []byte("1073741824\n")},
+			},
+			wantLimit: gib, wantOK: true,
+		},
+		{
+			name: "cgroup v1 unlimited",
+			files: fstest.MapFS{
+				"sys/fs/cgroup/memory/memory.limit_in_bytes": {Data: []byte("9223372036854771712\n")},
+			},
+		},
+		{
+			name:  "no cgroup such as macOS",
+			files: fstest.MapFS{},
+		},
+	} {
+		limit, ok := argonize.FSMemoryProbe{FS: test.files}.CgroupLimit()
+
+		require.Equal(t, test.wantOK, ok, test.name)
+		require.Equal(t, test.wantLimit, limit, test.name)
+	}
+}
+
+func TestFSMemoryProbe_SystemMemory(t *testing.T) {
+	t.Parallel()
+
+	probe := argonize.FSMemoryProbe{FS: fstest.MapFS{"proc/meminfo": {Data: []byte(fixtureMeminfo)}}}
+
+	total, ok := probe.SystemMemory()
+	require.True(t, ok)
+	require.Equal(t, 64*gib, total)
+
+	for _, meminfo := range []string{"", "MemTotal: lots kB\n", "MemTotal: 1024 MB\n"} {
+		probe := argonize.FSMemoryProbe{FS: fstest.MapFS{"proc/meminfo": {Data: []byte(meminfo)}}}
+
+		_, ok := probe.SystemMemory()
+		require.False(t, ok, "meminfo: %q", meminfo)
+	}
+
+	_, ok = argonize.FSMemoryProbe{FS: fstest.MapFS{}}.SystemMemory()
+	require.False(t, ok)
+}
+
+func TestFSMemoryProbe_pod_on_large_node(t *testing.T) {
+	t.Parallel()
+
+	probe := argonize.FSMemoryProbe{FS: fstest.MapFS{
+		"proc/self/cgroup":         {Data: []byte("0::/\n")},
+		"sys/fs/cgroup/memory.max": {Data: []byte("536870912\n")},
+		"proc/meminfo":             {Data: []byte(fixtureMeminfo)},
+	}}
+
+	available, err := argonize.AvailableMemoryWith(probe)
+	require.NoError(t, err)
+	require.LessOrEqual(t, available, 512*mib, "it should not pick the memory of the node")
+}
+
+func TestAvailableMemory(t *testing.T) {
+	t.Parallel()
+
+	// The result depends on the machine. It should not fail on Linux.
+	available, err := argonize.AvailableMemory()
+	if err != nil {
+		require.ErrorIs(t, err, argonize.ErrMemoryUnknown)
+
+		return
+	}
+
+	require.Positive(t, available)
+}
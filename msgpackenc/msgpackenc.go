@@ -0,0 +1,451 @@
+/*
+Package msgpackenc provides MessagePack encoding and decoding of argonize.Hashed.
+
+It is a separate package to keep the core argonize package free of any
+MessagePack specifics. It depends on no third-party MessagePack library: the
+layout is small and fixed, so it is encoded and decoded by hand.
+
+# Layout
+
+A Hashed object is encoded as a MessagePack map with the following string keys
+in this order. Integers use the smallest unsigned form and binaries use the
+"bin" family (use_bin_type=True in Python).
+
+	{
+	  "alg":  "argon2id",  // str, algorithm variant ("argon2id" or "argon2i")
+	  "v":    19,          // uint, Argon2 version
+	  "m":    65536,       // uint, memory cost in KiB
+	  "t":    3,           // uint, iterations
+	  "p":    2,           // uint, parallelism
+	  "salt": <bin>,       // bin, salt
+	  "hash": <bin>        // bin, hash
+	}
+
+Decoding accepts the keys in any order and any integer width, as encoders of
+other languages may choose differently. It rejects unknown, duplicate and
+missing keys, and validates the values the same way as argonize.DecodeHashStr().
+*/
+package msgpackenc
+
+import (
+	"encoding/binary"
+	"math"
+
+	"github.com/KEINOS/go-argonize"
+	"github.com/pkg/errors"
+	"golang.org/x/crypto/argon2"
+)
+
+// Map keys of the MessagePack layout.
+const (
+	KeyAlgorithm   = "alg"
+	KeyVersion     = "v"
+	KeyMemoryCost  = "m"
+	KeyIterations  = "t"
+	KeyParallelism = "p"
+	KeySalt        = "salt"
+	KeyHash        = "hash"
+)
+
+// numKeys is the number of entries in the map.
+const numKeys = 7
+
+// MessagePack format bytes used in the layout.
+const (
+	fmtFixMapMin = 0x80
+	fmtFixMapMax = 0x8f
+	fmtFixStrMin = 0xa0
+	fmtFixStrMax = 0xbf
+	fmtBin8      = 0xc4
+	fmtBin16     = 0xc5
+	fmtBin32     = 0xc6
+	fmtUint8     = 0xcc
+	fmtUint16    = 0xcd
+	fmtUint32    = 0xce
+	fmtUint64    = 0xcf
+	fmtInt8      = 0xd0
+	fmtInt16     = 0xd1
+	fmtInt32     = 0xd2
+	fmtInt64     = 0xd3
+	fmtStr8      = 0xd9
+	fmtStr16     = 0xda
+	fmtStr32     = 0xdb
+	fmtMap16     = 0xde
+	fmtMap32     = 0xdf
+	maxFixInt    = 0x7f
+	maxFixStr    = 31
+)
+
+// ============================================================================
+//  Functions
+// ============================================================================
+
+// Marshal returns the MessagePack encoding of the Hashed object.
+func Marshal(hashed *argonize.Hashed) ([]byte, error) {
+	if hashed == nil || hashed.Params == nil {
+		return nil, errors.New("failed to msgpack encode the hash: params are nil")
+	}
+
+	if len(hashed.Hash) == 0 {
+		return nil, errors.New("failed to msgpack encode the hash: hash value is empty")
+	}
+
+	if hashed.KeyID != "" {
+		return nil, errors.New("failed to msgpack encode the hash: key ID is not supported")
+	}
+
+	if hashed.Data != "" {
+		return nil, errors.New("failed to msgpack encode the hash: data is not supported")
+	}
+
+	out := []byte{fmtFixMapMin | numKeys}
+
+	out = appendStr(out, KeyAlgorithm)
+	out = appendStr(out, hashed.Params.Variant.String())
+	out = appendStr(out, KeyVersion)
+	out = appendUint(out, argon2.Version)
+	out = appendStr(out, KeyMemoryCost)
+	out = appendUint(out, uint64(hashed.Params.MemoryCost))
+	out = appendStr(out, KeyIterations)
+	out = appendUint(out, uint64(hashed.Params.Iterations))
+	out = appendStr(out, KeyParallelism)
+	out = appendUint(out, uint64(hashed.Params.Parallelism))
+	out = appendStr(out, KeySalt)
+	out = appendBin(out, hashed.Salt)
+	out = appendStr(out, KeyHash)
+	out = appendBin(out, hashed.Hash)
+
+	return out, nil
+}
+
+// Unmarshal decodes the MessagePack encoded data into a Hashed object. The data
+// may come from Marshal() or any other MessagePack encoder following the layout.
+func Unmarshal(data []byte) (*argonize.Hashed, error) {
+	dec := decoder{data: data}
+
+	hashed, err := dec.decodeHashed()
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to msgpack decode the hash")
+	}
+
+	return hashed, nil
+}
+
+// ============================================================================
+//  Private
+// ============================================================================
+
+// appendStr appends s as a MessagePack str. Only the short keys and the
+// algorithm name are written, so fixstr is enough.
+func appendStr(out []byte, s string) []byte {
+	out = append(out, fmtFixStrMin|byte(len(s)))
+
+	return append(out, s...)
+}
+
+// appendUint appends v as a MessagePack uint in its smallest form.
+func appendUint(out []byte, v uint64) []byte {
+	switch {
+	case v <= maxFixInt:
+		return append(out, byte(v))
+	case v <= math.MaxUint8:
+		return append(out, fmtUint8, byte(v))
+	case v <= math.MaxUint16:
+		return binary.BigEndian.AppendUint16(append(out, fmtUint16), uint16(v))
+	case v <= math.MaxUint32:
+		return binary.BigEndian.AppendUint32(append(out, fmtUint32), uint32(v))
+	default:
+		return binary.BigEndian.AppendUint64(append(out, fmtUint64), v)
+	}
+}
+
+// appendBin appends b as a MessagePack bin in its smallest form.
+func appendBin(out []byte, b []byte) []byte {
+	switch lenBin := len(b); {
+	case lenBin <= math.MaxUint8:
+		out = append(out, fmtBin8, byte(lenBin))
+	case lenBin <= math.MaxUint16:
+		out = binary.BigEndian.AppendUint16(append(out, fmtBin16), uint16(lenBin))
+	default:
+		out = binary.BigEndian.AppendUint32(append(out, fmtBin32), uint32(lenBin)) //nolint:gosec // hash is never that long
+	}
+
+	return append(out, b...)
+}
+
+// decoder reads the MessagePack items of the layout from data.
+type decoder struct {
+	data []byte
+	pos  int
+}
+
+// decodeHashed reads the whole map and returns the validated Hashed object.
+func (d *decoder) decodeHashed() (*argonize.Hashed, error) {
+	lenMap, err := d.readMapLen()
+	if err != nil {
+		return nil, err
+	}
+
+	if lenMap != numKeys {
+		return nil, errors.Errorf("map has %d entries, want %d", lenMap, numKeys)
+	}
+
+	var (
+		seen    = make(map[string]bool, numKeys)
+		values  = make(map[string]uint64, numKeys)
+		salt    []byte
+		hash    []byte
+		algName []byte
+	)
+
+	for range numKeys {
+		key, err := d.readStr()
+		if err != nil {
+			return nil, errors.Wrap(err, "bad map key")
+		}
+
+		if seen[string(key)] {
+			return nil, errors.Errorf("duplicate map key %q", key)
+		}
+
+		seen[string(key)] = true
+
+		switch string(key) {
+		case KeyAlgorithm:
+			algName, err = d.readStr()
+		case KeySalt:
+			salt, err = d.readBin()
+		case KeyHash:
+			hash, err = d.readBin()
+		case KeyVersion, KeyMemoryCost, KeyIterations, KeyParallelism:
+			values[string(key)], err = d.readUint()
+		default:
+			return nil, errors.Errorf("unknown map key %q", key)
+		}
+
+		if err != nil {
+			return nil, errors.Wrapf(err, "bad value of map key %q", key)
+		}
+	}
+
+	if d.pos != len(d.data) {
+		return nil, errors.New("trailing data after the map")
+	}
+
+	variant, err := argonize.ParseVariant(string(algName))
+	if err != nil {
+		return nil, err
+	}
+
+	return newHashed(variant, values, salt, hash)
+}
+
+// readByte reads the next byte.
+func (d *decoder) readByte() (byte, error) {
+	if d.pos >= len(d.data) {
+		return 0, errors.New("unexpected end of data")
+	}
+
+	b := d.data[d.pos]
+	d.pos++
+
+	return b, nil
+}
+
+// readN reads the next n bytes.
+func (d *decoder) readN(n uint64) ([]byte, error) {
+	if uint64(len(d.data)-d.pos) < n {
+		return nil, errors.New("unexpected end of data")
+	}
+
+	out := d.data[d.pos : d.pos+int(n)]
+	d.pos += int(n)
+
+	return out, nil
+}
+
+// readBE reads a big-endian unsigned integer of n bytes.
+func (d *decoder) readBE(n uint64) (uint64, error) {
+	raw, err := d.readN(n)
+	if err != nil {
+		return 0, err
+	}
+
+	var v uint64
+
+	for _, b := range raw {
+		v = v<<8 | uint64(b)
+	}
+
+	return v, nil
+}
+
+// readMapLen reads the header of a map and returns the number of entries.
+func (d *decoder) readMapLen() (uint64, error) {
+	format, err := d.readByte()
+	if err != nil {
+		return 0, err
+	}
+
+	switch {
+	case format >= fmtFixMapMin && format <= fmtFixMapMax:
+		return uint64(format & 0x0f), nil
+	case format == fmtMap16:
+		return d.readBE(2)
+	case format == fmtMap32:
+		return d.readBE(4)
+	default:
+		return 0, errors.Errorf("unexpected format 0x%02x, want map", format)
+	}
+}
+
+// readStr reads a str and returns a copy of its bytes.
+func (d *decoder) readStr() ([]byte, error) {
+	format, err := d.readByte()
+	if err != nil {
+		return nil, err
+	}
+
+	var lenStr uint64
+
+	switch {
+	case format >= fmtFixStrMin && format <= fmtFixStrMax:
+		lenStr = uint64(format & maxFixStr)
+	case format == fmtStr8:
+		lenStr, err = d.readBE(1)
+	case format == fmtStr16:
+		lenStr, err = d.readBE(2)
+	case format == fmtStr32:
+		lenStr, err = d.readBE(4)
+	default:
+		return nil, errors.Errorf("unexpected format 0x%02x, want str", format)
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	raw, err := d.readN(lenStr)
+
+	return append([]byte{}, raw...), err
+}
+
+// readBin reads a bin and returns a copy of its bytes.
+func (d *decoder) readBin() ([]byte, error) {
+	format, err := d.readByte()
+	if err != nil {
+		return nil, err
+	}
+
+	var lenBin uint64
+
+	switch format {
+	case fmtBin8:
+		lenBin, err = d.readBE(1)
+	case fmtBin16:
+		lenBin, err = d.readBE(2)
+	case fmtBin32:
+		lenBin, err = d.readBE(4)
+	default:
+		return nil, errors.Errorf("unexpected format 0x%02x, want bin", format)
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	raw, err := d.readN(lenBin)
+	if err != nil {
+		return nil, err
+	}
+
+	return append([]byte{}, raw...), nil
+}
+
+// readUint reads a non-negative integer of any width.
+func (d *decoder) readUint() (uint64, error) {
+	format, err := d.readByte()
+	if err != nil {
+		return 0, err
+	}
+
+	var (
+		value  uint64
+		signed bool
+	)
+
+	switch format {
+	case fmtUint8, fmtInt8:
+		value, err = d.readBE(1)
+		signed = format == fmtInt8 && value > math.MaxInt8
+	case fmtUint16, fmtInt16:
+		value, err = d.readBE(2)
+		signed = format == fmtInt16 && value > math.MaxInt16
+	case fmtUint32, fmtInt32:
+		value, err = d.readBE(4)
+		signed = format == fmtInt32 && value > math.MaxInt32
+	case fmtUint64, fmtInt64:
+		value, err = d.readBE(8)
+		signed = format == fmtInt64 && value > math.MaxInt64
+	default:
+		if format > maxFixInt {
+			return 0, errors.Errorf("unexpected format 0x%02x, want uint", format)
+		}
+
+		value = uint64(format)
+	}
+
+	if err != nil {
+		return 0, err
+	}
+
+	if signed {
+		return 0, errors.New("negative integer")
+	}
+
+	return value, nil
+}
+
+// newHashed validates the decoded values and returns the Hashed object.
+func newHashed(
+	variant argonize.Variant, values map[string]uint64, salt, hash []byte,
+) (*argonize.Hashed, error) {
+	if values[KeyVersion] != argon2.Version {
+		return nil, errors.New("incompatible version of Argon2")
+	}
+
+	if values[KeyMemoryCost] > math.MaxUint32 || values[KeyIterations] > math.MaxUint32 ||
+		values[KeyParallelism] > math.MaxUint8 {
+		return nil, errors.New("parameter value overflows")
+	}
+
+	if values[KeyMemoryCost] == 0 || values[KeyIterations] == 0 || values[KeyParallelism] == 0 {
+		return nil, errors.New("missing parameters in the hash")
+	}
+
+	if len(salt) < int(argonize.SaltLengthMin) || len(hash) == 0 ||
+		len(salt) > math.MaxInt32 || len(hash) > math.MaxInt32 {
+		return nil, errors.New("hash or salt length is too long or too short")
+	}
+
+	params := argonize.NewParams()
+
+	params.Variant = variant
+	params.MemoryCost = uint32(values[KeyMemoryCost])
+	params.Iterations = uint32(values[KeyIterations])
+	params.Parallelism = uint8(values[KeyParallelism])
+	params.SaltLength = uint32(len(salt))
+	params.KeyLength = uint32(len(hash))
+
+	hashed := &argonize.Hashed{
+		Params: params,
+		Salt:   argonize.Salt(salt),
+		Hash:   hash,
+	}
+
+	if err := hashed.Validate(); err != nil {
+		return nil, err
+	}
+
+	return hashed, nil
+}
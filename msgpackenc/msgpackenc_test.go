@@ -0,0 +1,142 @@
+package msgpackenc_test
+
+import (
+	"encoding/hex"
+	"strings"
+	"testing"
+
+	"github.com/KEINOS/go-argonize"
+	"github.com/KEINOS/go-argonize/msgpackenc"
+	"github.com/stretchr/testify/require"
+)
+
+const sampleHashStr = "$argon2id$v=19$m=65536,t=3,p=2$Woo1mErn1s7AHf96ewQ8Uw$D4TzIwGO4XD2buk96qAP+Ed2baMo/KbTRMqXX00wtsU"
+
+// fixturePython is the expected output of _tests/msgpack_fixture.py (Python's
+// msgpack with use_bin_type=True) for sampleHashStr.
+//
+//nolint:lll // long hex string
+const fixturePython = "87a3616c67a86172676f6e326964a17613a16dce00010000a17403a17002a473616c74c4105a8a35984ae7d6cec01dff7a7b043c53a468617368c4200f84f323018ee170f66ee93deaa00ff847766da328fca6d344ca975f4d30b6c5"
+
+// fixtureWide is the same object with the keys in reverse order, map16, str8,
+// bin16 and signed or wider integer formats, as other encoders may produce.
+//
+//nolint:lll // long hex string
+const fixtureWide = "de0007d90468617368c500200f84f323018ee170f66ee93deaa00ff847766da328fca6d344ca975f4d30b6c5a473616c74c4105a8a35984ae7d6cec01dff7a7b043c53a170d002a174cf0000000000000003a16dd200010000a176cc13a3616c67d9086172676f6e326964"
+
+// ----------------------------------------------------------------------------
+//  Marshal()
+// ----------------------------------------------------------------------------
+
+func TestMarshal(t *testing.T) {
+	t.Parallel()
+
+	hashedObj, err := argonize.DecodeHashStr(sampleHashStr)
+	require.NoError(t, err)
+
+	encoded, err := msgpackenc.Marshal(hashedObj)
+	require.NoError(t, err)
+	require.Equal(t, fixturePython, hex.EncodeToString(encoded),
+		"it should produce the same bytes as the Python msgpack library")
+}
+
+func TestMarshal_empty(t *testing.T) {
+	t.Parallel()
+
+	encoded, err := msgpackenc.Marshal(nil)
+
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "params are nil")
+	require.Nil(t, encoded)
+
+	encoded, err = msgpackenc.Marshal(&argonize.Hashed{Params: argonize.NewParams()})
+
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "hash value is empty")
+	require.Nil(t, encoded)
+
+	encoded, err = msgpackenc.Marshal(&argonize.Hashed{Params: argonize.NewParams(), Hash: []byte("hash"), KeyID: "v1"})
+
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "key ID is not supported")
+	require.Nil(t, encoded)
+}
+
+// ----------------------------------------------------------------------------
+//  Unmarshal()
+// ----------------------------------------------------------------------------
+
+func TestUnmarshal_fixtures(t *testing.T) {
+	t.Parallel()
+
+	for _, fixture := range []string{fixturePython, fixtureWide} {
+		data, err := hex.DecodeString(fixture)
+		require.NoError(t, err)
+
+		hashedObj, err := msgpackenc.Unmarshal(data)
+		require.NoError(t, err)
+		require.Equal(t, sampleHashStr, hashedObj.String())
+	}
+}
+
+func TestUnmarshal_round_trip(t *testing.T) {
+	t.Parallel()
+
+	params := argonize.NewParams()
+	params.MemoryCost = 8
+	params.Parallelism = 1
+
+	hashedObj := argonize.HashCustom([]byte("password"), nil, params)
+
+	encoded, err := msgpackenc.Marshal(hashedObj)
+	require.NoError(t, err)
+
+	decoded, err := msgpackenc.Unmarshal(encoded)
+	require.NoError(t, err)
+
+	require.True(t, decoded.IsValidPassword([]byte("password")))
+	require.False(t, decoded.IsValidPassword([]byte("wrong password")))
+}
+
+func TestUnmarshal_bad_data(t *testing.T) {
+	t.Parallel()
+
+	for _, test := range []struct {
+		hexData    string
+		msgContain string
+	}{
+		{"", "unexpected end of data"},
+		{"86", "map has 6 entries, want 7"},
+		{"90", "unexpected format 0x90, want map"},
+		{"de00", "unexpected end of data"},
+		{"87" + "01", "bad map key: unexpected format 0x01, want str"},
+		{"87" + "a3616c67" + "c0", `bad value of map key "alg": unexpected format 0xc0, want str`},
+		{"87" + "a178", `unknown map key "x"`},
+		{"87" + "a17613" + "a17613", `duplicate map key "v"`},
+		{"87" + "a176d0ff", "negative integer"},
+		{"87" + "a176c0", "unexpected format 0xc0, want uint"},
+		{"87" + "a473616c74" + "a3616263", "want bin"},
+		{"87" + "a473616c74" + "c410", "unexpected end of data"},
+		{fixturePython + "00", "trailing data after the map"},
+		// version 18
+		{strings.Replace(fixturePython, "a17613", "a17612", 1), "incompatible version of Argon2"},
+		// memory cost 0
+		{strings.Replace(fixturePython, "ce00010000", "ce00000000", 1), "missing parameters in the hash"},
+		// parallelism 256
+		{strings.Replace(fixturePython, "a17002", "a170cd0100", 1), "parameter value overflows"},
+		// algorithm "argon2xx"
+		{strings.Replace(fixturePython, "6964", "7878", 1), `unsupported algorithm variant "argon2xx"`},
+		// salt of 4 bytes
+		{strings.Replace(fixturePython, "c4105a8a35984ae7d6cec01dff7a7b043c53", "c4045a8a3598", 1), "too long or too short"},
+	} {
+		data, err := hex.DecodeString(test.hexData)
+		require.NoError(t, err, test.hexData)
+
+		hashedObj, err := msgpackenc.Unmarshal(data)
+
+		require.Error(t, err, test.hexData)
+		require.Contains(t, err.Error(), "failed to msgpack decode the hash")
+		require.Contains(t, err.Error(), test.msgContain, test.hexData)
+		require.Nil(t, hashedObj, "it should be nil on error")
+	}
+}
@@ -0,0 +1,69 @@
+package argonize
+
+import (
+	"bytes"
+	"crypto/sha256"
+
+	"github.com/pkg/errors"
+)
+
+// NamespaceTagLength is the length of the tenant tag at the beginning of the
+// salts of NewNamespacedSalt().
+const NamespaceTagLength = uint32(8)
+
+// ----------------------------------------------------------------------------
+//  Constructor of Salt
+// ----------------------------------------------------------------------------
+
+// NewNamespacedSalt returns a new Salt of a fixed tag of the tenant followed by
+// randomLen random bytes, so that the salts of different tenants never collide
+// even if the random parts do.
+//
+// The tag is the first NamespaceTagLength bytes of the SHA-256 of tenantID. The
+// total length is thus NamespaceTagLength + randomLen, which must be set as
+// Params.SaltLength when hashing with the salt. The verification needs nothing
+// special since the whole salt is stored in the hash.
+//
+// It returns an error if tenantID is empty or randomLen is shorter than
+// SaltLengthMin.
+func NewNamespacedSalt(tenantID []byte, randomLen uint32) (Salt, error) {
+	if len(tenantID) == 0 {
+		return nil, errors.New("failed to generate namespaced salt: tenant ID is empty")
+	}
+
+	random, err := newSaltFrom(currentRandomness(), randomLen)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to generate namespaced salt")
+	}
+
+	salt := make(Salt, 0, NamespaceTagLength+randomLen)
+	salt = append(salt, namespaceTag(tenantID)...)
+	salt = append(salt, random...)
+
+	return salt, nil
+}
+
+// ----------------------------------------------------------------------------
+//  Methods of Salt
+// ----------------------------------------------------------------------------
+
+// HasNamespace returns true if the salt begins with the tag of the tenant, that
+// is, it was made by NewNamespacedSalt() with the tenantID.
+func (s Salt) HasNamespace(tenantID []byte) bool {
+	if len(tenantID) == 0 || uint64(len(s)) < uint64(NamespaceTagLength)+uint64(SaltLengthMin) {
+		return false
+	}
+
+	return bytes.Equal(s[:NamespaceTagLength], namespaceTag(tenantID))
+}
+
+// ----------------------------------------------------------------------------
+//  Private Functions
+// ----------------------------------------------------------------------------
+
+// namespaceTag returns the tag of the tenant.
+func namespaceTag(tenantID []byte) []byte {
+	sum := sha256.Sum256(tenantID)
+
+	return sum[:NamespaceTagLength]
+}
@@ -0,0 +1,68 @@
+package argonize_test
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/KEINOS/go-argonize"
+	"github.com/stretchr/testify/require"
+)
+
+// ----------------------------------------------------------------------------
+//  NewNamespacedSalt()
+// ----------------------------------------------------------------------------
+
+func TestNewNamespacedSalt(t *testing.T) {
+	t.Parallel()
+
+	saltA1, err := argonize.NewNamespacedSalt([]byte("tenant-a"), 16)
+	require.NoError(t, err)
+	require.Len(t, saltA1, int(argonize.NamespaceTagLength)+16)
+
+	saltA2, err := argonize.NewNamespacedSalt([]byte("tenant-a"), 16)
+	require.NoError(t, err)
+
+	saltB, err := argonize.NewNamespacedSalt([]byte("tenant-b"), 16)
+	require.NoError(t, err)
+
+	tagLen := argonize.NamespaceTagLength
+
+	require.Equal(t, saltA1[:tagLen], saltA2[:tagLen], "the tag should be fixed per tenant")
+	require.NotEqual(t, saltA1[tagLen:], saltA2[tagLen:], "the rest should be random")
+	require.NotEqual(t, saltA1[:tagLen], saltB[:tagLen], "the tags of tenants should differ")
+
+	require.True(t, saltA1.HasNamespace([]byte("tenant-a")))
+	require.False(t, saltA1.HasNamespace([]byte("tenant-b")))
+	require.False(t, saltA1.HasNamespace(nil))
+	require.False(t, argonize.Salt(bytes.Repeat([]byte{1}, 8)).HasNamespace([]byte("tenant-a")))
+}
+
+func TestNewNamespacedSalt_hash_and_verify(t *testing.T) {
+	t.Parallel()
+
+	salt, err := argonize.NewNamespacedSalt([]byte("tenant-a"), 16)
+	require.NoError(t, err)
+
+	params := lowCostParams()
+	params.SaltLength = argonize.NamespaceTagLength + 16
+
+	hashedObj, err := argonize.HashCustomChecked([]byte("password"), salt, params)
+	require.NoError(t, err)
+
+	decoded, err := argonize.DecodeHashStr(hashedObj.String())
+	require.NoError(t, err)
+	require.True(t, decoded.IsValidPassword([]byte("password")))
+	require.True(t, decoded.Salt.HasNamespace([]byte("tenant-a")))
+}
+
+func TestNewNamespacedSalt_invalid(t *testing.T) {
+	t.Parallel()
+
+	salt, err := argonize.NewNamespacedSalt(nil, 16)
+	require.EqualError(t, err, "failed to generate namespaced salt: tenant ID is empty")
+	require.Nil(t, salt)
+
+	salt, err = argonize.NewNamespacedSalt([]byte("tenant-a"), 4)
+	require.ErrorContains(t, err, "length 4 is shorter than the minimum 8")
+	require.Nil(t, salt)
+}
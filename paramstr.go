@@ -0,0 +1,176 @@
+package argonize
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+// ============================================================================
+//  Parameter String
+// ============================================================================
+//
+// The parameter string is the compact "key=value" syntax used in the PHC
+// string, log lines and CLI flags. E.g. "m=65536,t=3,p=4,l=32,s=16".
+//
+//	m: memory cost in KiB
+//	t: iterations
+//	p: parallelism
+//	l: key length in bytes (not in the PHC string)
+//	s: salt length in bytes (not in the PHC string)
+
+// ----------------------------------------------------------------------------
+//  Constructor of Params
+// ----------------------------------------------------------------------------
+
+// ParseParamString parses the parameter string such as "m=65536,t=3,p=4" into
+// a Params object. The keys may be in any order and the missing ones are set
+// to the default values.
+//
+// It returns an error on unknown or duplicate keys, on values overflowing the
+// field and if the result does not pass Params.Validate().
+func ParseParamString(paramStr string) (*Params, error) {
+	params, _, err := parseParamString(paramStr, true)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to parse the param string")
+	}
+
+	if err := params.Validate(); err != nil {
+		return nil, errors.Wrap(err, "failed to parse the param string")
+	}
+
+	return params, nil
+}
+
+// DecodeParams parses the parameter block of the PHC string such as
+// "m=65536,t=3,p=4" into a Params object. It is the counterpart of
+// Params.EncodeParams().
+//
+// Unlike ParseParamString(), all of "m", "t" and "p" are required and the key
+// and salt lengths are not accepted, as the PHC string does not have them. They
+// are set to the default values. The keys may be in any order. It returns an
+// error if the result does not pass Params.Validate().
+func DecodeParams(paramStr string) (*Params, error) {
+	params, seen, err := parseParamString(paramStr, false)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to decode the params")
+	}
+
+	for _, key := range []string{"m", "t", "p"} {
+		if !seen[key] {
+			return nil, errors.Errorf("failed to decode the params: missing parameter %q", key)
+		}
+	}
+
+	if err := params.Validate(); err != nil {
+		return nil, errors.Wrap(err, "failed to decode the params")
+	}
+
+	return params, nil
+}
+
+// ----------------------------------------------------------------------------
+//  Methods of Params
+// ----------------------------------------------------------------------------
+
+// EncodeParams returns the parameter block of the PHC string. E.g.
+// "m=65536,t=3,p=2". Use DecodeParams() to decode it back.
+//
+// The key and salt lengths are not included, as the PHC string derives them
+// from the salt and hash. Use String() to include them.
+func (p *Params) EncodeParams() string {
+	return string(p.appendParamString(nil, false))
+}
+
+// String returns the parameter string of the parameters including the key and
+// salt lengths. E.g. "m=65536,t=1,p=2,l=32,s=16". Use ParseParamString() to
+// parse it back.
+func (p *Params) String() string {
+	return string(p.appendParamString(nil, true))
+}
+
+// appendParamString appends the parameter string to b. The key and salt lengths
+// are appended only if withLengths is true, as the PHC string does not have
+// them. It is the only formatter of the parameter string.
+func (p *Params) appendParamString(b []byte, withLengths bool) []byte {
+	b = append(b, "m="...)
+	b = strconv.AppendUint(b, uint64(p.MemoryCost), 10)
+	b = append(b, ",t="...)
+	b = strconv.AppendUint(b, uint64(p.Iterations), 10)
+	b = append(b, ",p="...)
+	b = strconv.AppendUint(b, uint64(p.Parallelism), 10)
+
+	if withLengths {
+		b = append(b, ",l="...)
+		b = strconv.AppendUint(b, uint64(p.KeyLength), 10)
+		b = append(b, ",s="...)
+		b = strconv.AppendUint(b, uint64(p.SaltLength), 10)
+	}
+
+	return b
+}
+
+// ----------------------------------------------------------------------------
+//  Private Functions
+// ----------------------------------------------------------------------------
+
+// parseParamString parses the parameter string into a Params object with the
+// default values for the missing keys. It returns the keys found as well. The
+// key and salt lengths are accepted only if withLengths is true. It is the only
+// parser of the parameter string.
+func parseParamString(paramStr string, withLengths bool) (*Params, map[string]bool, error) {
+	params := NewParams()
+	seen := make(map[string]bool)
+
+	if paramStr == "" {
+		return params, seen, nil
+	}
+
+	for _, item := range strings.Split(paramStr, ",") {
+		key, value, found := strings.Cut(item, "=")
+		if !found {
+			return nil, nil, errors.Errorf("malformed parameter %q", item)
+		}
+
+		if seen[key] {
+			return nil, nil, errors.Errorf("duplicate parameter %q", key)
+		}
+
+		seen[key] = true
+
+		var err error
+
+		switch {
+		case key == "m":
+			params.MemoryCost, err = parseUint[uint32](value)
+		case key == "t":
+			params.Iterations, err = parseUint[uint32](value)
+		case key == "p":
+			params.Parallelism, err = parseUint[uint8](value)
+		case key == "l" && withLengths:
+			params.KeyLength, err = parseUint[uint32](value)
+		case key == "s" && withLengths:
+			params.SaltLength, err = parseUint[uint32](value)
+		default:
+			return nil, nil, errors.Errorf("unknown parameter %q", key)
+		}
+
+		if err != nil {
+			return nil, nil, errors.Wrapf(err, "bad value of parameter %q", key)
+		}
+	}
+
+	return params, seen, nil
+}
+
+// parseUint parses the decimal string as an unsigned integer of type T. Signs,
+// spaces and values overflowing T are rejected.
+func parseUint[T uint8 | uint32](value string) (T, error) {
+	num, err := strconv.ParseUint(value, 10, 64)
+	if err != nil || num > uint64(^T(0)) {
+		return 0, errors.Errorf("invalid or overflowing number %q", value)
+	}
+
+	return T(num), nil
+}
@@ -0,0 +1,157 @@
+package argonize_test
+
+import (
+	"testing"
+
+	"github.com/KEINOS/go-argonize"
+	"github.com/stretchr/testify/require"
+)
+
+// ----------------------------------------------------------------------------
+//  ParseParamString()
+// ----------------------------------------------------------------------------
+
+func TestParseParamString(t *testing.T) {
+	t.Parallel()
+
+	for input, expect := range map[string]argonize.Params{
+		"m=65536,t=3,p=4,l=32,s=16": {MemoryCost: 65536, Iterations: 3, Parallelism: 4, KeyLength: 32, SaltLength: 16},
+		"p=4,t=3,m=65536":           {MemoryCost: 65536, Iterations: 3, Parallelism: 4, KeyLength: 32, SaltLength: 16},
+		"t=2":                       {MemoryCost: 65536, Iterations: 2, Parallelism: 2, KeyLength: 32, SaltLength: 16},
+		"s=32,l=64":                 {MemoryCost: 65536, Iterations: 1, Parallelism: 2, KeyLength: 64, SaltLength: 32},
+		"":                          *argonize.NewParams(),
+	} {
+		params, err := argonize.ParseParamString(input)
+
+		require.NoError(t, err, input)
+		require.Equal(t, &expect, params, input)
+	}
+}
+
+func TestParseParamString_invalid(t *testing.T) {
+	t.Parallel()
+
+	for input, msgContain := range map[string]string{
+		"m=65536,x=1":   `unknown parameter "x"`,
+		"m=65536,m=8":   `duplicate parameter "m"`,
+		"m=65536,":      `malformed parameter ""`,
+		"m":             `malformed parameter "m"`,
+		"m=4294967296":  `bad value of parameter "m": invalid or overflowing number "4294967296"`,
+		"p=256":         `bad value of parameter "p": invalid or overflowing number "256"`,
+		"t=-1":          `invalid or overflowing number "-1"`,
+		"t= 1":          `invalid or overflowing number " 1"`,
+		"t=0":           "iterations must be at least 1",
+		"m=8,p=2":       "memory cost must be at least 8 KiB per lane",
+		"M=65536":       `unknown parameter "M"`,
+		"m=65536;t=1":   `bad value of parameter "m"`,
+		"m=65536,,t=1,": `malformed parameter ""`,
+	} {
+		params, err := argonize.ParseParamString(input)
+
+		require.Error(t, err, input)
+		require.Contains(t, err.Error(), "failed to parse the param string", input)
+		require.Contains(t, err.Error(), msgContain, input)
+		require.Nil(t, params, "it should be nil on error")
+	}
+}
+
+// ----------------------------------------------------------------------------
+//  DecodeParams() / Params.EncodeParams()
+// ----------------------------------------------------------------------------
+
+func TestDecodeParams(t *testing.T) {
+	t.Parallel()
+
+	for input, expect := range map[string]argonize.Params{
+		"m=65536,t=3,p=4": {MemoryCost: 65536, Iterations: 3, Parallelism: 4, KeyLength: 32, SaltLength: 16},
+		"p=4,t=3,m=65536": {MemoryCost: 65536, Iterations: 3, Parallelism: 4, KeyLength: 32, SaltLength: 16},
+		"t=1,m=8,p=1":     {MemoryCost: 8, Iterations: 1, Parallelism: 1, KeyLength: 32, SaltLength: 16},
+	} {
+		params, err := argonize.DecodeParams(input)
+
+		require.NoError(t, err, input)
+		require.Equal(t, &expect, params, input)
+	}
+}
+
+func TestDecodeParams_invalid(t *testing.T) {
+	t.Parallel()
+
+	for input, msgContain := range map[string]string{
+		"":                         `missing parameter "m"`,
+		"m=65536,t=3":              `missing parameter "p"`,
+		"m=65536,p=4":              `missing parameter "t"`,
+		"m=65536,t=3,p=4,l=32":     `unknown parameter "l"`,
+		"m=65536,t=3,p=4,s=16":     `unknown parameter "s"`,
+		"m=65536,t=3,p=4,keyid=YQ": `unknown parameter "keyid"`,
+		"m=65536,t=3,p=4,p=4":      `duplicate parameter "p"`,
+		"m=65536,t=0,p=4":          "iterations must be at least 1",
+		"m=8,t=1,p=2":              "memory cost must be at least 8 KiB per lane",
+		"m=65536,t=3,p=256":        `bad value of parameter "p"`,
+	} {
+		params, err := argonize.DecodeParams(input)
+
+		require.Error(t, err, input)
+		require.Contains(t, err.Error(), "failed to decode the params", input)
+		require.Contains(t, err.Error(), msgContain, input)
+		require.Nil(t, params, "it should be nil on error")
+	}
+}
+
+func TestParams_EncodeParams_round_trip(t *testing.T) {
+	t.Parallel()
+
+	params := argonize.PresetRFC9106Second.Params()
+
+	require.Equal(t, "m=65536,t=3,p=4", params.EncodeParams())
+
+	decoded, err := argonize.DecodeParams(params.EncodeParams())
+
+	require.NoError(t, err)
+	require.Equal(t, params, decoded)
+
+	// It should be the parameter block of the PHC string
+	hashedObj, err := argonize.DecodeHashStr(sampleHashStr)
+	require.NoError(t, err)
+	require.Contains(t, sampleHashStr, "$"+hashedObj.Params.EncodeParams()+"$")
+}
+
+// ----------------------------------------------------------------------------
+//  Params.String()
+// ----------------------------------------------------------------------------
+
+func TestParams_String_round_trip(t *testing.T) {
+	t.Parallel()
+
+	params := argonize.PresetRFC9106Second.Params()
+
+	require.Equal(t, "m=65536,t=3,p=4,l=32,s=16", params.String())
+
+	parsed, err := argonize.ParseParamString(params.String())
+
+	require.NoError(t, err)
+	require.Equal(t, params, parsed)
+}
+
+// The PHC string must not accept the key and salt lengths in its parameter
+// segment, but does accept the parameters in any order.
+func TestDecodeHashStr_param_segment(t *testing.T) {
+	t.Parallel()
+
+	hashedObj, err := argonize.DecodeHashStr(
+		"$argon2id$v=19$p=2,t=3,m=65536$Woo1mErn1s7AHf96ewQ8Uw$D4TzIwGO4XD2buk96qAP+Ed2baMo/KbTRMqXX00wtsU",
+	)
+	require.NoError(t, err)
+	require.Equal(t,
+		"$argon2id$v=19$m=65536,t=3,p=2$Woo1mErn1s7AHf96ewQ8Uw$D4TzIwGO4XD2buk96qAP+Ed2baMo/KbTRMqXX00wtsU",
+		hashedObj.String(), "it should be re-encoded in the canonical order")
+
+	for _, paramSeg := range []string{"m=65536,t=3,p=2,l=32", "m=65536,t=3", "m=65536,t=3,p=2,"} {
+		_, err := argonize.DecodeHashStr(
+			"$argon2id$v=19$" + paramSeg + "$Woo1mErn1s7AHf96ewQ8Uw$D4TzIwGO4XD2buk96qAP+Ed2baMo/KbTRMqXX00wtsU",
+		)
+
+		require.Error(t, err, paramSeg)
+		require.Contains(t, err.Error(), "missing parameters in the hash", paramSeg)
+	}
+}
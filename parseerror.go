@@ -0,0 +1,158 @@
+package argonize
+
+import (
+	"github.com/pkg/errors"
+)
+
+// ============================================================================
+//  Public Variables
+// ============================================================================
+
+// Sentinel errors wrapped by ParseError. Check them with errors.Is().
+//
+//nolint:gochecknoglobals // sentinel errors
+var (
+	// ErrInvalidFormat is the error of a hash string that is not made of the
+	// "$"-delimited segments of the PHC string format.
+	ErrInvalidFormat = errors.New("invalid hash format")
+	// ErrUnsupportedVariant is the error of an unknown algorithm variant.
+	ErrUnsupportedVariant = errors.New("unsupported algorithm variant")
+	// ErrInvalidVersion is the error of a malformed version segment.
+	ErrInvalidVersion = errors.New("failed to parse the version")
+	// ErrIncompatibleVersion is the error of a version other than argon2.Version.
+	ErrIncompatibleVersion = errors.New("incompatible version of Argon2")
+	// ErrMissingParams is the error of a missing or malformed parameter segment.
+	ErrMissingParams = errors.New("missing parameters in the hash")
+	// ErrInvalidSalt is the error of a salt segment that is not valid base64.
+	ErrInvalidSalt = errors.New("failed to decode salt value")
+	// ErrInvalidHashValue is the error of a hash segment that is not valid base64.
+	ErrInvalidHashValue = errors.New("failed to decode hash value")
+	// ErrInvalidLength is the error of a salt or hash out of the length range.
+	ErrInvalidLength = errors.New("hash or salt length is too long or too short")
+)
+
+// ============================================================================
+//  Type: Segment
+// ============================================================================
+
+// Segment identifies a "$"-delimited segment of the hash string. Its value is
+// the index of the segment.
+//
+//	$argon2id$v=19$m=65536,t=3,p=2$<salt>$<hash>
+//	0    1      2        3           4      5
+type Segment int
+
+const (
+	// SegmentWhole means that the error is not of a single segment, such as a
+	// wrong number of segments or a too long input.
+	SegmentWhole Segment = iota - 1
+	// SegmentPrefix is the empty string before the leading "$".
+	SegmentPrefix
+	// SegmentAlgorithm is the algorithm variant such as "argon2id".
+	SegmentAlgorithm
+	// SegmentVersion is the version such as "v=19".
+	SegmentVersion
+	// SegmentParams is the parameters such as "m=65536,t=3,p=2".
+	SegmentParams
+	// SegmentSalt is the base64 encoded salt.
+	SegmentSalt
+	// SegmentHash is the base64 encoded hash.
+	SegmentHash
+)
+
+// String returns the name of the segment. E.g. "salt".
+func (s Segment) String() string {
+	switch s {
+	case SegmentWhole:
+		return "whole"
+	case SegmentPrefix:
+		return "prefix"
+	case SegmentAlgorithm:
+		return "algorithm"
+	case SegmentVersion:
+		return "version"
+	case SegmentParams:
+		return "params"
+	case SegmentSalt:
+		return "salt"
+	case SegmentHash:
+		return "hash"
+	default:
+		return "unknown"
+	}
+}
+
+// ============================================================================
+//  Type: ParseError
+// ============================================================================
+
+// lenSubstringMax is the maximum length of ParseError.Substring in bytes.
+const lenSubstringMax = 32
+
+// ParseError is the error returned by DecodeHashStr() and DecodeHashStrWith().
+// It tells which segment of the hash string was bad and why, so that the bad
+// lines of a large import can be reported without parsing the message.
+//
+// Use errors.As() to get it. errors.Is() matches both the sentinel error, such
+// as ErrInvalidSalt, and the underlying cause.
+type ParseError struct {
+	// Err is the wrapped sentinel error.
+	Err error
+	// Cause is the underlying error, such as a base64.CorruptInputError. It
+	// is nil if there is none.
+	Cause error
+	// Reason describes why the segment is bad. It is the error message.
+	Reason string
+	// Substring is the offending segment, truncated to 32 bytes. It is the
+	// whole input (truncated) if Segment is SegmentWhole.
+	Substring string
+	// Segment is the bad segment.
+	Segment Segment
+	// Offset is the byte offset of the bad segment in the input.
+	Offset int
+}
+
+// Error implements the error interface.
+func (e *ParseError) Error() string {
+	return e.Reason
+}
+
+// Unwrap returns the wrapped sentinel error and the cause, if any.
+func (e *ParseError) Unwrap() []error {
+	if e.Cause == nil {
+		return []error{e.Err}
+	}
+
+	return []error{e.Err, e.Cause}
+}
+
+// ----------------------------------------------------------------------------
+//  Private Functions
+// ----------------------------------------------------------------------------
+
+// newParseError returns a ParseError of the segment wrapping the sentinel. The
+// cause, if any, is appended to the reason unless it already wraps the sentinel.
+func newParseError(segment Segment, substring string, offset int, sentinel, cause error) *ParseError {
+	reason := sentinel.Error()
+
+	switch {
+	case cause == nil:
+	case errors.Is(cause, sentinel):
+		reason = cause.Error()
+	default:
+		reason += ": " + cause.Error()
+	}
+
+	if len(substring) > lenSubstringMax {
+		substring = substring[:lenSubstringMax]
+	}
+
+	return &ParseError{
+		Err:       sentinel,
+		Cause:     cause,
+		Reason:    reason,
+		Substring: substring,
+		Segment:   segment,
+		Offset:    offset,
+	}
+}
@@ -0,0 +1,131 @@
+package argonize_test
+
+import (
+	"encoding/base64"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/KEINOS/go-argonize"
+	"github.com/stretchr/testify/require"
+)
+
+// ----------------------------------------------------------------------------
+//  ParseError
+// ----------------------------------------------------------------------------
+
+func TestParseError(t *testing.T) {
+	t.Parallel()
+
+	for _, test := range []struct {
+		sentinel    error
+		encodedHash string
+		substring   string
+		segment     argonize.Segment
+		offset      int
+	}{
+		{
+			argonize.ErrInvalidFormat, "$argon2id$v=19$m=65536,t=3,p=2$" + sampleSaltB64,
+			"$argon2id$v=19$m=65536,t=3,p=2$W", argonize.SegmentWhole, 0,
+		},
+		{
+			argonize.ErrInvalidFormat, "x$argon2id$v=19$m=65536,t=3,p=2$" + sampleSaltB64 + "$" + sampleHashB64,
+			"x", argonize.SegmentPrefix, 0,
+		},
+		{
+			argonize.ErrUnsupportedVariant, "$argon2d$v=19$m=65536,t=3,p=2$" + sampleSaltB64 + "$" + sampleHashB64,
+			"argon2d", argonize.SegmentAlgorithm, 1,
+		},
+		{
+			argonize.ErrInvalidVersion, "$argon2id$v=x$m=65536,t=3,p=2$" + sampleSaltB64 + "$" + sampleHashB64,
+			"v=x", argonize.SegmentVersion, 10,
+		},
+		{
+			argonize.ErrIncompatibleVersion, "$argon2id$v=16$m=65536,t=3,p=2$" + sampleSaltB64 + "$" + sampleHashB64,
+			"v=16", argonize.SegmentVersion, 10,
+		},
+		{
+			argonize.ErrMissingParams, "$argon2id$v=19$m=65536,t=3$" + sampleSaltB64 + "$" + sampleHashB64,
+			"m=65536,t=3", argonize.SegmentParams, 15,
+		},
+		{
+			argonize.ErrInvalidSalt, "$argon2id$v=19$m=65536,t=3,p=2$%%$" + sampleHashB64,
+			"%%", argonize.SegmentSalt, 31,
+		},
+		{
+			argonize.ErrInvalidLength, "$argon2id$v=19$m=65536,t=3,p=2$Woo$" + sampleHashB64,
+			"Woo", argonize.SegmentSalt, 31,
+		},
+		{
+			argonize.ErrInvalidHashValue, "$argon2id$v=19$m=65536,t=3,p=2$" + sampleSaltB64 + "$%%",
+			"%%", argonize.SegmentHash, 54,
+		},
+	} {
+		hashedObj, err := argonize.DecodeHashStr(test.encodedHash)
+
+		require.Error(t, err, test.encodedHash)
+		require.Nil(t, hashedObj, "it should be nil on error")
+
+		var parseErr *argonize.ParseError
+
+		require.True(t, errors.As(err, &parseErr), "it should be a ParseError")
+		require.ErrorIs(t, err, test.sentinel, test.encodedHash)
+		require.Equal(t, test.segment, parseErr.Segment, test.encodedHash)
+		require.Equal(t, test.offset, parseErr.Offset, test.encodedHash)
+		require.Equal(t, test.substring, parseErr.Substring, test.encodedHash)
+		require.Equal(t, parseErr.Reason, err.Error())
+	}
+}
+
+func TestParseError_cause(t *testing.T) {
+	t.Parallel()
+
+	_, err := argonize.DecodeHashStr("$argon2id$v=19$m=65536,t=3,p=2$%%$" + sampleHashB64)
+
+	var corruptErr base64.CorruptInputError
+
+	require.True(t, errors.As(err, &corruptErr), "the underlying cause should be recoverable")
+	require.ErrorIs(t, err, argonize.ErrInvalidSalt)
+}
+
+func TestParseError_truncated_substring(t *testing.T) {
+	t.Parallel()
+
+	longSalt := strings.Repeat("%", 100)
+
+	_, err := argonize.DecodeHashStr("$argon2id$v=19$m=65536,t=3,p=2$" + longSalt + "$" + sampleHashB64)
+
+	var parseErr *argonize.ParseError
+
+	require.True(t, errors.As(err, &parseErr))
+	require.Equal(t, longSalt[:32], parseErr.Substring)
+}
+
+// ----------------------------------------------------------------------------
+//  Segment.String()
+// ----------------------------------------------------------------------------
+
+func TestSegment_String(t *testing.T) {
+	t.Parallel()
+
+	for segment, expect := range map[argonize.Segment]string{
+		argonize.SegmentWhole:     "whole",
+		argonize.SegmentPrefix:    "prefix",
+		argonize.SegmentAlgorithm: "algorithm",
+		argonize.SegmentVersion:   "version",
+		argonize.SegmentParams:    "params",
+		argonize.SegmentSalt:      "salt",
+		argonize.SegmentHash:      "hash",
+		argonize.Segment(100):     "unknown",
+	} {
+		require.Equal(t, expect, segment.String())
+	}
+}
+
+func TestParseVariant_sentinel(t *testing.T) {
+	t.Parallel()
+
+	_, err := argonize.ParseVariant("argon2d")
+
+	require.ErrorIs(t, err, argonize.ErrUnsupportedVariant)
+}
@@ -0,0 +1,159 @@
+package argonize
+
+import (
+	"context"
+	"crypto/subtle"
+
+	"github.com/pkg/errors"
+)
+
+// ============================================================================
+//  Type: PepperMode
+// ============================================================================
+
+// PepperMode selects how a pepper is mixed into the salt.
+//
+// Other implementations differ in the order. For example, some prepend the
+// pepper to the salt while Salt.AddPepper() appends it. Use the same mode as
+// the system that produced the hash to interoperate.
+type PepperMode int
+
+const (
+	// AppendPepper mixes the pepper as salt || pepper. It is the default and
+	// the same as Salt.AddPepper().
+	AppendPepper PepperMode = iota
+	// PrependPepper mixes the pepper as pepper || salt.
+	PrependPepper
+)
+
+// String returns the name of the mode. E.g. "append".
+func (m PepperMode) String() string {
+	switch m {
+	case AppendPepper:
+		return "append"
+	case PrependPepper:
+		return "prepend"
+	default:
+		return "unknown"
+	}
+}
+
+// ----------------------------------------------------------------------------
+//  Methods of Salt
+// ----------------------------------------------------------------------------
+
+// AddPepperMode is similar to AddPepper() but mixes the pepper in the given
+// mode. Unknown modes are treated as AppendPepper.
+func (s *Salt) AddPepperMode(pepper []byte, mode PepperMode) {
+	if mode != PrependPepper {
+		s.AddPepper(pepper)
+
+		return
+	}
+
+	peppered := make(Salt, 0, len(pepper)+len(*s))
+	peppered = append(peppered, pepper...)
+
+	*s = append(peppered, *s...)
+}
+
+// ----------------------------------------------------------------------------
+//  Methods of Hashed
+// ----------------------------------------------------------------------------
+
+// IsValidPasswordPeppered is similar to IsValidPassword() but mixes the pepper
+// into the stored salt in the given mode before deriving the key. Use it when
+// the stored hash holds the bare salt and the pepper is kept elsewhere.
+//
+// The stored salt is not modified.
+func (h *Hashed) IsValidPasswordPeppered(password, pepper []byte, mode PepperMode) bool {
+	return h.isValidPasswordPepperedWith(currentKDF(), password, pepper, mode)
+}
+
+// VerifyWithAnyPepper is similar to VerifyWithAnyPepperContext() but without a
+// context.
+func (h *Hashed) VerifyWithAnyPepper(password []byte, peppers [][]byte) (ok bool, matchedIndex int, err error) {
+	return h.VerifyWithAnyPepperContext(context.Background(), password, peppers)
+}
+
+// VerifyWithAnyPepperContext tries the peppers in order, mixed into the stored
+// salt with AppendPepper, and returns true and the index of the first pepper
+// that matches. It is for the rotation windows where the hashes created before
+// the IDs were recorded in Hashed.KeyID may be peppered with any of the live
+// peppers. Rehash the password with the current pepper if the matched one is
+// not the current one. Use Hasher and PepperProvider for the hashes with IDs.
+//
+// It returns false and -1 if none matches. Each try is a full-cost key
+// derivation, so put the most likely pepper, such as the current one, first.
+// The context is checked before each try, and it returns an error wrapping the
+// error of the context if it is done. Note that the timing reveals the number
+// of tries, i.e. the index of the matched pepper but not the pepper itself.
+//
+// The keys are compared in constant time, and the peppered salts and derived
+// keys are zeroed after each try.
+func (h *Hashed) VerifyWithAnyPepperContext(
+	ctx context.Context,
+	password []byte,
+	peppers [][]byte,
+) (ok bool, matchedIndex int, err error) {
+	if h.IsZero() {
+		return false, -1, errors.New("failed to verify password: the hash is uninitialized")
+	}
+
+	if len(peppers) == 0 {
+		return false, -1, errors.New("failed to verify password: no peppers given")
+	}
+
+	for index, pepper := range peppers {
+		if err := ctx.Err(); err != nil {
+			return false, -1, errors.Wrapf(err, "failed to verify password: stopped before pepper %d", index)
+		}
+
+		isValid, err := h.tryPepper(password, pepper)
+		if err != nil {
+			return false, -1, errors.Wrapf(err, "failed to verify password with pepper %d", index)
+		}
+
+		if isValid {
+			return true, index, nil
+		}
+	}
+
+	return false, -1, nil
+}
+
+// ----------------------------------------------------------------------------
+//  Methods of Hashed (Private)
+// ----------------------------------------------------------------------------
+
+// tryPepper derives the key of the password with the pepper appended to the
+// stored salt and compares it with the hash in constant time. The intermediate
+// salt and key are zeroed.
+func (h *Hashed) tryPepper(password, pepper []byte) (bool, error) {
+	salt := append(Salt(nil), h.Salt...)
+	salt.AddPepperMode(pepper, AppendPepper)
+
+	defer clear(salt)
+
+	key, err := deriveKey(password, salt, h.Params)
+	if err != nil {
+		return false, err
+	}
+
+	defer clear(key)
+
+	return subtle.ConstantTimeCompare(h.Hash, key) == 1, nil
+}
+
+// isValidPasswordPepperedWith is the implementation of IsValidPasswordPeppered()
+// deriving the key through the given KDF.
+func (h *Hashed) isValidPasswordPepperedWith(kdf KDF, password, pepper []byte, mode PepperMode) bool {
+	if h == nil {
+		return false
+	}
+
+	salt := append(Salt(nil), h.Salt...)
+	salt.AddPepperMode(pepper, mode)
+
+	return h.isValidPasswordWith(kdf, password, salt)
+}
@@ -0,0 +1,133 @@
+package vaultpepper
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+// FieldDefault is the default field of the secret holding the pepper.
+const FieldDefault = "pepper"
+
+// lenResponseMax is the maximum size of the response body to read.
+const lenResponseMax = 1 << 20
+
+// ============================================================================
+//  Type: HTTPBackend
+// ============================================================================
+
+// HTTPBackend is the Backend of the Vault KV v2 secrets engine over HTTP.
+type HTTPBackend struct {
+	// Client is the HTTP client. If nil, http.DefaultClient is used.
+	Client *http.Client
+	// Address is the address of Vault. E.g. "https://vault.example.com:8200".
+	Address string
+	// Token is the Vault token with the read capability of the secret.
+	Token string
+	// Mount is the mount path of the KV v2 engine. E.g. "secret".
+	Mount string
+	// Path is the path of the secret in the engine. E.g. "app/pepper".
+	Path string
+	// Field is the field of the secret holding the base64 encoded pepper. If
+	// empty, FieldDefault is used.
+	Field string
+}
+
+// kvResponse is the response of the KV v2 read API.
+type kvResponse struct {
+	Data struct {
+		Data     map[string]string `json:"data"`
+		Metadata struct {
+			Version int `json:"version"`
+		} `json:"metadata"`
+	} `json:"data"`
+}
+
+// ----------------------------------------------------------------------------
+//  Methods of HTTPBackend
+// ----------------------------------------------------------------------------
+
+// Current implements Backend. It reads the latest version of the secret. The
+// version number is the ID.
+func (b *HTTPBackend) Current(ctx context.Context) (string, []byte, error) {
+	version, secret, err := b.read(ctx, "")
+	if err != nil {
+		return "", nil, err
+	}
+
+	return strconv.Itoa(version), secret, nil
+}
+
+// ByID implements Backend. It reads the version of the secret of the ID.
+func (b *HTTPBackend) ByID(ctx context.Context, id string) ([]byte, error) {
+	if version, err := strconv.Atoi(id); err != nil || version < 1 {
+		return nil, errors.Errorf("id %q is not a version number", id)
+	}
+
+	_, secret, err := b.read(ctx, id)
+
+	return secret, err
+}
+
+// read reads the version of the secret. An empty version means the latest.
+func (b *HTTPBackend) read(ctx context.Context, version string) (int, []byte, error) {
+	endpoint := strings.TrimSuffix(b.Address, "/") + "/v1/" +
+		strings.Trim(b.Mount, "/") + "/data/" + strings.Trim(b.Path, "/")
+
+	if version != "" {
+		endpoint += "?" + url.Values{"version": {version}}.Encode()
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, endpoint, nil)
+	if err != nil {
+		return 0, nil, errors.Wrap(err, "failed to create the vault request")
+	}
+
+	req.Header.Set("X-Vault-Token", b.Token)
+
+	client := b.Client
+	if client == nil {
+		client = http.DefaultClient
+	}
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return 0, nil, errors.Wrap(err, "failed to request vault")
+	}
+
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return 0, nil, errors.Errorf("vault responded with status %d", resp.StatusCode)
+	}
+
+	var body kvResponse
+
+	if err := json.NewDecoder(io.LimitReader(resp.Body, lenResponseMax)).Decode(&body); err != nil {
+		return 0, nil, errors.Wrap(err, "failed to decode the vault response")
+	}
+
+	field := b.Field
+	if field == "" {
+		field = FieldDefault
+	}
+
+	encoded, ok := body.Data.Data[field]
+	if !ok {
+		return 0, nil, errors.Errorf("field %q is missing in the secret", field)
+	}
+
+	secret, err := base64.StdEncoding.DecodeString(encoded)
+	if err != nil {
+		return 0, nil, errors.Wrapf(err, "field %q is not base64", field)
+	}
+
+	return body.Data.Metadata.Version, secret, nil
+}
@@ -0,0 +1,204 @@
+/*
+Package vaultpepper provides an argonize.PepperProvider backed by the KV secrets
+engine version 2 of HashiCorp Vault.
+
+It is a reference implementation of argonize.PepperProvider showing caching,
+rotation and error handling. It depends on no Vault client library: the KV v2
+HTTP API is called with net/http. The Backend is an interface, so the Provider
+can be used with any other secrets store as well.
+
+# Peppers in Vault
+
+The pepper is stored in a field (default "pepper") of a KV v2 secret as a
+standard base64 string. Each version of the secret is a pepper, and the
+version number is the key ID recorded in the hash. Rotating the pepper is
+writing a new version of the secret.
+
+	vault kv put -mount=secret app/pepper pepper="$(head -c 32 /dev/urandom | base64)"
+
+Do not delete or destroy the old versions as long as the hashes peppered with
+them exist.
+
+# Caching
+
+The current pepper is cached for Options.TTL. If the refresh fails, the stale
+pepper is kept being used, so that an outage of Vault does not stop the new
+sign-ups. The peppers looked up by ID are cached forever since the versions of
+a KV v2 secret are immutable. An unknown ID is looked up in the backend each
+time, which picks up a rotation done by another instance.
+
+Every backend call is bounded by Options.Timeout, so a slow backend can not
+hang every login.
+*/
+package vaultpepper
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/KEINOS/go-argonize"
+	"github.com/pkg/errors"
+)
+
+// Default values of Options.
+const (
+	TTLDefault     = 5 * time.Minute
+	TimeoutDefault = 5 * time.Second
+)
+
+// Provider implements argonize.PepperProvider.
+var _ argonize.PepperProvider = (*Provider)(nil)
+
+// ============================================================================
+//  Type: Backend
+// ============================================================================
+
+// Backend reads the peppers from the secrets store. HTTPBackend implements it
+// for Vault. Implement it with a fake for tests or with another secrets store.
+//
+// Implementations must be safe for concurrent use and must honor the context.
+type Backend interface {
+	// Current returns the ID and the secret of the current pepper.
+	Current(ctx context.Context) (id string, secret []byte, err error)
+	// ByID returns the secret of the pepper of the ID.
+	ByID(ctx context.Context, id string) ([]byte, error)
+}
+
+// ============================================================================
+//  Type: Options
+// ============================================================================
+
+// Options configures the Provider. The zero value uses the defaults.
+type Options struct {
+	// TTL is how long the current pepper is cached. Zero means TTLDefault.
+	TTL time.Duration
+	// Timeout bounds every backend call. Zero means TimeoutDefault.
+	Timeout time.Duration
+}
+
+// ============================================================================
+//  Type: Provider
+// ============================================================================
+
+// Provider is an argonize.PepperProvider caching the peppers of the Backend.
+// It is safe for concurrent use.
+type Provider struct {
+	backend   Backend
+	byID      map[string][]byte
+	fetchedAt time.Time
+	currentID string
+	opts      Options
+	mutex     sync.RWMutex
+}
+
+// ----------------------------------------------------------------------------
+//  Constructor of Provider
+// ----------------------------------------------------------------------------
+
+// New returns a new Provider of the backend.
+func New(backend Backend, opts Options) (*Provider, error) {
+	if backend == nil {
+		return nil, errors.New("failed to create pepper provider: backend is nil")
+	}
+
+	if opts.TTL <= 0 {
+		opts.TTL = TTLDefault
+	}
+
+	if opts.Timeout <= 0 {
+		opts.Timeout = TimeoutDefault
+	}
+
+	return &Provider{
+		backend: backend,
+		byID:    make(map[string][]byte),
+		opts:    opts,
+	}, nil
+}
+
+// ----------------------------------------------------------------------------
+//  Methods of Provider
+// ----------------------------------------------------------------------------
+
+// CurrentPepper implements argonize.PepperProvider. It is CurrentPepperContext()
+// with the background context.
+func (p *Provider) CurrentPepper() (string, []byte, error) {
+	return p.CurrentPepperContext(context.Background())
+}
+
+// CurrentPepperContext returns the current pepper, refreshing it from the
+// backend if the cache is older than the TTL. If the refresh fails, the stale
+// pepper is returned, if any.
+func (p *Provider) CurrentPepperContext(ctx context.Context) (string, []byte, error) {
+	p.mutex.RLock()
+	currentID, fetchedAt := p.currentID, p.fetchedAt
+	secret := p.byID[currentID]
+	p.mutex.RUnlock()
+
+	if currentID != "" && time.Since(fetchedAt) < p.opts.TTL {
+		return currentID, secret, nil
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, p.opts.Timeout)
+	defer cancel()
+
+	newID, newSecret, err := p.backend.Current(ctx)
+	if err == nil && (newID == "" || len(newSecret) == 0) {
+		err = errors.New("empty id or secret")
+	}
+
+	if err != nil {
+		if currentID != "" {
+			return currentID, secret, nil // serve the stale pepper
+		}
+
+		return "", nil, errors.Wrap(err, "failed to get the current pepper")
+	}
+
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+
+	p.currentID = newID
+	p.fetchedAt = time.Now()
+	p.byID[newID] = newSecret
+
+	return newID, newSecret, nil
+}
+
+// PepperByID implements argonize.PepperProvider. It is PepperByIDContext()
+// with the background context.
+func (p *Provider) PepperByID(id string) ([]byte, error) {
+	return p.PepperByIDContext(context.Background(), id)
+}
+
+// PepperByIDContext returns the pepper of the ID. An ID not in the cache is
+// looked up in the backend.
+func (p *Provider) PepperByIDContext(ctx context.Context, id string) ([]byte, error) {
+	p.mutex.RLock()
+	secret, ok := p.byID[id]
+	p.mutex.RUnlock()
+
+	if ok {
+		return secret, nil
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, p.opts.Timeout)
+	defer cancel()
+
+	secret, err := p.backend.ByID(ctx, id)
+	if err == nil && len(secret) == 0 {
+		err = errors.New("empty secret")
+	}
+
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to get the pepper of id %q", id)
+	}
+
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+
+	p.byID[id] = secret
+
+	return secret, nil
+}
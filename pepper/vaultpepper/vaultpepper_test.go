@@ -0,0 +1,338 @@
+package vaultpepper_test
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/KEINOS/go-argonize"
+	"github.com/KEINOS/go-argonize/pepper/vaultpepper"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/require"
+)
+
+// ----------------------------------------------------------------------------
+//  Fakes
+// ----------------------------------------------------------------------------
+
+// fakeVault is a fake of the Vault KV v2 read API holding the versions of a
+// secret.
+type fakeVault struct {
+	versions [][]byte
+	requests atomic.Int32
+	mutex    sync.Mutex
+}
+
+func (f *fakeVault) put(secret string) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	f.versions = append(f.versions, []byte(secret))
+}
+
+func (f *fakeVault) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	f.requests.Add(1)
+
+	if r.Header.Get("X-Vault-Token") != "token" {
+		w.WriteHeader(http.StatusForbidden)
+
+		return
+	}
+
+	if r.URL.Path != "/v1/secret/data/app/pepper" {
+		w.WriteHeader(http.StatusNotFound)
+
+		return
+	}
+
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	version := len(f.versions)
+
+	if v := r.URL.Query().Get("version"); v != "" {
+		version, _ = strconv.Atoi(v)
+	}
+
+	if version < 1 || version > len(f.versions) {
+		w.WriteHeader(http.StatusNotFound)
+
+		return
+	}
+
+	resp := map[string]any{
+		"data": map[string]any{
+			"data":     map[string]string{"pepper": base64.StdEncoding.EncodeToString(f.versions[version-1])},
+			"metadata": map[string]any{"version": version},
+		},
+	}
+
+	_ = json.NewEncoder(w).Encode(resp)
+}
+
+// fakeBackend is a Backend with a switchable failure and a delay.
+type fakeBackend struct {
+	err      error
+	calls    atomic.Int32
+	current  string
+	peppers  map[string][]byte
+	mutex    sync.Mutex
+	blocking bool
+}
+
+func (f *fakeBackend) wait(ctx context.Context) error {
+	f.calls.Add(1)
+
+	f.mutex.Lock()
+	blocking, err := f.blocking, f.err
+	f.mutex.Unlock()
+
+	if blocking {
+		<-ctx.Done()
+
+		return ctx.Err()
+	}
+
+	return err
+}
+
+func (f *fakeBackend) Current(ctx context.Context) (string, []byte, error) {
+	if err := f.wait(ctx); err != nil {
+		return "", nil, err
+	}
+
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	return f.current, f.peppers[f.current], nil
+}
+
+func (f *fakeBackend) ByID(ctx context.Context, id string) ([]byte, error) {
+	if err := f.wait(ctx); err != nil {
+		return nil, err
+	}
+
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	secret, ok := f.peppers[id]
+	if !ok {
+		return nil, errors.Errorf("unknown id %q", id)
+	}
+
+	return secret, nil
+}
+
+func (f *fakeBackend) set(fn func()) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	fn()
+}
+
+// ----------------------------------------------------------------------------
+//  New()
+// ----------------------------------------------------------------------------
+
+func TestNew_nil_backend(t *testing.T) {
+	t.Parallel()
+
+	provider, err := vaultpepper.New(nil, vaultpepper.Options{})
+
+	require.ErrorContains(t, err, "backend is nil")
+	require.Nil(t, provider)
+}
+
+// ----------------------------------------------------------------------------
+//  Provider with HTTPBackend
+// ----------------------------------------------------------------------------
+
+func TestProvider_http_backend(t *testing.T) {
+	t.Parallel()
+
+	vault := new(fakeVault)
+	vault.put("pepper-v1")
+
+	server := httptest.NewServer(vault)
+	defer server.Close()
+
+	provider, err := vaultpepper.New(&vaultpepper.HTTPBackend{
+		Client:  server.Client(),
+		Address: server.URL,
+		Token:   "token",
+		Mount:   "secret",
+		Path:    "app/pepper",
+	}, vaultpepper.Options{TTL: time.Hour})
+	require.NoError(t, err)
+
+	params := argonize.NewParams()
+	params.MemoryCost = 64
+	params.Parallelism = 1
+
+	hasher := argonize.NewHasher(params).WithPepperProvider(provider)
+
+	hashed, err := hasher.Hash([]byte("password"))
+	require.NoError(t, err)
+	require.Equal(t, "1", hashed.KeyID)
+
+	// Rotate in Vault. The cached current pepper is used until the TTL.
+	vault.put("pepper-v2")
+
+	id, secret, err := provider.CurrentPepper()
+	require.NoError(t, err)
+	require.Equal(t, "1", id)
+	require.Equal(t, []byte("pepper-v1"), secret)
+
+	// Unknown IDs are looked up in Vault
+	secret, err = provider.PepperByID("2")
+	require.NoError(t, err)
+	require.Equal(t, []byte("pepper-v2"), secret)
+
+	ok, err := hasher.Verify(hashed, []byte("password"))
+	require.NoError(t, err)
+	require.True(t, ok)
+
+	// Known IDs are served from the cache
+	requests := vault.requests.Load()
+
+	_, err = provider.PepperByID("1")
+	require.NoError(t, err)
+	require.Equal(t, requests, vault.requests.Load())
+
+	// Errors of Vault
+	_, err = provider.PepperByID("9")
+	require.ErrorContains(t, err, "status 404")
+
+	_, err = provider.PepperByID("not-a-version")
+	require.ErrorContains(t, err, "is not a version number")
+}
+
+func TestHTTPBackend_errors(t *testing.T) {
+	t.Parallel()
+
+	vault := new(fakeVault)
+	vault.put("pepper-v1")
+
+	server := httptest.NewServer(vault)
+	defer server.Close()
+
+	for _, test := range []struct {
+		backend    *vaultpepper.HTTPBackend
+		msgContain string
+	}{
+		{&vaultpepper.HTTPBackend{Address: server.URL, Token: "bad", Mount: "secret", Path: "app/pepper"}, "status 403"},
+		{&vaultpepper.HTTPBackend{Address: server.URL, Token: "token", Mount: "secret", Path: "app/other"}, "status 404"},
+		{
+			&vaultpepper.HTTPBackend{Address: server.URL, Token: "token", Mount: "secret", Path: "app/pepper", Field: "other"},
+			`field "other" is missing`,
+		},
+		{&vaultpepper.HTTPBackend{Address: "http://127.0.0.1:0", Token: "token"}, "failed to request vault"},
+	} {
+		_, _, err := test.backend.Current(context.Background())
+
+		require.ErrorContains(t, err, test.msgContain)
+	}
+}
+
+// ----------------------------------------------------------------------------
+//  Provider caching and error handling
+// ----------------------------------------------------------------------------
+
+func TestProvider_ttl_refresh(t *testing.T) {
+	t.Parallel()
+
+	backend := &fakeBackend{current: "a", peppers: map[string][]byte{"a": []byte("A")}}
+
+	provider, err := vaultpepper.New(backend, vaultpepper.Options{TTL: 20 * time.Millisecond})
+	require.NoError(t, err)
+
+	id, _, err := provider.CurrentPepper()
+	require.NoError(t, err)
+	require.Equal(t, "a", id)
+
+	backend.set(func() {
+		backend.current = "b"
+		backend.peppers["b"] = []byte("B")
+	})
+
+	id, _, err = provider.CurrentPepper()
+	require.NoError(t, err)
+	require.Equal(t, "a", id, "it should be cached within the TTL")
+
+	time.Sleep(30 * time.Millisecond)
+
+	id, secret, err := provider.CurrentPepper()
+	require.NoError(t, err)
+	require.Equal(t, "b", id, "it should be refreshed after the TTL")
+	require.Equal(t, []byte("B"), secret)
+}
+
+func TestProvider_stale_on_failure(t *testing.T) {
+	t.Parallel()
+
+	backend := &fakeBackend{current: "a", peppers: map[string][]byte{"a": []byte("A")}}
+
+	provider, err := vaultpepper.New(backend, vaultpepper.Options{TTL: time.Nanosecond})
+	require.NoError(t, err)
+
+	_, _, err = provider.CurrentPepper()
+	require.NoError(t, err)
+
+	backend.set(func() { backend.err = errors.New("vault is sealed") })
+
+	id, secret, err := provider.CurrentPepper()
+	require.NoError(t, err, "the stale pepper should be served")
+	require.Equal(t, "a", id)
+	require.Equal(t, []byte("A"), secret)
+
+	// Without any cache, the error should surface
+	empty, err := vaultpepper.New(backend, vaultpepper.Options{})
+	require.NoError(t, err)
+
+	_, _, err = empty.CurrentPepper()
+	require.ErrorContains(t, err, "vault is sealed")
+
+	_, err = empty.PepperByID("a")
+	require.ErrorContains(t, err, "vault is sealed")
+}
+
+func TestProvider_timeout(t *testing.T) {
+	t.Parallel()
+
+	backend := &fakeBackend{blocking: true}
+
+	provider, err := vaultpepper.New(backend, vaultpepper.Options{Timeout: 20 * time.Millisecond})
+	require.NoError(t, err)
+
+	start := time.Now()
+
+	_, err = provider.PepperByID("a")
+
+	require.ErrorIs(t, err, context.DeadlineExceeded)
+	require.Less(t, time.Since(start), time.Second, "a slow backend should not hang")
+
+	// The caller's context is honored as well
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	_, _, err = provider.CurrentPepperContext(ctx)
+	require.ErrorIs(t, err, context.Canceled)
+
+	// The hasher should report it as an unavailable pepper, not a wrong password
+	hashed := &argonize.Hashed{
+		Params: argonize.NewParams(),
+		Salt:   make(argonize.Salt, 16),
+		Hash:   make([]byte, 32),
+		KeyID:  "a",
+	}
+
+	_, err = argonize.NewHasher(nil).WithPepperProvider(provider).Verify(hashed, []byte("password"))
+	require.ErrorIs(t, err, argonize.ErrPepperUnavailable)
+}
@@ -0,0 +1,175 @@
+package argonize_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/KEINOS/go-argonize"
+	"github.com/stretchr/testify/require"
+)
+
+// Fixtures of the password "password", the salt "0123456789abcdef" and the
+// pepper "pepper". The stored salt is the bare one. They were generated with
+// argon2.IDKey() directly, by concatenating the pepper and the salt in each
+// order.
+const (
+	// argon2.IDKey(password, pepper || salt, 1, 64, 1, 32)
+	fixturePrependPepper = "$argon2id$v=19$m=64,t=1,p=1$MDEyMzQ1Njc4OWFiY2RlZg$saY4v8vu9sOyRwat5RA4HoI26l8wmJyhVrxCq6tP+xc"
+	// argon2.IDKey(password, salt || pepper, 1, 64, 1, 32)
+	fixtureAppendPepper = "$argon2id$v=19$m=64,t=1,p=1$MDEyMzQ1Njc4OWFiY2RlZg$ztkHDCdoV6Vohv0qPJndUTngPjzlnmctl0HOE+yxvjo"
+)
+
+// ----------------------------------------------------------------------------
+//  Salt.AddPepperMode()
+// ----------------------------------------------------------------------------
+
+func TestSalt_AddPepperMode(t *testing.T) {
+	t.Parallel()
+
+	salt := argonize.Salt("salt")
+	salt.AddPepperMode([]byte("pepper"), argonize.PrependPepper)
+
+	require.Equal(t, argonize.Salt("peppersalt"), salt)
+
+	salt = argonize.Salt("salt")
+	salt.AddPepperMode([]byte("pepper"), argonize.AppendPepper)
+
+	require.Equal(t, argonize.Salt("saltpepper"), salt)
+
+	salt = argonize.Salt("salt")
+	salt.AddPepperMode([]byte("pepper"), argonize.PepperMode(99))
+
+	require.Equal(t, argonize.Salt("saltpepper"), salt, "unknown mode should append")
+}
+
+func TestPepperMode_String(t *testing.T) {
+	t.Parallel()
+
+	require.Equal(t, "append", argonize.AppendPepper.String())
+	require.Equal(t, "prepend", argonize.PrependPepper.String())
+	require.Equal(t, "unknown", argonize.PepperMode(99).String())
+}
+
+// ----------------------------------------------------------------------------
+//  Hashed.IsValidPasswordPeppered()
+// ----------------------------------------------------------------------------
+
+func TestHashed_IsValidPasswordPeppered(t *testing.T) {
+	t.Parallel()
+
+	for _, test := range []struct {
+		fixture string
+		mode    argonize.PepperMode
+		other   argonize.PepperMode
+	}{
+		{fixturePrependPepper, argonize.PrependPepper, argonize.AppendPepper},
+		{fixtureAppendPepper, argonize.AppendPepper, argonize.PrependPepper},
+	} {
+		hashedObj, err := argonize.DecodeHashStr(test.fixture)
+		require.NoError(t, err)
+
+		storedSalt := append(argonize.Salt(nil), hashedObj.Salt...)
+
+		require.True(t, hashedObj.IsValidPasswordPeppered([]byte("password"), []byte("pepper"), test.mode),
+			"mode %s should verify its own fixture", test.mode)
+		require.False(t, hashedObj.IsValidPasswordPeppered([]byte("password"), []byte("pepper"), test.other),
+			"mode %s should not verify the fixture of mode %s", test.other, test.mode)
+		require.False(t, hashedObj.IsValidPasswordPeppered([]byte("wrong"), []byte("pepper"), test.mode))
+		require.False(t, hashedObj.IsValidPasswordPeppered([]byte("password"), []byte("wrong"), test.mode))
+		require.False(t, hashedObj.IsValidPassword([]byte("password")), "it should require the pepper")
+		require.Equal(t, storedSalt, hashedObj.Salt, "the stored salt should not be modified")
+	}
+}
+
+func TestHashed_IsValidPasswordPeppered_round_trip(t *testing.T) {
+	t.Parallel()
+
+	params := argonize.NewParams()
+	params.MemoryCost = 64
+	params.Parallelism = 1
+
+	salt, err := argonize.NewSalt(params.SaltLength)
+	require.NoError(t, err)
+
+	peppered := append(argonize.Salt(nil), salt...)
+	peppered.AddPepperMode([]byte("pepper"), argonize.PrependPepper)
+
+	hashedObj, err := argonize.HashWithSalt([]byte("password"), peppered, params)
+	require.NoError(t, err)
+
+	// Store the bare salt
+	hashedObj.Salt = salt
+
+	require.True(t, hashedObj.IsValidPasswordPeppered([]byte("password"), []byte("pepper"), argonize.PrependPepper))
+}
+
+// ----------------------------------------------------------------------------
+//  Hashed.VerifyWithAnyPepper()
+// ----------------------------------------------------------------------------
+
+func TestHashed_VerifyWithAnyPepper(t *testing.T) {
+	t.Parallel()
+
+	hashedObj, err := argonize.DecodeHashStr(fixtureAppendPepper)
+	require.NoError(t, err)
+
+	peppers := [][]byte{[]byte("new pepper"), []byte("pepper"), []byte("older pepper")}
+
+	ok, index, err := hashedObj.VerifyWithAnyPepper([]byte("password"), peppers)
+	require.NoError(t, err)
+	require.True(t, ok)
+	require.Equal(t, 1, index, "the old pepper should match")
+
+	ok, index, err = hashedObj.VerifyWithAnyPepper([]byte("wrong password"), peppers)
+	require.NoError(t, err)
+	require.False(t, ok)
+	require.Equal(t, -1, index)
+
+	ok, index, err = hashedObj.VerifyWithAnyPepper([]byte("password"), peppers[:1])
+	require.NoError(t, err)
+	require.False(t, ok)
+	require.Equal(t, -1, index)
+
+	// The stored salt should not be modified
+	require.Equal(t, argonize.Salt("0123456789abcdef"), hashedObj.Salt)
+}
+
+func TestHashed_VerifyWithAnyPepperContext_canceled(t *testing.T) {
+	t.Parallel()
+
+	hashedObj, err := argonize.DecodeHashStr(fixtureAppendPepper)
+	require.NoError(t, err)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	ok, index, err := hashedObj.VerifyWithAnyPepperContext(ctx, []byte("password"), [][]byte{[]byte("pepper")})
+	require.ErrorIs(t, err, context.Canceled)
+	require.False(t, ok)
+	require.Equal(t, -1, index)
+}
+
+func TestHashed_VerifyWithAnyPepper_errors(t *testing.T) {
+	t.Parallel()
+
+	ok, index, err := new(argonize.Hashed).VerifyWithAnyPepper([]byte("password"), [][]byte{[]byte("pepper")})
+	require.ErrorContains(t, err, "the hash is uninitialized")
+	require.False(t, ok)
+	require.Equal(t, -1, index)
+
+	hashedObj, err := argonize.DecodeHashStr(fixtureAppendPepper)
+	require.NoError(t, err)
+
+	ok, index, err = hashedObj.VerifyWithAnyPepper([]byte("password"), nil)
+	require.ErrorContains(t, err, "no peppers given")
+	require.False(t, ok)
+	require.Equal(t, -1, index)
+
+	hashedObj.Params.Parallelism = 0
+
+	ok, index, err = hashedObj.VerifyWithAnyPepper([]byte("password"), [][]byte{[]byte("pepper")})
+	require.ErrorIs(t, err, argonize.ErrParallelismTooLow)
+	require.ErrorContains(t, err, "with pepper 0")
+	require.False(t, ok)
+	require.Equal(t, -1, index)
+}
@@ -0,0 +1,146 @@
+package argonize
+
+import (
+	"fmt"
+	"log/slog"
+
+	"github.com/pkg/errors"
+)
+
+// ============================================================================
+//  Type: PepperedSalt
+// ============================================================================
+
+// PepperedSalt holds a salt and a pepper apart, so that the pepper never ends
+// up in the stored hash by accident. Create it with Salt.Peppered() and hash
+// with HashPeppered().
+//
+// Unlike Salt.AddPepper(), the pepper is mixed into the salt only during the
+// key derivation. The Hashed object holds the bare salt, so String(), Gob(),
+// EncodeBinary() and the other encodings of it can not contain the pepper.
+// Verify it with Hashed.IsValidPasswordPeppered() and AppendPepper, giving the
+// pepper again.
+//
+// The PepperedSalt itself is redacted when printed or logged, and refuses to
+// be marshaled by the encoding packages, such as encoding/json and
+// encoding/gob.
+type PepperedSalt struct {
+	salt   Salt
+	pepper []byte
+}
+
+// ----------------------------------------------------------------------------
+//  Methods of PepperedSalt
+// ----------------------------------------------------------------------------
+
+// Salt returns a copy of the bare salt, without the pepper.
+func (p PepperedSalt) Salt() Salt {
+	return append(Salt(nil), p.salt...)
+}
+
+// String implements fmt.Stringer. It returns "[REDACTED]".
+func (p PepperedSalt) String() string {
+	return redacted
+}
+
+// GoString implements fmt.GoStringer for the "%#v" verb. It returns
+// "argonize.PepperedSalt{[REDACTED]}".
+func (p PepperedSalt) GoString() string {
+	return "argonize.PepperedSalt{" + redacted + "}"
+}
+
+// Format implements fmt.Formatter so that any verb prints the redacted
+// placeholder instead of the bytes.
+func (p PepperedSalt) Format(state fmt.State, verb rune) {
+	if verb == 'v' && state.Flag('#') {
+		fmt.Fprint(state, p.GoString())
+
+		return
+	}
+
+	fmt.Fprint(state, redacted)
+}
+
+// LogValue implements slog.LogValuer. It returns "[REDACTED]".
+func (p PepperedSalt) LogValue() slog.Value {
+	return slog.StringValue(redacted)
+}
+
+// MarshalText implements encoding.TextMarshaler to always return an error, so
+// that encoding/json and the other text encoders can not serialize the pepper.
+func (p PepperedSalt) MarshalText() ([]byte, error) {
+	return nil, errors.New("argonize: PepperedSalt must not be serialized, store Hashed instead")
+}
+
+// MarshalBinary implements encoding.BinaryMarshaler to always return an error,
+// so that encoding/gob and the other binary encoders can not serialize the
+// pepper.
+func (p PepperedSalt) MarshalBinary() ([]byte, error) {
+	return nil, errors.New("argonize: PepperedSalt must not be serialized, store Hashed instead")
+}
+
+// ----------------------------------------------------------------------------
+//  Methods of PepperedSalt (Private)
+// ----------------------------------------------------------------------------
+
+// mixed returns a new slice of the salt with the pepper appended, the same as
+// Salt.AddPepper(). Clear it after use.
+func (p PepperedSalt) mixed() Salt {
+	salt := append(Salt(nil), p.salt...)
+	salt.AddPepper(p.pepper)
+
+	return salt
+}
+
+// ----------------------------------------------------------------------------
+//  Methods of Salt
+// ----------------------------------------------------------------------------
+
+// Peppered returns the PepperedSalt of the salt and the pepper for
+// HashPeppered(). It holds copies of both, so the caller may clear them
+// afterwards.
+func (s Salt) Peppered(pepper []byte) PepperedSalt {
+	return PepperedSalt{
+		salt:   append(Salt(nil), s...),
+		pepper: append([]byte(nil), pepper...),
+	}
+}
+
+// ============================================================================
+//  Functions
+// ============================================================================
+
+// HashPeppered hashes the password with the salt and the pepper of the
+// PepperedSalt. The pepper is appended to the salt as Salt.AddPepper() does,
+// but the returned object holds only the bare salt, so that none of its
+// encodings contain the pepper. Verify it with IsValidPasswordPeppered() and
+// AppendPepper.
+//
+// It returns an error if the params are invalid, the pepper is empty or the
+// bare salt is shorter than SaltLengthMin.
+func HashPeppered(password []byte, salt PepperedSalt, params *Params) (*Hashed, error) {
+	if len(salt.pepper) == 0 {
+		return nil, errors.New("failed to hash the password: the pepper is empty")
+	}
+
+	if len(salt.salt) < int(SaltLengthMin) {
+		return nil, errors.Errorf(
+			"failed to hash the password: salt length %d is shorter than the minimum %d",
+			len(salt.salt), SaltLengthMin,
+		)
+	}
+
+	mixed := salt.mixed()
+	defer clear(mixed)
+
+	hashed, err := hashWithSalt(currentKDF(), password, mixed, params)
+	if err != nil {
+		return nil, err
+	}
+
+	// hashWithSalt() holds its own copy of the mixed salt.
+	clear(hashed.Salt)
+	hashed.Salt = salt.Salt()
+
+	return hashed, nil
+}
@@ -0,0 +1,166 @@
+package argonize_test
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/gob"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"testing"
+
+	"github.com/KEINOS/go-argonize"
+	"github.com/stretchr/testify/require"
+)
+
+// requireNoPepper fails if the output contains the pepper in raw, hex or any
+// base64 form.
+func requireNoPepper(t *testing.T, name string, output, pepper []byte) {
+	t.Helper()
+
+	for _, form := range [][]byte{
+		pepper,
+		[]byte(hex.EncodeToString(pepper)),
+		[]byte(base64.StdEncoding.EncodeToString(pepper)),
+		[]byte(base64.RawStdEncoding.EncodeToString(pepper)),
+		[]byte(base64.RawURLEncoding.EncodeToString(pepper)),
+	} {
+		require.False(t, bytes.Contains(output, form), "%s should not contain the pepper %q: %s", name, form, output)
+	}
+}
+
+// ----------------------------------------------------------------------------
+//  HashPeppered()
+// ----------------------------------------------------------------------------
+
+func TestHashPeppered(t *testing.T) {
+	t.Parallel()
+
+	password := []byte("password")
+	pepper := []byte("my-secret-pepper-0123456789")
+	salt := argonize.Salt("0123456789abcdef")
+
+	hashedObj, err := argonize.HashPeppered(password, salt.Peppered(pepper), lowCostParams())
+	require.NoError(t, err)
+	require.Equal(t, salt, hashedObj.Salt, "it should hold the bare salt")
+
+	// It should be the same key as AddPepper()
+	mixed := append(argonize.Salt(nil), salt...)
+	mixed.AddPepper(pepper)
+
+	expect, err := argonize.HashWithSalt(password, mixed, lowCostParams())
+	require.NoError(t, err)
+	require.Equal(t, expect.Hash, hashedObj.Hash)
+
+	// Verification requires the pepper again
+	require.True(t, hashedObj.IsValidPasswordPeppered(password, pepper, argonize.AppendPepper))
+	require.False(t, hashedObj.IsValidPasswordPeppered(password, []byte("wrong pepper"), argonize.AppendPepper))
+	require.False(t, hashedObj.IsValidPassword(password), "it should not verify without the pepper")
+
+	// Peppered() should copy the inputs
+	peppered := salt.Peppered(pepper)
+	pepper[0] = 'X'
+	salt[0] = 'X'
+
+	hashedObj2, err := argonize.HashPeppered(password, peppered, lowCostParams())
+	require.NoError(t, err)
+	require.Equal(t, hashedObj.Hash, hashedObj2.Hash)
+	require.Equal(t, argonize.Salt("0123456789abcdef"), peppered.Salt())
+}
+
+func TestHashPeppered_errors(t *testing.T) {
+	t.Parallel()
+
+	password := []byte("password")
+	salt := argonize.Salt("0123456789abcdef")
+
+	_, err := argonize.HashPeppered(password, salt.Peppered(nil), lowCostParams())
+	require.ErrorContains(t, err, "the pepper is empty")
+
+	_, err = argonize.HashPeppered(password, argonize.PepperedSalt{}, lowCostParams())
+	require.ErrorContains(t, err, "the pepper is empty")
+
+	// The pepper should not count toward the salt length
+	_, err = argonize.HashPeppered(password, argonize.Salt("short").Peppered([]byte("long enough pepper")), lowCostParams())
+	require.ErrorContains(t, err, "salt length 5 is shorter than the minimum 8")
+
+	_, err = argonize.HashPeppered(password, salt.Peppered([]byte("pepper")), nil)
+	require.ErrorIs(t, err, argonize.ErrNilParams)
+}
+
+func TestHashPeppered_no_leak(t *testing.T) {
+	t.Parallel()
+
+	pepper := []byte("my-secret-pepper-0123456789")
+	peppered := argonize.Salt("0123456789abcdef").Peppered(pepper)
+
+	hashedObj, err := argonize.HashPeppered([]byte("password"), peppered, lowCostParams())
+	require.NoError(t, err)
+
+	outputs := map[string][]byte{
+		"String":         []byte(hashedObj.String()),
+		"StringURLSafe":  []byte(hashedObj.StringURLSafe()),
+		"Summary":        []byte(hashedObj.Summary()),
+		"Claim":          []byte(hashedObj.Claim()),
+		"Fingerprint":    []byte(hashedObj.Fingerprint()),
+		"fmt %v":         []byte(fmt.Sprintf("%v", hashedObj)),
+		"fmt %+v":        []byte(fmt.Sprintf("%+v", hashedObj)),
+		"fmt %#v":        []byte(fmt.Sprintf("%#v", hashedObj)),
+		"fmt %x":         []byte(fmt.Sprintf("%x", hashedObj.Salt)),
+		"slog LogValue":  []byte(hashedObj.LogValue().String()),
+		"PepperedSalt":   []byte(fmt.Sprintf("%v %+v %#v %s %x %q", peppered, peppered, peppered, peppered, peppered, peppered)),
+		"PepperedSaltLV": []byte(slog.AnyValue(peppered).Resolve().String()),
+	}
+
+	encoders := map[string]func() ([]byte, error){
+		"Gob":           hashedObj.Gob,
+		"EncodeBinary":  func() ([]byte, error) { return argonize.EncodeBinary(hashedObj) },
+		"AppendText":    func() ([]byte, error) { return hashedObj.AppendText(nil) },
+		"EncodeCompact": hashedObj.EncodeCompact,
+		"JSON":          func() ([]byte, error) { return json.Marshal(hashedObj) },
+		"Envelope":      func() ([]byte, error) { return argonize.EncodeEnvelope(hashedObj) },
+		"SignHash": func() ([]byte, error) {
+			signed, err := argonize.SignHash(hashedObj, []byte("hmac key"), "user:42")
+
+			return []byte(signed), err
+		},
+		"gob.Encode": func() ([]byte, error) {
+			var buf bytes.Buffer
+
+			return buf.Bytes(), gob.NewEncoder(&buf).Encode(hashedObj)
+		},
+	}
+
+	for name, encode := range encoders {
+		output, err := encode()
+		require.NoError(t, err, name)
+
+		outputs[name] = output
+	}
+
+	for name, output := range outputs {
+		requireNoPepper(t, name, output, pepper)
+	}
+}
+
+func TestPepperedSalt_not_serializable(t *testing.T) {
+	t.Parallel()
+
+	peppered := argonize.Salt("0123456789abcdef").Peppered([]byte("my-secret-pepper"))
+
+	_, err := json.Marshal(peppered)
+	require.ErrorContains(t, err, "PepperedSalt must not be serialized")
+
+	_, err = json.Marshal(struct{ Salt argonize.PepperedSalt }{Salt: peppered})
+	require.ErrorContains(t, err, "PepperedSalt must not be serialized", "nested fields should be refused as well")
+
+	var buf bytes.Buffer
+
+	err = gob.NewEncoder(&buf).Encode(peppered)
+	require.ErrorContains(t, err, "PepperedSalt must not be serialized")
+	requireNoPepper(t, "gob", buf.Bytes(), []byte("my-secret-pepper"))
+
+	require.Equal(t, "argonize.PepperedSalt{[REDACTED]}", fmt.Sprintf("%#v", peppered))
+	require.Equal(t, "[REDACTED]", peppered.String())
+}
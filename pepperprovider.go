@@ -0,0 +1,130 @@
+package argonize
+
+import (
+	"sync"
+
+	"github.com/pkg/errors"
+)
+
+// ============================================================================
+//  Public Variables
+// ============================================================================
+
+// ErrPepperUnavailable is the error returned when the pepper could not be
+// obtained from the PepperProvider. It is distinct from a wrong password, which
+// is not an error. Check it with errors.Is().
+//
+//nolint:gochecknoglobals // sentinel error
+var ErrPepperUnavailable = errors.New("pepper is unavailable")
+
+// ============================================================================
+//  Type: PepperProvider
+// ============================================================================
+
+// PepperProvider provides the pepper from an external secret store, such as a
+// secrets manager, so that the pepper is not hard-coded in the application
+// config and can be rotated.
+//
+// Implementations must be safe for concurrent use.
+type PepperProvider interface {
+	// CurrentPepper returns the ID and the secret of the pepper to hash new
+	// passwords with.
+	CurrentPepper() (id string, secret []byte, err error)
+	// PepperByID returns the secret of the pepper of the ID to verify the
+	// passwords hashed with it. It must keep returning the retired peppers as
+	// long as the hashes peppered with them exist.
+	PepperByID(id string) ([]byte, error)
+}
+
+// ============================================================================
+//  Type: MemoryPepperProvider
+// ============================================================================
+
+// MemoryPepperProvider is a PepperProvider holding the peppers in memory. It is
+// for tests and small deployments that load the peppers at startup.
+type MemoryPepperProvider struct {
+	peppers   map[string][]byte
+	currentID string
+	mutex     sync.RWMutex
+}
+
+// ----------------------------------------------------------------------------
+//  Constructor of MemoryPepperProvider
+// ----------------------------------------------------------------------------
+
+// NewMemoryPepperProvider returns a new MemoryPepperProvider with the pepper of
+// the ID as the current one.
+func NewMemoryPepperProvider(id string, secret []byte) (*MemoryPepperProvider, error) {
+	provider := &MemoryPepperProvider{
+		peppers: make(map[string][]byte),
+	}
+
+	if err := provider.Add(id, secret); err != nil {
+		return nil, err
+	}
+
+	provider.currentID = id
+
+	return provider, nil
+}
+
+// ----------------------------------------------------------------------------
+//  Methods of MemoryPepperProvider
+// ----------------------------------------------------------------------------
+
+// Add adds the pepper of the ID without changing the current one. The secret
+// is copied. It returns an error if the ID or the secret is empty or if the ID
+// already exists, as changing the secret of an ID breaks the hashes peppered
+// with it.
+func (m *MemoryPepperProvider) Add(id string, secret []byte) error {
+	if id == "" || len(secret) == 0 {
+		return errors.New("failed to add pepper: id or secret is empty")
+	}
+
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	if _, ok := m.peppers[id]; ok {
+		return errors.Errorf("failed to add pepper: id %q already exists", id)
+	}
+
+	m.peppers[id] = append([]byte(nil), secret...)
+
+	return nil
+}
+
+// SetCurrent sets the pepper of the ID as the current one to hash new passwords
+// with. The ID must have been added.
+func (m *MemoryPepperProvider) SetCurrent(id string) error {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	if _, ok := m.peppers[id]; !ok {
+		return errors.Errorf("failed to set current pepper: unknown id %q", id)
+	}
+
+	m.currentID = id
+
+	return nil
+}
+
+// CurrentPepper implements PepperProvider.
+func (m *MemoryPepperProvider) CurrentPepper() (string, []byte, error) {
+	m.mutex.RLock()
+	defer m.mutex.RUnlock()
+
+	return m.currentID, m.peppers[m.currentID], nil
+}
+
+// PepperByID implements PepperProvider.
+func (m *MemoryPepperProvider) PepperByID(id string) ([]byte, error) {
+	m.mutex.RLock()
+	defer m.mutex.RUnlock()
+
+	secret, ok := m.peppers[id]
+	if !ok {
+		return nil, errors.Errorf("unknown pepper id %q", id)
+	}
+
+	return secret, nil
+}
@@ -0,0 +1,61 @@
+package argonize_test
+
+import (
+	"testing"
+
+	"github.com/KEINOS/go-argonize"
+	"github.com/stretchr/testify/require"
+)
+
+// ----------------------------------------------------------------------------
+//  MemoryPepperProvider
+// ----------------------------------------------------------------------------
+
+func TestMemoryPepperProvider(t *testing.T) {
+	t.Parallel()
+
+	secret := []byte("pepper1")
+
+	provider, err := argonize.NewMemoryPepperProvider("v1", secret)
+	require.NoError(t, err)
+
+	secret[0] = 'X' // the secret should be copied
+
+	keyID, current, err := provider.CurrentPepper()
+	require.NoError(t, err)
+	require.Equal(t, "v1", keyID)
+	require.Equal(t, []byte("pepper1"), current)
+
+	// Rotate
+	require.NoError(t, provider.Add("v2", []byte("pepper2")))
+	require.NoError(t, provider.SetCurrent("v2"))
+
+	keyID, current, err = provider.CurrentPepper()
+	require.NoError(t, err)
+	require.Equal(t, "v2", keyID)
+	require.Equal(t, []byte("pepper2"), current)
+
+	// Retired peppers are still resolvable
+	old, err := provider.PepperByID("v1")
+	require.NoError(t, err)
+	require.Equal(t, []byte("pepper1"), old)
+}
+
+func TestMemoryPepperProvider_errors(t *testing.T) {
+	t.Parallel()
+
+	_, err := argonize.NewMemoryPepperProvider("", []byte("pepper"))
+	require.ErrorContains(t, err, "id or secret is empty")
+
+	_, err = argonize.NewMemoryPepperProvider("v1", nil)
+	require.ErrorContains(t, err, "id or secret is empty")
+
+	provider, err := argonize.NewMemoryPepperProvider("v1", []byte("pepper"))
+	require.NoError(t, err)
+
+	require.ErrorContains(t, provider.Add("v1", []byte("other")), `id "v1" already exists`)
+	require.ErrorContains(t, provider.SetCurrent("v9"), `unknown id "v9"`)
+
+	_, err = provider.PepperByID("v9")
+	require.ErrorContains(t, err, `unknown pepper id "v9"`)
+}
@@ -0,0 +1,97 @@
+package argonize_test
+
+import (
+	"testing"
+
+	"github.com/KEINOS/go-argonize"
+	"github.com/stretchr/testify/require"
+)
+
+// phcFixtures are the hash strings in the canonical PHC string format: the
+// unpadded standard base64, the "v=19" version and the "m,t,p" order. They
+// were generated with "golang.org/x/crypto/argon2", so they test the decoding
+// and the round trip of the format, not the compatibility with any other
+// implementation.
+//
+// They cover the parameters commonly used by other libraries (argon2id,
+// m=65536, t=3, p=4, 16 bytes salt and 32 bytes hash), argon2i, and the custom
+// salt and hash lengths.
+//
+//nolint:gochecknoglobals // test fixtures
+var phcFixtures = []struct {
+	name     string
+	encoded  string
+	password string
+	params   string
+	saltLen  int
+	hashLen  int
+}{
+	{
+		name:     "common parameters",
+		encoded:  "$argon2id$v=19$m=65536,t=3,p=4$bm9kZS1zYWx0LTE2Ynl0ZQ$xWqmXysg4JrvFTx5Gq/p2/LP9bl67h2EDmI0zu7dOW4",
+		password: "password",
+		params:   "m=65536,t=3,p=4",
+		saltLen:  16,
+		hashLen:  32,
+	},
+	{
+		name:     "argon2i",
+		encoded:  "$argon2i$v=19$m=4096,t=3,p=1$b2xkLW5vZGUtc2FsdC0xNg$98NMTITmc993Ww7fOwATHNzCCeNdnNK8tZjuLk1T298",
+		password: "password",
+		params:   "m=4096,t=3,p=1",
+		saltLen:  16,
+		hashLen:  32,
+	},
+	{
+		name: "custom salt and hash lengths",
+		encoded: "$argon2id$v=19$m=19456,t=2,p=1$bm9kZS1zYWx0LXdpdGgtMzItYnl0ZXMtbGVuZ3RoISE$" +
+			"LXDEcFx7Fn3CquHXJAf6BPSVP5G5LFfX6nvjoP5YL2R8BHrOjU28NY7/1C2IjrLw5xGzjZ8r/ZwN8pn6UHK55g",
+		password: "correct horse battery staple",
+		params:   "m=19456,t=2,p=1",
+		saltLen:  32,
+		hashLen:  64,
+	},
+	{
+		name:     "non-ASCII password",
+		encoded:  "$argon2id$v=19$m=65536,t=3,p=4$dW5pY29kZS1zYWx0LTE2Yg$4v8G1jQ6VL+zlCkaESiT57lrIqxjwMo1d9SZs/j0f2w",
+		password: "パスワード",
+		params:   "m=65536,t=3,p=4",
+		saltLen:  16,
+		hashLen:  32,
+	},
+}
+
+// ----------------------------------------------------------------------------
+//  DecodeHashStrStrict() with the canonical PHC strings
+// ----------------------------------------------------------------------------
+
+func TestDecodeHashStrStrict_phc_fixtures(t *testing.T) {
+	t.Parallel()
+
+	for _, fixture := range phcFixtures {
+		hashedObj, err := argonize.DecodeHashStrStrict(fixture.encoded)
+		require.NoError(t, err, fixture.name)
+
+		require.Equal(t, fixture.params, hashedObj.Params.EncodeParams(), fixture.name)
+		require.Len(t, hashedObj.Salt, fixture.saltLen, fixture.name)
+		require.Len(t, hashedObj.Hash, fixture.hashLen, fixture.name)
+		require.Equal(t, fixture.encoded, hashedObj.String(), "it should round-trip: %s", fixture.name)
+
+		require.True(t, hashedObj.IsValidPassword([]byte(fixture.password)), fixture.name)
+		require.False(t, hashedObj.IsValidPassword([]byte(fixture.password+"x")), fixture.name)
+	}
+}
+
+func TestDecodeHashStrStrict_phc_data(t *testing.T) {
+	t.Parallel()
+
+	// The "data" parameter is placed after the other parameters
+	const encoded = "$argon2id$v=19$m=65536,t=3,p=4,data=YXNzb2NpYXRlZA$" +
+		"bm9kZS1zYWx0LTE2Ynl0ZQ$xWqmXysg4JrvFTx5Gq/p2/LP9bl67h2EDmI0zu7dOW4"
+
+	hashedObj, err := argonize.DecodeHashStrStrict(encoded)
+	require.NoError(t, err)
+	require.Equal(t, "associated", hashedObj.Data)
+	require.Equal(t, "m=65536,t=3,p=4", hashedObj.Params.EncodeParams())
+	require.Equal(t, encoded, hashedObj.String(), "it should round-trip")
+}
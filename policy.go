@@ -0,0 +1,128 @@
+package argonize
+
+import (
+	"fmt"
+
+	"github.com/pkg/errors"
+)
+
+// ErrBelowFloor is the error of a hash whose parameters are below the floor of
+// Hashed.IsValidPasswordMin(). Check it with errors.Is().
+//
+//nolint:gochecknoglobals // sentinel error
+var ErrBelowFloor = errors.New("params are below the floor")
+
+// ============================================================================
+//  Type: Policy
+// ============================================================================
+
+// Policy holds the acceptable bounds of the Argon2id parameters.
+//
+// It is used to check the parameters of an externally supplied hash before
+// running the expensive key derivation. A zero value of a field means that the
+// bound is not checked. For example, a zero MaxMemoryCost means no upper limit
+// for the memory cost.
+type Policy struct {
+	// MinMemoryCost and MaxMemoryCost are the bounds of the memory cost in KiB.
+	MinMemoryCost uint32
+	MaxMemoryCost uint32
+	// MinIterations and MaxIterations are the bounds of the number of passes.
+	MinIterations uint32
+	MaxIterations uint32
+	// MinKeyLength and MaxKeyLength are the bounds of the hash length in bytes.
+	MinKeyLength uint32
+	MaxKeyLength uint32
+	// MinSaltLength and MaxSaltLength are the bounds of the salt length in bytes.
+	MinSaltLength uint32
+	MaxSaltLength uint32
+	// MinParallelism and MaxParallelism are the bounds of the number of lanes.
+	MinParallelism uint8
+	MaxParallelism uint8
+}
+
+// ----------------------------------------------------------------------------
+//  Methods of Hashed
+// ----------------------------------------------------------------------------
+
+// MeetsPolicy returns nil if the parameters of the hash are within the bounds
+// of the given policy. Otherwise, it returns an error describing the first
+// parameter out of the bounds.
+//
+// It does not run the key derivation, so it is cheap to call. Use it right
+// after decoding an externally supplied hash and before IsValidPassword() to
+// reject weak or resource-exhausting parameters at the gate.
+func (h *Hashed) MeetsPolicy(policy Policy) error {
+	if h == nil || h.Params == nil {
+		return errors.New("the hash has no parameters to check")
+	}
+
+	params := h.Params
+
+	if err := checkBounds("memory cost", params.MemoryCost,
+		policy.MinMemoryCost, policy.MaxMemoryCost); err != nil {
+		return err
+	}
+
+	if err := checkBounds("iterations", params.Iterations,
+		policy.MinIterations, policy.MaxIterations); err != nil {
+		return err
+	}
+
+	if err := checkBounds("parallelism", uint32(params.Parallelism),
+		uint32(policy.MinParallelism), uint32(policy.MaxParallelism)); err != nil {
+		return err
+	}
+
+	if err := checkBounds("key length", params.KeyLength,
+		policy.MinKeyLength, policy.MaxKeyLength); err != nil {
+		return err
+	}
+
+	return checkBounds("salt length", params.SaltLength,
+		policy.MinSaltLength, policy.MaxSaltLength)
+}
+
+// IsValidPasswordMin is similar to IsValidPassword() but refuses to verify if
+// the parameters of the hash are below the floor. It protects against the
+// downgrade of an untrusted hash to cheap parameters such as "t=1,m=8", which
+// turns the verification into a resource amplification gadget.
+//
+// The non-zero memory cost, iterations, parallelism, key length and salt length
+// of the floor are the minimums. It returns an error wrapping ErrBelowFloor
+// without running the key derivation if any of them is not met.
+func (h *Hashed) IsValidPasswordMin(password []byte, floor *Params) (bool, error) {
+	if floor == nil {
+		return false, errors.New("failed to verify password: the floor is nil")
+	}
+
+	err := h.MeetsPolicy(Policy{
+		MinMemoryCost:  floor.MemoryCost,
+		MinIterations:  floor.Iterations,
+		MinKeyLength:   floor.KeyLength,
+		MinSaltLength:  floor.SaltLength,
+		MinParallelism: floor.Parallelism,
+	})
+	if err != nil {
+		return false, fmt.Errorf("failed to verify password: %w: %w", ErrBelowFloor, err)
+	}
+
+	return h.IsValidPassword(password), nil
+}
+
+// ----------------------------------------------------------------------------
+//  Private Functions
+// ----------------------------------------------------------------------------
+
+// checkBounds returns an error if value is out of the min and max bounds. Zero
+// bounds are not checked.
+func checkBounds(name string, value, minVal, maxVal uint32) error {
+	if minVal != 0 && value < minVal {
+		return errors.Errorf("%s %d is below the policy minimum %d", name, value, minVal)
+	}
+
+	if maxVal != 0 && value > maxVal {
+		return errors.Errorf("%s %d exceeds the policy maximum %d", name, value, maxVal)
+	}
+
+	return nil
+}
@@ -0,0 +1,123 @@
+package argonize_test
+
+import (
+	"testing"
+
+	"github.com/KEINOS/go-argonize"
+	"github.com/stretchr/testify/require"
+)
+
+// ----------------------------------------------------------------------------
+//  Hashed.MeetsPolicy()
+// ----------------------------------------------------------------------------
+
+func TestHashed_MeetsPolicy(t *testing.T) {
+	t.Parallel()
+
+	// m=65536, t=3, p=2, salt=16, key=32
+	hashedObj, err := argonize.DecodeHashStr(
+		"$argon2id$v=19$m=65536,t=3,p=2$Woo1mErn1s7AHf96ewQ8Uw$D4TzIwGO4XD2buk96qAP+Ed2baMo/KbTRMqXX00wtsU",
+	)
+	require.NoError(t, err)
+
+	for _, test := range []struct {
+		policy     argonize.Policy
+		msgContain string
+		errMsg     string
+	}{
+		{argonize.Policy{}, "", "zero policy should accept any params"},
+		{
+			argonize.Policy{MinMemoryCost: 65536, MaxMemoryCost: 65536, MinIterations: 3, MaxIterations: 3},
+			"", "params equal to the bounds should be accepted",
+		},
+		{argonize.Policy{MinMemoryCost: 128 * 1024}, "memory cost 65536 is below the policy minimum", "weak memory should be an error"},
+		{argonize.Policy{MaxMemoryCost: 1024}, "memory cost 65536 exceeds the policy maximum", "huge memory should be an error"},
+		{argonize.Policy{MinIterations: 4}, "iterations 3 is below", "weak iterations should be an error"},
+		{argonize.Policy{MaxIterations: 2}, "iterations 3 exceeds", "huge iterations should be an error"},
+		{argonize.Policy{MinParallelism: 4}, "parallelism 2 is below", "low parallelism should be an error"},
+		{argonize.Policy{MaxParallelism: 1}, "parallelism 2 exceeds", "high parallelism should be an error"},
+		{argonize.Policy{MinKeyLength: 64}, "key length 32 is below", "short key should be an error"},
+		{argonize.Policy{MaxSaltLength: 8}, "salt length 16 exceeds", "long salt should be an error"},
+	} {
+		err := hashedObj.MeetsPolicy(test.policy)
+
+		if test.msgContain == "" {
+			require.NoError(t, err, test.errMsg)
+
+			continue
+		}
+
+		require.Error(t, err, test.errMsg)
+		require.Contains(t, err.Error(), test.msgContain, test.errMsg)
+	}
+}
+
+func TestHashed_MeetsPolicy_no_params(t *testing.T) {
+	t.Parallel()
+
+	err := new(argonize.Hashed).MeetsPolicy(argonize.Policy{})
+
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "the hash has no parameters to check")
+}
+
+// ----------------------------------------------------------------------------
+//  Hashed.IsValidPasswordMin()
+// ----------------------------------------------------------------------------
+
+func TestHashed_IsValidPasswordMin(t *testing.T) {
+	t.Parallel()
+
+	password := []byte("password")
+
+	params := lowCostParams() // m=64, t=1, p=1
+	params.Iterations = 2
+
+	hashedObj := argonize.HashCustom(password, []byte("0123456789abcdef"), params)
+	require.NotNil(t, hashedObj)
+
+	for _, floor := range []*argonize.Params{
+		{},
+		{MemoryCost: 64, Iterations: 2, Parallelism: 1, KeyLength: 32, SaltLength: 16},
+		{MemoryCost: 32, Iterations: 1},
+	} {
+		isValid, err := hashedObj.IsValidPasswordMin(password, floor)
+		require.NoError(t, err, "floor: %+v", floor)
+		require.True(t, isValid)
+
+		isValid, err = hashedObj.IsValidPasswordMin([]byte("wrong"), floor)
+		require.NoError(t, err)
+		require.False(t, isValid)
+	}
+}
+
+func TestHashed_IsValidPasswordMin_below_floor(t *testing.T) {
+	t.Parallel()
+
+	// A forged hash with cheap parameters
+	params := lowCostParams()
+	params.MemoryCost = 8
+
+	forged := argonize.HashCustom([]byte("password"), []byte("0123456789abcdef"), params)
+	require.NotNil(t, forged)
+
+	for floor, msgContain := range map[*argonize.Params]string{
+		argonize.NewParams():            "memory cost 8 is below the policy minimum 65536",
+		{Iterations: 3}:                 "iterations 1 is below",
+		{Parallelism: 2}:                "parallelism 1 is below",
+		{KeyLength: 64}:                 "key length 32 is below",
+		{SaltLength: 32}:                "salt length 16 is below",
+		{MemoryCost: 64, Iterations: 1}: "memory cost 8 is below",
+	} {
+		isValid, err := forged.IsValidPasswordMin([]byte("password"), floor)
+		require.ErrorIs(t, err, argonize.ErrBelowFloor)
+		require.ErrorContains(t, err, msgContain)
+		require.False(t, isValid, "it should refuse rather than verify")
+	}
+
+	_, err := forged.IsValidPasswordMin([]byte("password"), nil)
+	require.ErrorContains(t, err, "the floor is nil")
+
+	_, err = new(argonize.Hashed).IsValidPasswordMin([]byte("password"), argonize.NewParams())
+	require.ErrorIs(t, err, argonize.ErrBelowFloor)
+}
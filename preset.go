@@ -0,0 +1,86 @@
+package argonize
+
+// ============================================================================
+//  Type: Preset
+// ============================================================================
+
+// Preset is a named set of the recommended Argon2id parameters.
+type Preset int
+
+const (
+	// PresetRFC9106First is the FIRST RECOMMENDED option of RFC 9106. It uses
+	// 2 GiB of memory with t=1 and p=4. Use it if much memory is available.
+	//
+	// Ref: https://www.rfc-editor.org/rfc/rfc9106.html#section-4
+	PresetRFC9106First Preset = iota + 1
+	// PresetRFC9106Second is the SECOND RECOMMENDED option of RFC 9106. It uses
+	// 64 MiB of memory with t=3 and p=4. Use it if much memory is not available.
+	//
+	// Ref: https://www.rfc-editor.org/rfc/rfc9106.html#section-4
+	PresetRFC9106Second
+)
+
+// ----------------------------------------------------------------------------
+//  Methods of Preset
+// ----------------------------------------------------------------------------
+
+// Params returns a new Params object with the parameters of the preset. It
+// returns nil if the preset is unknown.
+func (pr Preset) Params() *Params {
+	params := NewParams()
+
+	switch pr {
+	case PresetRFC9106First:
+		params.Iterations = 1
+		params.MemoryCost = MemoryCost2GiB
+		params.Parallelism = 4
+	case PresetRFC9106Second:
+		params.Iterations = 3
+		params.MemoryCost = MemoryCost64MiB
+		params.Parallelism = 4
+	default:
+		return nil
+	}
+
+	// Both presets use 128-bit salt and 256-bit tag.
+	params.SaltLength = 16
+	params.KeyLength = 32
+
+	return params
+}
+
+// String returns the name of the preset.
+func (pr Preset) String() string {
+	switch pr {
+	case PresetRFC9106First:
+		return "RFC9106First"
+	case PresetRFC9106Second:
+		return "RFC9106Second"
+	default:
+		return "unknown"
+	}
+}
+
+// ----------------------------------------------------------------------------
+//  Methods of Hashed
+// ----------------------------------------------------------------------------
+
+// IsRFC9106 returns true if the parameters of the hash exactly match the given
+// recommended preset of RFC 9106. It compares the variant, memory cost,
+// iterations, parallelism, key length and salt length. Both presets are of
+// Argon2id, so the hashes of the other variants never match.
+//
+// It is useful to find the hashes using custom or weak parameters.
+func (h *Hashed) IsRFC9106(which Preset) bool {
+	preset := which.Params()
+	if preset == nil || h == nil || h.Params == nil {
+		return false
+	}
+
+	return h.Params.Variant.String() == string(VariantArgon2id) &&
+		h.Params.MemoryCost == preset.MemoryCost &&
+		h.Params.Iterations == preset.Iterations &&
+		h.Params.Parallelism == preset.Parallelism &&
+		h.Params.KeyLength == preset.KeyLength &&
+		h.Params.SaltLength == preset.SaltLength
+}
@@ -0,0 +1,90 @@
+package argonize_test
+
+import (
+	"testing"
+
+	"github.com/KEINOS/go-argonize"
+	"github.com/stretchr/testify/require"
+)
+
+// ----------------------------------------------------------------------------
+//  Preset
+// ----------------------------------------------------------------------------
+
+func TestPreset_unknown(t *testing.T) {
+	t.Parallel()
+
+	unknown := argonize.Preset(0)
+
+	require.Nil(t, unknown.Params(), "unknown preset should return nil params")
+	require.Equal(t, "unknown", unknown.String())
+	require.Equal(t, "RFC9106Second", argonize.PresetRFC9106Second.String())
+}
+
+func TestPreset_Params_not_aliased(t *testing.T) {
+	t.Parallel()
+
+	params := argonize.PresetRFC9106Second.Params()
+	params.Iterations = 1
+
+	require.Equal(t, uint32(3), argonize.PresetRFC9106Second.Params().Iterations,
+		"modifying the returned params should not affect the preset")
+}
+
+// ----------------------------------------------------------------------------
+//  Hashed.IsRFC9106()
+// ----------------------------------------------------------------------------
+
+func TestHashed_IsRFC9106(t *testing.T) {
+	t.Parallel()
+
+	for _, test := range []struct {
+		encoded string
+		preset  argonize.Preset
+		expect  bool
+	}{
+		// m=64MiB, t=3, p=4, salt=16, key=32
+		{
+			"$argon2id$v=19$m=65536,t=3,p=4$Woo1mErn1s7AHf96ewQ8Uw$D4TzIwGO4XD2buk96qAP+Ed2baMo/KbTRMqXX00wtsU",
+			argonize.PresetRFC9106Second, true,
+		},
+		{
+			"$argon2id$v=19$m=65536,t=3,p=4$Woo1mErn1s7AHf96ewQ8Uw$D4TzIwGO4XD2buk96qAP+Ed2baMo/KbTRMqXX00wtsU",
+			argonize.PresetRFC9106First, false,
+		},
+		// m=2GiB, t=1, p=4, salt=16, key=32
+		{
+			"$argon2id$v=19$m=2097152,t=1,p=4$Woo1mErn1s7AHf96ewQ8Uw$D4TzIwGO4XD2buk96qAP+Ed2baMo/KbTRMqXX00wtsU",
+			argonize.PresetRFC9106First, true,
+		},
+		// Default params of the package (p=2)
+		{
+			"$argon2id$v=19$m=65536,t=3,p=2$Woo1mErn1s7AHf96ewQ8Uw$D4TzIwGO4XD2buk96qAP+Ed2baMo/KbTRMqXX00wtsU",
+			argonize.PresetRFC9106Second, false,
+		},
+		// Salt length of 8
+		{
+			"$argon2id$v=19$m=65536,t=3,p=4$Woo1mErn1s4$D4TzIwGO4XD2buk96qAP+Ed2baMo/KbTRMqXX00wtsU",
+			argonize.PresetRFC9106Second, false,
+		},
+		// Argon2i with the parameters of the preset
+		{
+			"$argon2i$v=19$m=65536,t=3,p=4$Woo1mErn1s7AHf96ewQ8Uw$D4TzIwGO4XD2buk96qAP+Ed2baMo/KbTRMqXX00wtsU",
+			argonize.PresetRFC9106Second, false,
+		},
+	} {
+		hashedObj, err := argonize.DecodeHashStr(test.encoded)
+		require.NoError(t, err)
+
+		require.Equal(t, test.expect, hashedObj.IsRFC9106(test.preset), test.encoded)
+	}
+
+	require.False(t, new(argonize.Hashed).IsRFC9106(argonize.PresetRFC9106Second),
+		"hash without params should not match")
+
+	// The explicit name of the default variant should match
+	params := argonize.PresetRFC9106Second.Params()
+	params.Variant = argonize.VariantArgon2id
+
+	require.True(t, (&argonize.Hashed{Params: params}).IsRFC9106(argonize.PresetRFC9106Second))
+}
@@ -0,0 +1,60 @@
+package argonize
+
+import (
+	"github.com/pkg/errors"
+)
+
+// ProfileNameMax is the maximum length of the profile name of
+// Hasher.WithProfileName().
+const ProfileNameMax = 32
+
+// ----------------------------------------------------------------------------
+//  Methods of Hashed
+// ----------------------------------------------------------------------------
+
+// ProfileName returns the name of the parameter profile recorded by
+// Hasher.WithProfileName(). It returns an empty string if h is nil or if the
+// hash has no profile, such as the hashes made before the profiles were used.
+//
+// The profile is metadata and not a part of the key derivation. It is not
+// authenticated, so do not trust it for anything but the rehash decisions.
+func (h *Hashed) ProfileName() string {
+	if h == nil {
+		return ""
+	}
+
+	return h.Data
+}
+
+// NeedsRehashProfile returns true if the hash was not made with the current
+// profile, so that the hash can be upgraded on the next successful login. The
+// hashes without a profile are always reported as drifted.
+//
+//	if ok && hashed.NeedsRehashProfile("interactive-2024") {
+//	    // rehash the password with the current Hasher and store it
+//	}
+func (h *Hashed) NeedsRehashProfile(current string) bool {
+	return h.ProfileName() != current
+}
+
+// ----------------------------------------------------------------------------
+//  Private Functions
+// ----------------------------------------------------------------------------
+
+// validateProfileName returns an error if the name is empty, too long or has a
+// character other than ASCII letters, digits, ".", "_" and "-".
+func validateProfileName(name string) error {
+	if name == "" || len(name) > ProfileNameMax {
+		return errors.Errorf("profile name must be 1 to %d characters long, got %d", ProfileNameMax, len(name))
+	}
+
+	for i := 0; i < len(name); i++ {
+		switch c := name[i]; {
+		case 'a' <= c && c <= 'z', 'A' <= c && c <= 'Z', '0' <= c && c <= '9', c == '.', c == '_', c == '-':
+		default:
+			return errors.Errorf("profile name %q has an invalid character %q", name, c)
+		}
+	}
+
+	return nil
+}
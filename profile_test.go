@@ -0,0 +1,130 @@
+package argonize_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/KEINOS/go-argonize"
+	"github.com/stretchr/testify/require"
+)
+
+// ----------------------------------------------------------------------------
+//  Hasher.WithProfileName()
+// ----------------------------------------------------------------------------
+
+func TestHasher_WithProfileName(t *testing.T) {
+	t.Parallel()
+
+	password := []byte("password")
+
+	hashed, err := argonize.NewHasher(lowCostParams()).WithProfileName("interactive-2024").Hash(password)
+	require.NoError(t, err)
+	require.Equal(t, "interactive-2024", hashed.ProfileName())
+
+	encoded := hashed.String()
+	require.Contains(t, encoded, ",data=aW50ZXJhY3RpdmUtMjAyNA$", "data should be the base64 of the name")
+
+	decoded, err := argonize.DecodeHashStrStrict(encoded)
+	require.NoError(t, err)
+	require.Equal(t, "interactive-2024", decoded.ProfileName())
+	require.Equal(t, encoded, decoded.String())
+	require.True(t, decoded.IsValidPassword(password))
+
+	// The profile is not a part of the key derivation
+	decoded.Data = "batch-2025"
+	require.True(t, decoded.IsValidPassword(password))
+
+	decoded.Data = ""
+	require.True(t, decoded.IsValidPassword(password))
+
+	out, err := argonize.EncodeBinary(hashed)
+	require.NoError(t, err)
+
+	decoded, err = argonize.DecodeBinary(out)
+	require.NoError(t, err)
+	require.Equal(t, "interactive-2024", decoded.ProfileName(), "binary encoding should keep the data")
+}
+
+func TestHasher_WithProfileName_with_pepper(t *testing.T) {
+	t.Parallel()
+
+	provider, err := argonize.NewMemoryPepperProvider("v1", []byte("secret pepper"))
+	require.NoError(t, err)
+
+	hasher := argonize.NewHasher(lowCostParams()).WithPepperProvider(provider).WithProfileName("p1")
+
+	hashed, err := hasher.Hash([]byte("password"))
+	require.NoError(t, err)
+	require.Contains(t, hashed.String(), ",keyid=djE,data=cDE$", "keyid should precede data")
+
+	decoded, err := argonize.DecodeHashStr(hashed.String())
+	require.NoError(t, err)
+	require.Equal(t, "v1", decoded.KeyID)
+	require.Equal(t, "p1", decoded.ProfileName())
+
+	ok, err := hasher.Verify(decoded, []byte("password"))
+	require.NoError(t, err)
+	require.True(t, ok)
+}
+
+func TestHasher_WithProfileName_invalid(t *testing.T) {
+	t.Parallel()
+
+	for _, name := range []string{
+		strings.Repeat("a", argonize.ProfileNameMax+1),
+		"with space",
+		"comma,name",
+		"dollar$name",
+		"ünicode",
+	} {
+		hashed, err := argonize.NewHasher(lowCostParams()).WithProfileName(name).Hash([]byte("password"))
+
+		require.ErrorContains(t, err, "failed to hash the password: profile name", "name: %q", name)
+		require.Nil(t, hashed)
+	}
+
+	hashed, err := argonize.NewHasher(lowCostParams()).WithProfileName(strings.Repeat("a", argonize.ProfileNameMax)).
+		Hash([]byte("password"))
+	require.NoError(t, err)
+	require.Len(t, hashed.ProfileName(), argonize.ProfileNameMax)
+}
+
+// ----------------------------------------------------------------------------
+//  DecodeHashStr() with data
+// ----------------------------------------------------------------------------
+
+func TestDecodeHashStr_data(t *testing.T) {
+	t.Parallel()
+
+	for _, bad := range []string{
+		strings.Replace(sampleHashStr, "p=2$", "p=2,data=%%$", 1),
+		strings.Replace(sampleHashStr, "p=2$", "p=2,data=$", 1),
+		strings.Replace(sampleHashStr, "p=2$", "p=2,data=cDE,keyid=djE$", 1),
+	} {
+		_, err := argonize.DecodeHashStr(bad)
+		requireParseError(t, err, argonize.ErrMissingParams, argonize.SegmentParams)
+	}
+}
+
+// ----------------------------------------------------------------------------
+//  Hashed.NeedsRehashProfile()
+// ----------------------------------------------------------------------------
+
+func TestHashed_NeedsRehashProfile(t *testing.T) {
+	t.Parallel()
+
+	legacy, err := argonize.DecodeHashStr(sampleHashStr)
+	require.NoError(t, err)
+	require.Empty(t, legacy.ProfileName())
+	require.True(t, legacy.NeedsRehashProfile("interactive-2024"), "hash without profile should drift")
+
+	hashed, err := argonize.NewHasher(lowCostParams()).WithProfileName("interactive-2024").Hash([]byte("password"))
+	require.NoError(t, err)
+	require.False(t, hashed.NeedsRehashProfile("interactive-2024"))
+	require.True(t, hashed.NeedsRehashProfile("interactive-2025"))
+
+	var nilHashed *argonize.Hashed
+
+	require.Empty(t, nilHashed.ProfileName())
+	require.True(t, nilHashed.NeedsRehashProfile("interactive-2024"))
+}
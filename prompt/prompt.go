@@ -0,0 +1,229 @@
+/*
+Package prompt reads passwords from the terminal for the CLI tools using the
+argonize package.
+
+It implements the "prompt, disable echo, read, confirm" routine once, so that
+every CLI does not have to. The echo is restored on all paths, including the
+interrupt and termination signals. If the standard input is not a terminal,
+such as a pipe, the password is read as a plain line.
+
+	password, err := prompt.ReadPasswordConfirm("Password: ", "Confirm: ")
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	hashedObj, err := argonize.Hash(password)
+
+The terminal is accessed through the Terminal interface, so the Prompter can be
+driven without a real TTY in tests. The echo is disabled with the
+"golang.org/x/term" package, so it is supported on all of its platforms,
+including Windows.
+
+It is a separate module to keep the core argonize package free of
+"golang.org/x/term".
+*/
+package prompt
+
+import (
+	"io"
+	"os"
+
+	"github.com/pkg/errors"
+)
+
+// ============================================================================
+//  Public Variables
+// ============================================================================
+
+// ErrMismatch is the error of a confirmation not matching the password. Check
+// it with errors.Is().
+//
+//nolint:gochecknoglobals // sentinel error
+var ErrMismatch = errors.New("passwords do not match")
+
+// ============================================================================
+//  Type: Terminal
+// ============================================================================
+
+// Terminal is the terminal to read the passwords from.
+type Terminal interface {
+	// IsTerminal reports whether the input is a terminal. If false, the
+	// Prompter reads a plain line from its Input instead of ReadPassword().
+	IsTerminal() bool
+	// ReadPassword reads a line with the echo disabled and returns it without
+	// the line ending. It must restore the terminal state on all paths.
+	ReadPassword() ([]byte, error)
+}
+
+// ============================================================================
+//  Type: Prompter
+// ============================================================================
+
+// Prompter prompts for the passwords. The zero value uses the standard input
+// and prints the prompts to the standard error.
+type Prompter struct {
+	// Terminal is the terminal to read the passwords from. If nil, the
+	// standard input is used.
+	Terminal Terminal
+	// Input is read if Terminal is not a terminal. If nil, the standard input
+	// is used.
+	Input io.Reader
+	// Output is where the prompts are written to. If nil, the standard error
+	// is used, so that the standard output can be piped.
+	Output io.Writer
+}
+
+// ============================================================================
+//  Functions
+// ============================================================================
+
+// ReadPasswordPrompt prints the prompt and reads a password from the standard
+// input without echo. It is a shorthand of Prompter.ReadPassword() of the zero
+// value Prompter.
+func ReadPasswordPrompt(prompt string) ([]byte, error) {
+	return new(Prompter).ReadPassword(prompt)
+}
+
+// ReadPasswordConfirm is similar to ReadPasswordPrompt() but reads the password
+// twice. It returns an error wrapping ErrMismatch if they differ. It is a
+// shorthand of Prompter.ReadPasswordConfirm() of the zero value Prompter.
+func ReadPasswordConfirm(prompt, confirmPrompt string) ([]byte, error) {
+	return new(Prompter).ReadPasswordConfirm(prompt, confirmPrompt)
+}
+
+// ----------------------------------------------------------------------------
+//  Methods of Prompter
+// ----------------------------------------------------------------------------
+
+// ReadPassword prints the prompt and reads a password.
+//
+// If the input is a terminal, the echo is disabled while reading and a newline
+// is printed afterwards, since the one typed is not echoed. Otherwise, a plain
+// line is read from the Input. The line ending, "\n" or "\r\n", is trimmed. It
+// returns io.EOF if the input ended before any character.
+func (p *Prompter) ReadPassword(prompt string) ([]byte, error) {
+	out := p.output()
+
+	if _, err := io.WriteString(out, prompt); err != nil {
+		return nil, errors.Wrap(err, "failed to print the prompt")
+	}
+
+	term := p.terminal()
+	if !term.IsTerminal() {
+		return readLine(p.input())
+	}
+
+	password, err := term.ReadPassword()
+
+	// Move to the next line as the typed one was not echoed.
+	_, _ = io.WriteString(out, "\n")
+
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to read the password")
+	}
+
+	return password, nil
+}
+
+// ReadPasswordConfirm is similar to ReadPassword() but reads the password twice
+// with the prompt and the confirmPrompt. It returns an error wrapping
+// ErrMismatch if they differ. The buffers other than the returned one are
+// zeroed.
+func (p *Prompter) ReadPasswordConfirm(prompt, confirmPrompt string) ([]byte, error) {
+	password, err := p.ReadPassword(prompt)
+	if err != nil {
+		return nil, err
+	}
+
+	confirm, err := p.ReadPassword(confirmPrompt)
+	defer clear(confirm)
+
+	if err != nil {
+		clear(password)
+
+		return nil, err
+	}
+
+	if string(password) != string(confirm) {
+		clear(password)
+
+		return nil, ErrMismatch
+	}
+
+	return password, nil
+}
+
+// ----------------------------------------------------------------------------
+//  Methods of Prompter (Private)
+// ----------------------------------------------------------------------------
+
+func (p *Prompter) terminal() Terminal {
+	if p.Terminal != nil {
+		return p.Terminal
+	}
+
+	return stdinTerminal{fd: int(os.Stdin.Fd())}
+}
+
+func (p *Prompter) input() io.Reader {
+	if p.Input != nil {
+		return p.Input
+	}
+
+	return os.Stdin
+}
+
+func (p *Prompter) output() io.Writer {
+	if p.Output != nil {
+		return p.Output
+	}
+
+	return os.Stderr
+}
+
+// ----------------------------------------------------------------------------
+//  Private Functions
+// ----------------------------------------------------------------------------
+
+// readLine reads r up to the newline byte by byte, so that nothing after the
+// line is consumed and the next line can be read from r. The line ending is
+// trimmed. It returns io.EOF if r ended before any byte.
+func readLine(r io.Reader) ([]byte, error) {
+	var (
+		char [1]byte
+		line []byte
+	)
+
+	for {
+		n, err := r.Read(char[:])
+		if n > 0 {
+			if char[0] == '\n' {
+				break
+			}
+
+			line = append(line, char[0])
+
+			continue
+		}
+
+		if errors.Is(err, io.EOF) && len(line) > 0 {
+			break
+		}
+
+		if err != nil {
+			clear(line)
+
+			if errors.Is(err, io.EOF) {
+				return nil, io.EOF
+			}
+
+			return nil, errors.Wrap(err, "failed to read the password")
+		}
+	}
+
+	if len(line) > 0 && line[len(line)-1] == '\r' {
+		line = line[:len(line)-1]
+	}
+
+	return line, nil
+}
@@ -0,0 +1,172 @@
+package prompt_test
+
+import (
+	"bytes"
+	"io"
+	"strings"
+	"testing"
+	"testing/iotest"
+
+	"github.com/KEINOS/go-argonize/prompt"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeTerminal is a Terminal returning the lines in order.
+type fakeTerminal struct {
+	err      error
+	lines    []string
+	notATerm bool
+}
+
+func (f *fakeTerminal) IsTerminal() bool {
+	return !f.notATerm
+}
+
+func (f *fakeTerminal) ReadPassword() ([]byte, error) {
+	if f.err != nil {
+		return nil, f.err
+	}
+
+	if len(f.lines) == 0 {
+		return nil, io.EOF
+	}
+
+	line := f.lines[0]
+	f.lines = f.lines[1:]
+
+	return []byte(line), nil
+}
+
+// ----------------------------------------------------------------------------
+//  Prompter.ReadPassword()
+// ----------------------------------------------------------------------------
+
+func TestPrompter_ReadPassword_terminal(t *testing.T) {
+	t.Parallel()
+
+	out := new(bytes.Buffer)
+	prompter := &prompt.Prompter{
+		Terminal: &fakeTerminal{lines: []string{"secret"}},
+		Output:   out,
+	}
+
+	password, err := prompter.ReadPassword("Password: ")
+	require.NoError(t, err)
+	require.Equal(t, []byte("secret"), password)
+	require.Equal(t, "Password: \n", out.String(), "it should move to the next line")
+}
+
+func TestPrompter_ReadPassword_terminal_error(t *testing.T) {
+	t.Parallel()
+
+	prompter := &prompt.Prompter{
+		Terminal: &fakeTerminal{err: errors.New("forced failure")},
+		Output:   io.Discard,
+	}
+
+	password, err := prompter.ReadPassword("Password: ")
+	require.ErrorContains(t, err, "forced failure")
+	require.Nil(t, password)
+}
+
+func TestPrompter_ReadPassword_not_a_terminal(t *testing.T) {
+	t.Parallel()
+
+	out := new(bytes.Buffer)
+	prompter := &prompt.Prompter{
+		Terminal: &fakeTerminal{notATerm: true},
+		Input:    strings.NewReader("first\r\nsecond\nthird"),
+		Output:   out,
+	}
+
+	for _, expect := range []string{"first", "second", "third"} {
+		password, err := prompter.ReadPassword("> ")
+		require.NoError(t, err)
+		require.Equal(t, expect, string(password))
+	}
+
+	_, err := prompter.ReadPassword("> ")
+	require.ErrorIs(t, err, io.EOF)
+	require.Equal(t, "> > > > ", out.String(), "no newline should be added to the plain input")
+}
+
+func TestPrompter_ReadPassword_not_a_terminal_read_error(t *testing.T) {
+	t.Parallel()
+
+	prompter := &prompt.Prompter{
+		Terminal: &fakeTerminal{notATerm: true},
+		Input:    iotest.ErrReader(errors.New("forced failure")),
+		Output:   io.Discard,
+	}
+
+	_, err := prompter.ReadPassword("> ")
+	require.ErrorContains(t, err, "forced failure")
+}
+
+func TestPrompter_ReadPassword_output_error(t *testing.T) {
+	t.Parallel()
+
+	prompter := &prompt.Prompter{
+		Terminal: &fakeTerminal{lines: []string{"secret"}},
+		Output:   failingWriter{},
+	}
+
+	_, err := prompter.ReadPassword("Password: ")
+	require.ErrorContains(t, err, "failed to print the prompt")
+}
+
+// ----------------------------------------------------------------------------
+//  Prompter.ReadPasswordConfirm()
+// ----------------------------------------------------------------------------
+
+func TestPrompter_ReadPasswordConfirm(t *testing.T) {
+	t.Parallel()
+
+	for _, test := range []struct {
+		term      *fakeTerminal
+		expect    string
+		expectErr error
+	}{
+		{&fakeTerminal{lines: []string{"secret", "secret"}}, "secret", nil},
+		{&fakeTerminal{lines: []string{"secret", "Secret"}}, "", prompt.ErrMismatch},
+		{&fakeTerminal{lines: []string{"secret"}}, "", io.EOF},
+	} {
+		out := new(bytes.Buffer)
+		prompter := &prompt.Prompter{Terminal: test.term, Output: out}
+
+		password, err := prompter.ReadPasswordConfirm("Password: ", "Confirm: ")
+
+		if test.expectErr != nil {
+			require.ErrorIs(t, err, test.expectErr)
+			require.Nil(t, password)
+
+			continue
+		}
+
+		require.NoError(t, err)
+		require.Equal(t, test.expect, string(password))
+		require.Equal(t, "Password: \nConfirm: \n", out.String())
+	}
+}
+
+func TestPrompter_ReadPasswordConfirm_not_a_terminal(t *testing.T) {
+	t.Parallel()
+
+	prompter := &prompt.Prompter{
+		Terminal: &fakeTerminal{notATerm: true},
+		Input:    strings.NewReader("secret\nsecret\n"),
+		Output:   io.Discard,
+	}
+
+	password, err := prompter.ReadPasswordConfirm("Password: ", "Confirm: ")
+	require.NoError(t, err)
+	require.Equal(t, "secret", string(password))
+}
+
+// failingWriter is an io.Writer that always fails.
+type failingWriter struct{}
+
+func (failingWriter) Write([]byte) (int, error) {
+	return 0, errors.New("forced failure")
+}
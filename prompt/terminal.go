@@ -0,0 +1,79 @@
+package prompt
+
+import (
+	"os"
+	"os/signal"
+	"sync"
+	"syscall"
+
+	"github.com/pkg/errors"
+	"golang.org/x/term"
+)
+
+// stdinTerminal is the Terminal of the file descriptor, which is the standard
+// input by default.
+type stdinTerminal struct {
+	fd int
+}
+
+// IsTerminal implements Terminal.
+func (t stdinTerminal) IsTerminal() bool {
+	return term.IsTerminal(t.fd)
+}
+
+// ReadPassword implements Terminal. The terminal state is restored on return
+// and on the SIGINT, SIGTERM and SIGHUP signals. On a signal, it is sent again
+// after restoring the state to let the default action or the handlers of the
+// program run.
+func (t stdinTerminal) ReadPassword() ([]byte, error) {
+	state, err := term.GetState(t.fd)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get the terminal state")
+	}
+
+	restore := sync.OnceFunc(func() {
+		_ = term.Restore(t.fd, state)
+	})
+	defer restore()
+
+	sigs := make(chan os.Signal, 1)
+	done := make(chan struct{})
+
+	signal.Notify(sigs, os.Interrupt, syscall.SIGTERM, syscall.SIGHUP)
+
+	defer func() {
+		signal.Stop(sigs)
+		close(done)
+	}()
+
+	go func() {
+		select {
+		case sig := <-sigs:
+			restore()
+			signal.Stop(sigs)
+			raise(sig)
+		case <-done:
+		}
+	}()
+
+	password, err := term.ReadPassword(t.fd)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to read from the terminal")
+	}
+
+	return password, nil
+}
+
+// raise sends the signal to the current process again. If the platform can
+// not send it, such as Windows, the process exits instead as the default
+// action would.
+func raise(sig os.Signal) {
+	process, err := os.FindProcess(os.Getpid())
+	if err == nil {
+		err = process.Signal(sig)
+	}
+
+	if err != nil {
+		os.Exit(1)
+	}
+}
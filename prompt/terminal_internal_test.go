@@ -0,0 +1,25 @@
+package prompt
+
+import (
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestStdinTerminal_pipe(t *testing.T) {
+	t.Parallel()
+
+	reader, writer, err := os.Pipe()
+	require.NoError(t, err)
+
+	defer reader.Close()
+	defer writer.Close()
+
+	term := stdinTerminal{fd: int(reader.Fd())}
+
+	require.False(t, term.IsTerminal(), "a pipe should not be a terminal")
+
+	_, err = term.ReadPassword()
+	require.ErrorContains(t, err, "failed to get the terminal state")
+}
@@ -0,0 +1,155 @@
+package argonize
+
+import (
+	"crypto/rand"
+	"io"
+	"sync/atomic"
+
+	"github.com/pkg/errors"
+)
+
+// ============================================================================
+//  Type: Randomness
+// ============================================================================
+
+// Randomness is the source of the random bytes, such as the salts. It has the
+// same signature as io.Reader. A short read is retried until b is filled, and
+// it is an error if the source ends or fails before that. A source returning no
+// bytes and no error 100 times in a row fails with io.ErrNoProgress, instead of
+// blocking the caller forever.
+//
+// Implementations must be safe for concurrent use.
+type Randomness interface {
+	Read(b []byte) (n int, err error)
+}
+
+// CryptoRandomness is the Randomness of the "crypto/rand" package.
+type CryptoRandomness struct{}
+
+// Read implements Randomness with crypto/rand.Read().
+func (CryptoRandomness) Read(b []byte) (int, error) {
+	return rand.Read(b)
+}
+
+// randReadAdapter is the default Randomness. It calls the RandRead variable to
+// keep the replacement of RandRead working.
+type randReadAdapter struct{}
+
+// Read implements Randomness with RandRead().
+func (randReadAdapter) Read(b []byte) (int, error) {
+	return RandRead(b)
+}
+
+// maxEmptyReads is the number of the consecutive reads returning no bytes and
+// no error before readFullRandom() gives up. It is the same limit as the bufio
+// package.
+const maxEmptyReads = 100
+
+// randomnessBox holds a Randomness to store various implementations in an
+// atomic.Value, which requires the same concrete type.
+type randomnessBox struct {
+	randomness Randomness
+}
+
+// globalRandomness is the Randomness set by SetRandomness().
+//
+//nolint:gochecknoglobals // set via SetRandomness() only
+var globalRandomness atomic.Value
+
+// ============================================================================
+//  Functions
+// ============================================================================
+
+// SetRandomness sets the package-wide Randomness used by RandomBytes(),
+// NewSalt() and the functions depending on them. It is safe for concurrent use,
+// unlike replacing the RandRead variable.
+//
+// If r is nil, the default is restored, which calls RandRead (crypto/rand.Read
+// unless replaced). To inject randomness without affecting the other users of
+// the package, such as parallel tests, use Hasher.WithRandomness() instead.
+func SetRandomness(r Randomness) {
+	globalRandomness.Store(randomnessBox{randomness: r})
+}
+
+// ----------------------------------------------------------------------------
+//  Private Functions
+// ----------------------------------------------------------------------------
+
+// currentRandomness returns the package-wide Randomness.
+func currentRandomness() Randomness {
+	if box, ok := globalRandomness.Load().(randomnessBox); ok && box.randomness != nil {
+		return box.randomness
+	}
+
+	return randReadAdapter{}
+}
+
+// randomBytesFrom returns lenOut bytes read from the Randomness.
+func randomBytesFrom(r Randomness, lenOut uint32) ([]byte, error) {
+	bytesOut := make([]byte, lenOut)
+
+	// Short reads are retried. A source which ends early is an error rather
+	// than leaving the rest of the bytes zero.
+	if n, err := readFullRandom(r, bytesOut); err != nil {
+		clear(bytesOut)
+
+		return nil, errors.Wrapf(err, "failed to read random bytes: got %d of %d bytes", n, lenOut)
+	}
+
+	return bytesOut, nil
+}
+
+// readFullRandom is io.ReadFull() for the Randomness, which also fails with
+// io.ErrNoProgress after maxEmptyReads consecutive reads of (0, nil), so that a
+// broken source can not spin forever.
+func readFullRandom(r Randomness, b []byte) (int, error) {
+	var (
+		n, numEmpty int
+		err         error
+	)
+
+	for n < len(b) && err == nil {
+		var read int
+
+		read, err = r.Read(b[n:])
+		n += read
+
+		switch {
+		case read > 0:
+			numEmpty = 0
+		case err == nil:
+			numEmpty++
+
+			if numEmpty >= maxEmptyReads {
+				err = io.ErrNoProgress
+			}
+		}
+	}
+
+	if n >= len(b) {
+		return n, nil
+	}
+
+	if n > 0 && errors.Is(err, io.EOF) {
+		err = io.ErrUnexpectedEOF
+	}
+
+	return n, err
+}
+
+// newSaltFrom returns a new Salt of lenOut bytes read from the Randomness.
+func newSaltFrom(r Randomness, lenOut uint32) (Salt, error) {
+	if lenOut < SaltLengthMin {
+		return nil, errors.Errorf(
+			"failed to generate salt: length %d is shorter than the minimum %d",
+			lenOut, SaltLengthMin,
+		)
+	}
+
+	salt, err := randomBytesFrom(r, lenOut)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to generate salt")
+	}
+
+	return Salt(salt), nil
+}
@@ -0,0 +1,219 @@
+package argonize_test
+
+import (
+	"bytes"
+	"io"
+	"testing"
+
+	"github.com/KEINOS/go-argonize"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/require"
+)
+
+// fixedRandomness is a Randomness filling the buffer with a fixed byte.
+type fixedRandomness byte
+
+func (f fixedRandomness) Read(b []byte) (int, error) {
+	copy(b, bytes.Repeat([]byte{byte(f)}, len(b)))
+
+	return len(b), nil
+}
+
+// failingRandomness is a Randomness that always fails.
+type failingRandomness struct{}
+
+func (failingRandomness) Read([]byte) (int, error) {
+	return 0, errors.New("forced failure")
+}
+
+// chunkedRandomness is a Randomness reading at most 3 bytes of 0xCD per call.
+type chunkedRandomness struct{}
+
+func (chunkedRandomness) Read(b []byte) (int, error) {
+	n := min(len(b), 3)
+	copy(b, bytes.Repeat([]byte{0xCD}, n))
+
+	return n, nil
+}
+
+// shortRandomness is a Randomness which ends after 4 bytes of 0xEF in total.
+type shortRandomness struct {
+	remain int
+}
+
+func (s *shortRandomness) Read(b []byte) (int, error) {
+	if s.remain == 0 {
+		return 0, io.EOF
+	}
+
+	n := min(len(b), s.remain)
+	copy(b, bytes.Repeat([]byte{0xEF}, n))
+	s.remain -= n
+
+	return n, nil
+}
+
+// flakyRandomness is a Randomness returning (0, nil) 99 times before each byte
+// of 0xCD.
+type flakyRandomness struct {
+	calls int
+}
+
+func (f *flakyRandomness) Read(b []byte) (int, error) {
+	f.calls++
+
+	if f.calls%100 != 0 || len(b) == 0 {
+		return 0, nil
+	}
+
+	b[0] = 0xCD
+
+	return 1, nil
+}
+
+// stalledRandomness is a Randomness returning (0, nil) after its bytes of 0xAB
+// are read, as a broken source may.
+type stalledRandomness struct {
+	remain int
+	calls  int
+}
+
+func (s *stalledRandomness) Read(b []byte) (int, error) {
+	s.calls++
+
+	n := min(len(b), s.remain)
+	copy(b, bytes.Repeat([]byte{0xAB}, n))
+	s.remain -= n
+
+	return n, nil
+}
+
+// ----------------------------------------------------------------------------
+//  RandomBytes()
+// ----------------------------------------------------------------------------
+
+//nolint:paralleltest // disable parallel since it changes the package-wide randomness
+func TestRandomBytes_short_read(t *testing.T) {
+	defer argonize.SetRandomness(nil)
+
+	// Short reads should be retried until filled
+	argonize.SetRandomness(chunkedRandomness{})
+
+	out, err := argonize.RandomBytes(16)
+	require.NoError(t, err)
+	require.Equal(t, bytes.Repeat([]byte{0xCD}, 16), out)
+
+	// A source ending early should be an error, not a partly zero output
+	argonize.SetRandomness(&shortRandomness{remain: 4})
+
+	out, err = argonize.RandomBytes(16)
+	require.ErrorIs(t, err, io.ErrUnexpectedEOF)
+	require.ErrorContains(t, err, "got 4 of 16 bytes")
+	require.Nil(t, out)
+
+	argonize.SetRandomness(&shortRandomness{remain: 0})
+
+	salt, err := argonize.NewSalt(16)
+	require.ErrorIs(t, err, io.EOF)
+	require.Nil(t, salt, "no partially random salt should escape")
+}
+
+//nolint:paralleltest // disable parallel since it changes the package-wide randomness
+func TestRandomBytes_no_progress(t *testing.T) {
+	defer argonize.SetRandomness(nil)
+
+	stalled := &stalledRandomness{remain: 4}
+
+	argonize.SetRandomness(stalled)
+
+	out, err := argonize.RandomBytes(16)
+	require.ErrorIs(t, err, io.ErrNoProgress)
+	require.ErrorContains(t, err, "got 4 of 16 bytes")
+	require.Nil(t, out)
+	require.Equal(t, 1+100, stalled.calls, "it should give up after 100 empty reads")
+
+	// Empty reads between the progress should be tolerated
+	argonize.SetRandomness(&flakyRandomness{})
+
+	out, err = argonize.RandomBytes(16)
+	require.NoError(t, err)
+	require.Equal(t, bytes.Repeat([]byte{0xCD}, 16), out)
+}
+
+// ----------------------------------------------------------------------------
+//  SetRandomness()
+// ----------------------------------------------------------------------------
+
+//nolint:paralleltest // disable parallel since it changes the package-wide randomness
+func TestSetRandomness(t *testing.T) {
+	defer argonize.SetRandomness(nil)
+
+	argonize.SetRandomness(fixedRandomness(0xAB))
+
+	salt, err := argonize.NewSalt(16)
+	require.NoError(t, err)
+	require.Equal(t, argonize.Salt(bytes.Repeat([]byte{0xAB}, 16)), salt)
+
+	argonize.SetRandomness(failingRandomness{})
+
+	_, err = argonize.RandomBytes(16)
+	require.ErrorContains(t, err, "forced failure")
+
+	// Nil restores the default
+	argonize.SetRandomness(nil)
+
+	salt, err = argonize.NewSalt(16)
+	require.NoError(t, err)
+	require.NotEqual(t, argonize.Salt(bytes.Repeat([]byte{0xAB}, 16)), salt)
+}
+
+// ----------------------------------------------------------------------------
+//  CryptoRandomness
+// ----------------------------------------------------------------------------
+
+func TestCryptoRandomness(t *testing.T) {
+	t.Parallel()
+
+	buf1 := make([]byte, 32)
+	buf2 := make([]byte, 32)
+
+	n, err := argonize.CryptoRandomness{}.Read(buf1)
+	require.NoError(t, err)
+	require.Equal(t, 32, n)
+
+	_, err = argonize.CryptoRandomness{}.Read(buf2)
+	require.NoError(t, err)
+	require.NotEqual(t, buf1, buf2)
+}
+
+// ----------------------------------------------------------------------------
+//  Hasher.WithRandomness()
+// ----------------------------------------------------------------------------
+
+func TestHasher_WithRandomness(t *testing.T) {
+	t.Parallel()
+
+	hasher := argonize.NewHasher(lowCostParams()).WithRandomness(fixedRandomness(0x01))
+
+	hashed1, err := hasher.Hash([]byte("password"))
+	require.NoError(t, err)
+	require.Equal(t, argonize.Salt(bytes.Repeat([]byte{0x01}, 16)), hashed1.Salt)
+
+	hashed2, err := hasher.Hash([]byte("password"))
+	require.NoError(t, err)
+	require.Equal(t, hashed1.String(), hashed2.String(), "it should be deterministic")
+
+	_, err = argonize.NewHasher(lowCostParams()).WithRandomness(failingRandomness{}).Hash([]byte("password"))
+	require.ErrorContains(t, err, "forced failure")
+}
+
+func TestHasher_WithRandomness_short_read(t *testing.T) {
+	t.Parallel()
+
+	hasher := argonize.NewHasher(lowCostParams()).WithRandomness(&shortRandomness{remain: 10})
+
+	hashedObj, err := hasher.Hash([]byte("password"))
+	require.ErrorIs(t, err, io.ErrUnexpectedEOF)
+	require.ErrorContains(t, err, "got 10 of 16 bytes")
+	require.Nil(t, hashedObj, "no hash with a partially random salt should escape")
+}
@@ -0,0 +1,176 @@
+package argonize
+
+import (
+	"io"
+	"math"
+
+	"github.com/pkg/errors"
+)
+
+// ============================================================================
+//  Public Variables
+// ============================================================================
+
+// ErrPasswordTooLong is the error of a password stream longer than the limit.
+// Check it with errors.Is().
+//
+//nolint:gochecknoglobals // sentinel error
+var ErrPasswordTooLong = errors.New("password exceeds the limit")
+
+// readChunkSize is the initial buffer size to read the password stream.
+const readChunkSize = 512
+
+// ============================================================================
+//  Type: ReaderOptions
+// ============================================================================
+
+// ReaderOptions configures how HashFromReaderWith() and
+// Hashed.VerifyFromReaderWith() read the password from a stream.
+type ReaderOptions struct {
+	// Limit is the maximum length of the password in bytes, including the
+	// trailing newline if any. It must be positive and is capped at
+	// math.MaxInt32-1. A longer stream is an error wrapping ErrPasswordTooLong
+	// rather than silently truncated.
+	Limit int64
+	// TrimNewline trims a single trailing "\n" or "\r\n" off the password,
+	// such as the one of a file containing a secret. Other whitespace is kept.
+	TrimNewline bool
+}
+
+// ============================================================================
+//  Functions
+// ============================================================================
+
+// HashFromReader reads the password from r up to limit bytes and hashes it
+// with the parameters in the same way as HashCustomChecked(). It is for the
+// passwords which arrive as streams, such as HTTP request bodies.
+//
+// The password is read as is, including a trailing newline. Use
+// HashFromReaderWith() to trim it. It returns an error wrapping
+// ErrPasswordTooLong if r has more than limit bytes and an error if the stream
+// is empty. The temporary buffer is zeroed before returning.
+func HashFromReader(r io.Reader, limit int64, params *Params) (*Hashed, error) {
+	return HashFromReaderWith(r, params, ReaderOptions{Limit: limit})
+}
+
+// HashFromReaderWith is similar to HashFromReader() but reads the password with
+// the given options.
+func HashFromReaderWith(r io.Reader, params *Params, opts ReaderOptions) (*Hashed, error) {
+	buf, err := readPassword(r, opts.Limit)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to hash the password")
+	}
+
+	defer clear(buf)
+
+	return HashCustomChecked(opts.password(buf), nil, params)
+}
+
+// ----------------------------------------------------------------------------
+//  Methods of Hashed
+// ----------------------------------------------------------------------------
+
+// VerifyFromReader reads the password from r up to limit bytes and returns true
+// if it matches the hash. It is the counterpart of HashFromReader() and reads
+// the password in the same way.
+func (h *Hashed) VerifyFromReader(r io.Reader, limit int64) (bool, error) {
+	return h.VerifyFromReaderWith(r, ReaderOptions{Limit: limit})
+}
+
+// VerifyFromReaderWith is similar to VerifyFromReader() but reads the password
+// with the given options. Use the same options as on hashing.
+func (h *Hashed) VerifyFromReaderWith(r io.Reader, opts ReaderOptions) (bool, error) {
+	buf, err := readPassword(r, opts.Limit)
+	if err != nil {
+		return false, errors.Wrap(err, "failed to verify password")
+	}
+
+	defer clear(buf)
+
+	password := opts.password(buf)
+	if len(password) == 0 {
+		return false, errors.New("failed to verify password: the password is empty")
+	}
+
+	return h.IsValidPassword(password), nil
+}
+
+// ----------------------------------------------------------------------------
+//  Methods of ReaderOptions (Private)
+// ----------------------------------------------------------------------------
+
+// password returns the password in buf with the trailing newline trimmed if
+// TrimNewline is true. It shares the memory of buf.
+func (opts ReaderOptions) password(buf []byte) []byte {
+	if !opts.TrimNewline || len(buf) == 0 || buf[len(buf)-1] != '\n' {
+		return buf
+	}
+
+	buf = buf[:len(buf)-1]
+
+	if len(buf) > 0 && buf[len(buf)-1] == '\r' {
+		buf = buf[:len(buf)-1]
+	}
+
+	return buf
+}
+
+// ----------------------------------------------------------------------------
+//  Private Functions
+// ----------------------------------------------------------------------------
+
+// readPassword reads r up to limit bytes. Unlike io.ReadAll(), the buffers
+// outgrown while reading are zeroed, so no copy of the password is left behind
+// except the returned one, which the caller must zero after use.
+func readPassword(r io.Reader, limit int64) ([]byte, error) {
+	if r == nil {
+		return nil, errors.New("reader is nil")
+	}
+
+	if limit <= 0 {
+		return nil, errors.Errorf("limit must be positive: %d", limit)
+	}
+
+	// Read one more byte than the limit to detect the overflow.
+	limit = min(limit, math.MaxInt32-1)
+	capMax := limit + 1
+
+	buf := make([]byte, 0, min(capMax, readChunkSize))
+
+	for {
+		if len(buf) == cap(buf) {
+			if int64(len(buf)) > limit {
+				clear(buf)
+
+				return nil, errors.Wrapf(ErrPasswordTooLong, "more than %d bytes", limit)
+			}
+
+			grown := make([]byte, len(buf), min(capMax, 2*int64(cap(buf))))
+			copy(grown, buf)
+			clear(buf)
+
+			buf = grown
+		}
+
+		n, err := r.Read(buf[len(buf):cap(buf)])
+		buf = buf[:len(buf)+n]
+
+		if errors.Is(err, io.EOF) {
+			break
+		}
+
+		if err != nil {
+			clear(buf)
+
+			return nil, errors.Wrap(err, "failed to read the password")
+		}
+	}
+
+	if int64(len(buf)) > limit {
+		clear(buf)
+
+		return nil, errors.Wrapf(ErrPasswordTooLong, "more than %d bytes", limit)
+	}
+
+	return buf, nil
+}
@@ -0,0 +1,143 @@
+package argonize_test
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"testing/iotest"
+
+	"github.com/KEINOS/go-argonize"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/require"
+)
+
+// ----------------------------------------------------------------------------
+//  HashFromReader()
+// ----------------------------------------------------------------------------
+
+func TestHashFromReader(t *testing.T) {
+	t.Parallel()
+
+	password := "password"
+
+	for _, test := range []struct {
+		input string
+		limit int64
+	}{
+		{password, 8},    // exact limit
+		{password, 1024}, // under the limit
+	} {
+		hashedObj, err := argonize.HashFromReader(strings.NewReader(test.input), test.limit, lowCostParams())
+		require.NoError(t, err, "limit: %d", test.limit)
+		require.True(t, hashedObj.IsValidPassword([]byte(password)))
+	}
+
+	// The newline is kept by default
+	hashedObj, err := argonize.HashFromReader(strings.NewReader(password+"\n"), 9, lowCostParams())
+	require.NoError(t, err)
+	require.False(t, hashedObj.IsValidPassword([]byte(password)))
+	require.True(t, hashedObj.IsValidPassword([]byte(password+"\n")))
+}
+
+func TestHashFromReader_long_stream(t *testing.T) {
+	t.Parallel()
+
+	// Longer than the initial buffer to grow it
+	password := strings.Repeat("a", 5000)
+
+	hashedObj, err := argonize.HashFromReader(iotest.OneByteReader(strings.NewReader(password)), 5000, lowCostParams())
+	require.NoError(t, err)
+	require.True(t, hashedObj.IsValidPassword([]byte(password)))
+
+	_, err = argonize.HashFromReader(strings.NewReader(password), 4999, lowCostParams())
+	require.ErrorIs(t, err, argonize.ErrPasswordTooLong)
+}
+
+func TestHashFromReader_errors(t *testing.T) {
+	t.Parallel()
+
+	for _, test := range []struct {
+		reader     *strings.Reader
+		limit      int64
+		msgContain string
+	}{
+		{strings.NewReader("password"), 7, "password exceeds the limit"}, // over the limit
+		{strings.NewReader(""), 8, "the password is empty"},
+		{strings.NewReader("password"), 0, "limit must be positive"},
+		{strings.NewReader("password"), -1, "limit must be positive"},
+	} {
+		hashedObj, err := argonize.HashFromReader(test.reader, test.limit, lowCostParams())
+		require.ErrorContains(t, err, test.msgContain)
+		require.Nil(t, hashedObj)
+	}
+
+	_, err := argonize.HashFromReader(strings.NewReader("password!"), 8, lowCostParams())
+	require.ErrorIs(t, err, argonize.ErrPasswordTooLong, "it should not truncate silently")
+
+	_, err = argonize.HashFromReader(nil, 8, lowCostParams())
+	require.ErrorContains(t, err, "reader is nil")
+
+	_, err = argonize.HashFromReader(iotest.ErrReader(errors.New("forced failure")), 8, lowCostParams())
+	require.ErrorContains(t, err, "forced failure")
+
+	_, err = argonize.HashFromReader(strings.NewReader("password"), 8, nil)
+	require.ErrorIs(t, err, argonize.ErrNilParams)
+}
+
+// ----------------------------------------------------------------------------
+//  HashFromReaderWith()
+// ----------------------------------------------------------------------------
+
+func TestHashFromReaderWith_trim_newline(t *testing.T) {
+	t.Parallel()
+
+	opts := argonize.ReaderOptions{Limit: 10, TrimNewline: true}
+
+	for input, expect := range map[string]string{
+		"password":     "password",
+		"password\n":   "password",
+		"password\r\n": "password",
+		"password\n\n": "password\n", // only a single newline
+		"password \n":  "password ",  // other whitespace is kept
+	} {
+		hashedObj, err := argonize.HashFromReaderWith(strings.NewReader(input), lowCostParams(), opts)
+		require.NoError(t, err, "input: %q", input)
+		require.True(t, hashedObj.IsValidPassword([]byte(expect)), "input: %q", input)
+
+		ok, err := hashedObj.VerifyFromReaderWith(strings.NewReader(input), opts)
+		require.NoError(t, err)
+		require.True(t, ok, "input: %q", input)
+	}
+
+	for _, input := range []string{"\n", "\r\n"} {
+		_, err := argonize.HashFromReaderWith(strings.NewReader(input), lowCostParams(), opts)
+		require.ErrorContains(t, err, "the password is empty", "input: %q", input)
+	}
+}
+
+// ----------------------------------------------------------------------------
+//  Hashed.VerifyFromReader()
+// ----------------------------------------------------------------------------
+
+func TestHashed_VerifyFromReader(t *testing.T) {
+	t.Parallel()
+
+	hashedObj, err := argonize.HashCustomChecked([]byte("password"), nil, lowCostParams())
+	require.NoError(t, err)
+
+	ok, err := hashedObj.VerifyFromReader(strings.NewReader("password"), 8)
+	require.NoError(t, err)
+	require.True(t, ok, "exact limit should be accepted")
+
+	ok, err = hashedObj.VerifyFromReader(strings.NewReader("wrong"), 8)
+	require.NoError(t, err)
+	require.False(t, ok)
+
+	ok, err = hashedObj.VerifyFromReader(bytes.NewReader([]byte("password")), 7)
+	require.ErrorIs(t, err, argonize.ErrPasswordTooLong)
+	require.False(t, ok)
+
+	ok, err = hashedObj.VerifyFromReader(strings.NewReader(""), 8)
+	require.ErrorContains(t, err, "the password is empty")
+	require.False(t, ok)
+}
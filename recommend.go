@@ -0,0 +1,127 @@
+package argonize
+
+import (
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+const (
+	// recommendIterationsMax is the upper limit of the iterations chosen by
+	// RecommendParams(). It keeps a tiny memory budget with a long target from
+	// ending up with an absurd number of passes.
+	recommendIterationsMax = uint32(64)
+	// recommendTrialPassword is the password of the trial hashes.
+	recommendTrialPassword = "argonize-recommend-trial"
+)
+
+// ============================================================================
+//  Functions
+// ============================================================================
+
+// RecommendParams measures trial hashes on the current machine and returns the
+// parameters taking about the target duration per hash within the memory
+// budget in KiB, along with the measured duration of the chosen parameters.
+//
+// The memory cost is the primary dimension as recommended by RFC 9106. It
+// starts with the whole budget and is halved only while a single pass exceeds
+// the target. Then the iterations are tuned to fill the rest of the target.
+// If even the minimum memory with one pass exceeds the target, the cheapest
+// parameters are returned and the measured duration is longer than the target.
+//
+// The other fields are the defaults of NewParams(). Note that the measurement
+// varies with the load of the machine, so run it on the production hardware
+// and store the result in the configuration rather than calling it per hash.
+// Use Calibrate() instead to tune the iterations of a fixed memory cost.
+func RecommendParams(memBudgetKiB uint32, target time.Duration, parallelism uint8) (*Params, time.Duration, error) {
+	if target <= 0 {
+		return nil, 0, errors.Errorf("failed to recommend params: target duration %v must be positive", target)
+	}
+
+	if parallelism == 0 {
+		return nil, 0, errors.New("failed to recommend params: parallelism must be at least 1")
+	}
+
+	memoryMin := uint32(parallelism) * memoryPerLaneMin
+	if memBudgetKiB < memoryMin {
+		return nil, 0, errors.Errorf(
+			"failed to recommend params: memory budget %d KiB is less than the minimum %d KiB for parallelism %d",
+			memBudgetKiB, memoryMin, parallelism,
+		)
+	}
+
+	params := NewParams()
+	params.Parallelism = parallelism
+	params.Iterations = 1
+	params.MemoryCost = memBudgetKiB
+
+	// Memory first: shrink it only while a single pass is too slow
+	elapsed, err := trialHash(params)
+	if err != nil {
+		return nil, 0, errors.Wrap(err, "failed to recommend params")
+	}
+
+	for elapsed > target && params.MemoryCost/2 >= memoryMin {
+		params.MemoryCost /= 2
+
+		if elapsed, err = trialHash(params); err != nil {
+			return nil, 0, errors.Wrap(err, "failed to recommend params")
+		}
+	}
+
+	// Then fill the rest of the target with the iterations
+	params.Iterations = iterationsFor(target, elapsed)
+	if params.Iterations == 1 {
+		return params, elapsed, nil
+	}
+
+	if elapsed, err = trialHash(params); err != nil {
+		return nil, 0, errors.Wrap(err, "failed to recommend params")
+	}
+
+	// Correct the estimate once since the passes are not exactly linear
+	if elapsed > target {
+		params.Iterations = iterationsFor(target, elapsed/time.Duration(params.Iterations))
+
+		if elapsed, err = trialHash(params); err != nil {
+			return nil, 0, errors.Wrap(err, "failed to recommend params")
+		}
+	}
+
+	return params, elapsed, nil
+}
+
+// ----------------------------------------------------------------------------
+//  Private Functions
+// ----------------------------------------------------------------------------
+
+// iterationsFor returns the number of iterations fitting in the target from the
+// duration of a single pass. It is between 1 and recommendIterationsMax.
+func iterationsFor(target, perPass time.Duration) uint32 {
+	perPass = max(perPass, time.Nanosecond)
+
+	iterations := target / perPass
+	if iterations < 1 {
+		return 1
+	}
+
+	if iterations > time.Duration(recommendIterationsMax) {
+		return recommendIterationsMax
+	}
+
+	return uint32(iterations)
+}
+
+// trialHash hashes a fixed password with the params and returns the elapsed
+// time.
+func trialHash(params *Params) (time.Duration, error) {
+	salt := make([]byte, params.SaltLength)
+
+	start := time.Now()
+
+	if _, err := deriveKey([]byte(recommendTrialPassword), salt, params); err != nil {
+		return 0, errors.Wrap(err, "trial hash failed")
+	}
+
+	return time.Since(start), nil
+}
@@ -0,0 +1,76 @@
+package argonize_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/KEINOS/go-argonize"
+	"github.com/stretchr/testify/require"
+)
+
+// ----------------------------------------------------------------------------
+//  RecommendParams()
+// ----------------------------------------------------------------------------
+
+func TestRecommendParams(t *testing.T) {
+	t.Parallel()
+
+	const (
+		memBudget   = uint32(256)
+		target      = 20 * time.Millisecond
+		parallelism = uint8(2)
+	)
+
+	params, elapsed, err := argonize.RecommendParams(memBudget, target, parallelism)
+	require.NoError(t, err)
+	require.NoError(t, params.Validate(), "recommended params should be valid")
+
+	require.LessOrEqual(t, params.MemoryCost, memBudget, "memory should be within the budget")
+	require.GreaterOrEqual(t, params.MemoryCost, uint32(8*parallelism))
+	require.GreaterOrEqual(t, params.Iterations, uint32(1))
+	require.Equal(t, parallelism, params.Parallelism)
+	require.Positive(t, elapsed, "it should return the measured duration")
+
+	// The recommended params should be usable as is
+	hashedObj, err := argonize.HashCustomChecked([]byte("password"), nil, params)
+	require.NoError(t, err)
+	require.True(t, hashedObj.IsValidPassword([]byte("password")))
+}
+
+func TestRecommendParams_tiny_target(t *testing.T) {
+	t.Parallel()
+
+	// Nothing fits in a nanosecond, so it should fall back to the cheapest
+	// params and report the longer duration rather than failing.
+	params, elapsed, err := argonize.RecommendParams(1024, time.Nanosecond, 1)
+	require.NoError(t, err)
+
+	require.Equal(t, uint32(8), params.MemoryCost, "memory should be halved down to the minimum")
+	require.Equal(t, uint32(1), params.Iterations)
+	require.Greater(t, elapsed, time.Nanosecond)
+}
+
+func TestRecommendParams_bad_args(t *testing.T) {
+	t.Parallel()
+
+	for _, test := range []struct {
+		msgContain  string
+		target      time.Duration
+		memBudget   uint32
+		parallelism uint8
+	}{
+		{memBudget: 64, target: 0, parallelism: 1, msgContain: "target duration 0s must be positive"},
+		{memBudget: 64, target: -time.Second, parallelism: 1, msgContain: "must be positive"},
+		{memBudget: 64, target: time.Second, parallelism: 0, msgContain: "parallelism must be at least 1"},
+		{
+			memBudget: 31, target: time.Second, parallelism: 4,
+			msgContain: "memory budget 31 KiB is less than the minimum 32 KiB for parallelism 4",
+		},
+	} {
+		params, elapsed, err := argonize.RecommendParams(test.memBudget, test.target, test.parallelism)
+
+		require.ErrorContains(t, err, test.msgContain)
+		require.Nil(t, params)
+		require.Zero(t, elapsed)
+	}
+}
@@ -0,0 +1,33 @@
+package argonize
+
+// ----------------------------------------------------------------------------
+//  Methods of Hashed
+// ----------------------------------------------------------------------------
+
+// ReSalt verifies the password and, if it matches, re-derives the hash with a
+// fresh random salt of newSaltLen bytes under the same parameters. It is for
+// the salt rotation policies, such as moving to 32-byte salts, applied at the
+// next login. The bool reports whether the password matched.
+//
+// It returns nil and false on a mismatch. On a match, it returns nil and true
+// if the re-derivation failed, such as for newSaltLen shorter than
+// SaltLengthMin, so keep the current hash in that case. The Data is carried
+// over to the new hash. The hashes with a KeyID are not supported since they
+// need the pepper of Hasher.Verify(), and are reported as a mismatch.
+func (h *Hashed) ReSalt(password []byte, newSaltLen uint32) (*Hashed, bool) {
+	if h.IsZero() || h.KeyID != "" || !h.IsValidPassword(password) {
+		return nil, false
+	}
+
+	params := *h.Params
+	params.SaltLength = newSaltLen
+
+	resalted, err := HashWithSalt(password, nil, &params)
+	if err != nil {
+		return nil, true
+	}
+
+	resalted.Data = h.Data
+
+	return resalted, true
+}
@@ -0,0 +1,70 @@
+package argonize_test
+
+import (
+	"testing"
+
+	"github.com/KEINOS/go-argonize"
+	"github.com/stretchr/testify/require"
+)
+
+// ----------------------------------------------------------------------------
+//  Hashed.ReSalt()
+// ----------------------------------------------------------------------------
+
+func TestHashed_ReSalt(t *testing.T) {
+	t.Parallel()
+
+	hashedObj, err := argonize.HashCustomChecked([]byte("password"), nil, lowCostParams())
+	require.NoError(t, err)
+
+	hashedObj.Data = "profile-1"
+
+	resalted, ok := hashedObj.ReSalt([]byte("password"), 32)
+	require.True(t, ok)
+	require.NotNil(t, resalted)
+
+	require.Len(t, resalted.Salt, 32)
+	require.Equal(t, uint32(32), resalted.Params.SaltLength)
+	require.Equal(t, hashedObj.Params.EncodeParams(), resalted.Params.EncodeParams(), "params should be kept")
+	require.Equal(t, "profile-1", resalted.Data)
+	require.NotEqual(t, hashedObj.Hash, resalted.Hash)
+	require.True(t, resalted.IsValidPassword([]byte("password")))
+
+	// The original should not be modified
+	require.Len(t, hashedObj.Salt, 16)
+	require.Equal(t, uint32(16), hashedObj.Params.SaltLength)
+}
+
+func TestHashed_ReSalt_mismatch(t *testing.T) {
+	t.Parallel()
+
+	hashedObj, err := argonize.HashCustomChecked([]byte("password"), nil, lowCostParams())
+	require.NoError(t, err)
+
+	resalted, ok := hashedObj.ReSalt([]byte("wrong password"), 32)
+	require.False(t, ok)
+	require.Nil(t, resalted)
+
+	var nilHashed *argonize.Hashed
+
+	resalted, ok = nilHashed.ReSalt([]byte("password"), 32)
+	require.False(t, ok)
+	require.Nil(t, resalted)
+
+	hashedObj.KeyID = "v1"
+
+	resalted, ok = hashedObj.ReSalt([]byte("password"), 32)
+	require.False(t, ok, "hashes with key ID need the pepper")
+	require.Nil(t, resalted)
+}
+
+func TestHashed_ReSalt_too_short(t *testing.T) {
+	t.Parallel()
+
+	hashedObj, err := argonize.HashCustomChecked([]byte("password"), nil, lowCostParams())
+	require.NoError(t, err)
+
+	resalted, ok := hashedObj.ReSalt([]byte("password"), argonize.SaltLengthMin-1)
+	require.True(t, ok, "the password should match")
+	require.Nil(t, resalted, "the re-derivation should fail")
+}
@@ -0,0 +1,89 @@
+package argonize
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"crypto/subtle"
+
+	"github.com/pkg/errors"
+)
+
+// saltlessPepperMin is the minimum length of the pepper of HashSaltless().
+const saltlessPepperMin = 16
+
+// ============================================================================
+//  Functions
+// ============================================================================
+
+// HashSaltless returns a Hashed object of the password without a stored salt.
+// It is only for the interoperability with systems requiring a keyed hash with
+// a server-wide pepper and no per-user salt.
+//
+// WARNING: This defeats the purpose of the salt. The same password always
+// produces the same hash, which reveals the users sharing a password and allows
+// precomputation by anyone who obtains the pepper. Use Hash() or a Hasher with
+// a PepperProvider unless you have to interoperate.
+//
+// The salt is derived deterministically as HMAC-SHA256(pepper, password) and is
+// not stored: the Salt of the returned object is empty, as is the salt segment
+// of its String(), such as "$argon2id$v=19$m=65536,t=1,p=2$$<hash>". Decode it
+// with DecodeHashStrWith() and DecodeOptions.AllowEmptySalt, and verify it with
+// Hashed.IsValidPasswordSaltless(). The pepper must be at least 16 bytes long.
+func HashSaltless(password, pepper []byte, params *Params) (*Hashed, error) {
+	if len(pepper) < saltlessPepperMin {
+		return nil, errors.Errorf(
+			"failed to hash the password: pepper length %d is shorter than the minimum %d",
+			len(pepper), saltlessPepperMin,
+		)
+	}
+
+	if err := params.Validate(); err != nil {
+		return nil, err
+	}
+
+	paramsCopy := *params
+
+	hashed, err := HashWithSalt(password, saltlessSalt(password, pepper), &paramsCopy)
+	if err != nil {
+		return nil, err
+	}
+
+	hashed.Salt = nil
+
+	return hashed, nil
+}
+
+// ----------------------------------------------------------------------------
+//  Methods of Hashed
+// ----------------------------------------------------------------------------
+
+// IsValidPasswordSaltless returns true if the password matches the hash created
+// by HashSaltless() with the pepper. It reproduces the same salt derivation.
+//
+// It returns false rather than panicking if the object is nil or invalid, such
+// as nil Params.
+func (h *Hashed) IsValidPasswordSaltless(password, pepper []byte) bool {
+	if h.IsZero() || h.validate(true) != nil || len(pepper) < saltlessPepperMin {
+		return false
+	}
+
+	otherHash, err := deriveKey(password, saltlessSalt(password, pepper), h.Params)
+	if err != nil {
+		return false
+	}
+
+	return subtle.ConstantTimeCompare(h.Hash, otherHash) == 1
+}
+
+// ----------------------------------------------------------------------------
+//  Private Functions
+// ----------------------------------------------------------------------------
+
+// saltlessSalt derives the salt of HashSaltless() from the password and the
+// pepper.
+func saltlessSalt(password, pepper []byte) Salt {
+	mac := hmac.New(sha256.New, pepper)
+	mac.Write(password)
+
+	return mac.Sum(nil)
+}
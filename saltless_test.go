@@ -0,0 +1,103 @@
+package argonize_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/KEINOS/go-argonize"
+	"github.com/stretchr/testify/require"
+)
+
+// ----------------------------------------------------------------------------
+//  HashSaltless()
+// ----------------------------------------------------------------------------
+
+func TestHashSaltless(t *testing.T) {
+	t.Parallel()
+
+	pepper := []byte("0123456789abcdef")
+
+	hashed1, err := argonize.HashSaltless([]byte("password"), pepper, lowCostParams())
+	require.NoError(t, err)
+	require.Empty(t, hashed1.Salt, "the salt should not be stored")
+
+	hashed2, err := argonize.HashSaltless([]byte("password"), pepper, lowCostParams())
+	require.NoError(t, err)
+	require.Equal(t, hashed1.Hash, hashed2.Hash, "it should be deterministic")
+
+	encoded := hashed1.String()
+
+	require.True(t, strings.HasPrefix(encoded, "$argon2id$v=19$m=64,t=1,p=1$$"), encoded)
+
+	// Default decoding rejects the empty salt
+	_, err = argonize.DecodeHashStr(encoded)
+	requireParseError(t, err, argonize.ErrInvalidLength, argonize.SegmentSalt)
+
+	decoded, err := argonize.DecodeHashStrWith(encoded, argonize.DecodeOptions{AllowEmptySalt: true})
+	require.NoError(t, err)
+	require.Equal(t, hashed1.Hash, decoded.Hash)
+
+	require.True(t, decoded.IsValidPasswordSaltless([]byte("password"), pepper))
+	require.False(t, decoded.IsValidPasswordSaltless([]byte("wrong"), pepper))
+	require.False(t, decoded.IsValidPasswordSaltless([]byte("password"), []byte("fedcba9876543210")))
+	require.False(t, decoded.IsValidPasswordSaltless([]byte("password"), nil))
+	require.False(t, decoded.IsValidPassword([]byte("password")), "it should require the pepper")
+}
+
+func TestHashSaltless_errors(t *testing.T) {
+	t.Parallel()
+
+	hashed, err := argonize.HashSaltless([]byte("password"), []byte("short"), lowCostParams())
+	require.ErrorContains(t, err, "pepper length 5 is shorter than the minimum 16")
+	require.Nil(t, hashed)
+
+	hashed, err = argonize.HashSaltless([]byte("password"), []byte("0123456789abcdef"), nil)
+	require.ErrorIs(t, err, argonize.ErrNilParams)
+	require.Nil(t, hashed)
+}
+
+// ----------------------------------------------------------------------------
+//  Hashed.IsValidPasswordSaltless()
+// ----------------------------------------------------------------------------
+
+func TestHashed_IsValidPasswordSaltless_invalid(t *testing.T) {
+	t.Parallel()
+
+	pepper := []byte("0123456789abcdef")
+
+	hashed, err := argonize.HashSaltless([]byte("password"), pepper, lowCostParams())
+	require.NoError(t, err)
+
+	var nilHashed *argonize.Hashed
+
+	noParams := *hashed
+	noParams.Params = nil
+
+	badParams := *hashed
+	badParams.Params = lowCostParams()
+	badParams.Params.Iterations = 0
+
+	for name, test := range map[string]*argonize.Hashed{
+		"nil receiver": nilHashed,
+		"zero value":   new(argonize.Hashed),
+		"nil params":   &noParams,
+		"bad params":   &badParams,
+	} {
+		require.NotPanics(t, func() {
+			require.False(t, test.IsValidPasswordSaltless([]byte("password"), pepper), name)
+		}, name)
+	}
+}
+
+func TestDecodeHashStrWith_allow_empty_salt(t *testing.T) {
+	t.Parallel()
+
+	opts := argonize.DecodeOptions{AllowEmptySalt: true}
+
+	_, err := argonize.DecodeHashStrWith("$argon2id$v=19$m=65536,t=3,p=2$$"+sampleHashB64, opts)
+	require.NoError(t, err)
+
+	// Short but non-empty salts are rejected regardless
+	_, err = argonize.DecodeHashStrWith("$argon2id$v=19$m=65536,t=3,p=2$Woo$"+sampleHashB64, opts)
+	requireParseError(t, err, argonize.ErrInvalidLength, argonize.SegmentSalt)
+}
@@ -0,0 +1,95 @@
+package argonize
+
+import (
+	"sync"
+
+	"github.com/pkg/errors"
+)
+
+// ============================================================================
+//  Type: SaltPool
+// ============================================================================
+
+// SaltPoolSizeDefault is the default number of salts buffered by a SaltPool
+// per refill.
+const SaltPoolSizeDefault = 256
+
+// SaltPool buffers random bytes and hands out salts of a fixed length. It
+// reduces the overhead of reading the random source for every salt when
+// hashing in a tight loop.
+//
+// Each salt is a fresh copy of a distinct part of the buffer, so salts never
+// overlap. It is safe for concurrent use by multiple goroutines.
+type SaltPool struct {
+	buf        []byte
+	pos        int
+	saltLength uint32
+	poolSize   uint32
+	mu         sync.Mutex
+}
+
+// ----------------------------------------------------------------------------
+//  Constructor of SaltPool
+// ----------------------------------------------------------------------------
+
+// NewSaltPool returns a new SaltPool which hands out salts with the given
+// length. The poolSize is the number of salts to read from RandRead at once.
+// If poolSize is zero, SaltPoolSizeDefault is used.
+//
+// It returns an error if saltLength is shorter than SaltLengthMin or if the
+// buffer size overflows.
+func NewSaltPool(saltLength uint32, poolSize uint32) (*SaltPool, error) {
+	if saltLength < SaltLengthMin {
+		return nil, errors.Errorf(
+			"failed to create salt pool: salt length %d is shorter than the minimum %d",
+			saltLength, SaltLengthMin,
+		)
+	}
+
+	if poolSize == 0 {
+		poolSize = SaltPoolSizeDefault
+	}
+
+	if uint64(saltLength)*uint64(poolSize) > maxInt32 {
+		return nil, errors.New("failed to create salt pool: pool size is too large")
+	}
+
+	return &SaltPool{
+		saltLength: saltLength,
+		poolSize:   poolSize,
+	}, nil
+}
+
+// ----------------------------------------------------------------------------
+//  Methods of SaltPool
+// ----------------------------------------------------------------------------
+
+// Get returns a new random salt from the pool. The pool is refilled from
+// RandRead when it runs out.
+//
+// It returns an error if the pool could not be refilled. Do not fall back to a
+// nil salt, since HashWithSalt() then reads a new one from RandRead anyway.
+func (sp *SaltPool) Get() (Salt, error) {
+	sp.mu.Lock()
+	defer sp.mu.Unlock()
+
+	if sp.pos+int(sp.saltLength) > len(sp.buf) {
+		buf, err := RandomBytes(sp.saltLength * sp.poolSize)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to refill the salt pool")
+		}
+
+		sp.buf = buf
+		sp.pos = 0
+	}
+
+	salt := make(Salt, sp.saltLength)
+	copy(salt, sp.buf[sp.pos:])
+
+	// Wipe the handed out part so that the bytes do not remain in memory twice.
+	clear(sp.buf[sp.pos : sp.pos+int(sp.saltLength)])
+
+	sp.pos += int(sp.saltLength)
+
+	return salt, nil
+}
@@ -0,0 +1,128 @@
+package argonize_test
+
+import (
+	"sync"
+	"testing"
+
+	"github.com/KEINOS/go-argonize"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/require"
+)
+
+// ----------------------------------------------------------------------------
+//  NewSaltPool()
+// ----------------------------------------------------------------------------
+
+func TestNewSaltPool_bad_args(t *testing.T) {
+	t.Parallel()
+
+	pool, err := argonize.NewSaltPool(4, 0)
+
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "salt length 4 is shorter than the minimum 8")
+	require.Nil(t, pool, "it should be nil on error")
+
+	pool, err = argonize.NewSaltPool(1024*1024, 1024*1024)
+
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "pool size is too large")
+	require.Nil(t, pool, "it should be nil on error")
+}
+
+// ----------------------------------------------------------------------------
+//  SaltPool.Get()
+// ----------------------------------------------------------------------------
+
+func TestSaltPool_Get_concurrent(t *testing.T) {
+	t.Parallel()
+
+	const (
+		numWorkers = 8
+		numSalts   = 100
+	)
+
+	// Small pool size to force refills during the test.
+	pool, err := argonize.NewSaltPool(argonize.SaltLengthDefault, 3)
+	require.NoError(t, err)
+
+	var (
+		mu    sync.Mutex
+		wg    sync.WaitGroup
+		seen  = make(map[string]bool, numWorkers*numSalts)
+		dupes int
+	)
+
+	for range numWorkers {
+		wg.Add(1)
+
+		go func() {
+			defer wg.Done()
+
+			for range numSalts {
+				salt, err := pool.Get()
+
+				mu.Lock()
+
+				if err != nil || seen[string(salt)] || len(salt) != int(argonize.SaltLengthDefault) {
+					dupes++
+				}
+
+				seen[string(salt)] = true
+
+				mu.Unlock()
+			}
+		}()
+	}
+
+	wg.Wait()
+
+	require.Zero(t, dupes, "salts should never fail, overlap nor be short")
+	require.Len(t, seen, numWorkers*numSalts)
+}
+
+//nolint:paralleltest // disable parallel since it temporarily changes the RandRead function
+func TestSaltPool_Get_refill_error(t *testing.T) {
+	// Backup and defer restore the random reader.
+	oldRandRead := argonize.RandRead
+	defer func() { argonize.RandRead = oldRandRead }()
+
+	argonize.RandRead = func(_ []byte) (int, error) {
+		return 0, errors.New("forced error")
+	}
+
+	pool, err := argonize.NewSaltPool(argonize.SaltLengthDefault, 0)
+	require.NoError(t, err)
+
+	salt, err := pool.Get()
+
+	require.ErrorContains(t, err, "failed to refill the salt pool")
+	require.ErrorContains(t, err, "forced error")
+	require.Nil(t, salt, "it should be nil on error")
+}
+
+// ----------------------------------------------------------------------------
+//  Benchmarks
+// ----------------------------------------------------------------------------
+
+func BenchmarkNewSalt(b *testing.B) {
+	for range b.N {
+		if _, err := argonize.NewSalt(argonize.SaltLengthDefault); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkSaltPool_Get(b *testing.B) {
+	pool, err := argonize.NewSaltPool(argonize.SaltLengthDefault, 0)
+	if err != nil {
+		b.Fatal(err)
+	}
+
+	b.ResetTimer()
+
+	for range b.N {
+		if _, err := pool.Get(); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
@@ -0,0 +1,80 @@
+package argonize
+
+import (
+	"fmt"
+	"log/slog"
+)
+
+// ============================================================================
+//  Type: SecretBytes
+// ============================================================================
+
+// redacted is the placeholder printed instead of the secret.
+const redacted = "[REDACTED]"
+
+// SecretBytes holds a secret such as a password. It is redacted when printed
+// with the fmt package or logged with the log/slog package, to prevent
+// accidental leakage to logs.
+//
+// The underlying bytes are only accessible through the Reveal() method.
+type SecretBytes struct {
+	secret []byte
+}
+
+// ----------------------------------------------------------------------------
+//  Constructor of SecretBytes
+// ----------------------------------------------------------------------------
+
+// NewSecretBytes returns a SecretBytes holding a copy of the secret. The caller
+// may clear the original slice afterwards.
+func NewSecretBytes(secret []byte) SecretBytes {
+	return SecretBytes{secret: append([]byte(nil), secret...)}
+}
+
+// ----------------------------------------------------------------------------
+//  Methods of SecretBytes
+// ----------------------------------------------------------------------------
+
+// Reveal returns the underlying secret bytes. It is the only way to access
+// them. Do not modify the returned slice.
+func (s SecretBytes) Reveal() []byte {
+	return s.secret
+}
+
+// String implements fmt.Stringer. It returns "[REDACTED]".
+func (s SecretBytes) String() string {
+	return redacted
+}
+
+// GoString implements fmt.GoStringer for the "%#v" verb. It returns
+// "argonize.SecretBytes{[REDACTED]}".
+func (s SecretBytes) GoString() string {
+	return "argonize.SecretBytes{" + redacted + "}"
+}
+
+// Format implements fmt.Formatter so that any verb, such as "%x" or "%d",
+// prints the redacted placeholder instead of the bytes.
+func (s SecretBytes) Format(state fmt.State, verb rune) {
+	if verb == 'v' && state.Flag('#') {
+		fmt.Fprint(state, s.GoString())
+
+		return
+	}
+
+	fmt.Fprint(state, redacted)
+}
+
+// LogValue implements slog.LogValuer. It returns "[REDACTED]".
+func (s SecretBytes) LogValue() slog.Value {
+	return slog.StringValue(redacted)
+}
+
+// ----------------------------------------------------------------------------
+//  Methods of Hashed
+// ----------------------------------------------------------------------------
+
+// IsValidSecret is similar to IsValidPassword() but takes the password wrapped
+// in SecretBytes, so that it can be kept in a non-logging wrapper end to end.
+func (h *Hashed) IsValidSecret(secret SecretBytes) bool {
+	return h.IsValidPassword(secret.secret)
+}
@@ -0,0 +1,73 @@
+package argonize_test
+
+import (
+	"bytes"
+	"fmt"
+	"log/slog"
+	"testing"
+
+	"github.com/KEINOS/go-argonize"
+	"github.com/stretchr/testify/require"
+)
+
+// ----------------------------------------------------------------------------
+//  SecretBytes
+// ----------------------------------------------------------------------------
+
+func TestSecretBytes_redaction(t *testing.T) {
+	t.Parallel()
+
+	secret := argonize.NewSecretBytes([]byte("my password"))
+
+	for _, format := range []string{"%v", "%+v", "%s", "%q", "%x", "%X", "%d", "%08b"} {
+		out := fmt.Sprintf(format, secret)
+
+		require.Equal(t, "[REDACTED]", out, format)
+	}
+
+	require.Equal(t, "argonize.SecretBytes{[REDACTED]}", fmt.Sprintf("%#v", secret))
+	require.Equal(t, "[REDACTED]", secret.String())
+	require.Equal(t, "argonize.SecretBytes{[REDACTED]}", secret.GoString())
+
+	// Nested in a struct
+	out := fmt.Sprintf("%+v", struct{ Password argonize.SecretBytes }{secret})
+
+	require.NotContains(t, out, "my password")
+
+	// Logged with slog
+	var buf bytes.Buffer
+
+	logger := slog.New(slog.NewTextHandler(&buf, nil))
+	logger.Info("login", "password", secret)
+
+	require.Contains(t, buf.String(), "password=[REDACTED]")
+	require.NotContains(t, buf.String(), "my password")
+}
+
+func TestSecretBytes_Reveal(t *testing.T) {
+	t.Parallel()
+
+	original := []byte("my password")
+	secret := argonize.NewSecretBytes(original)
+
+	require.Equal(t, []byte("my password"), secret.Reveal())
+
+	// It should hold a copy
+	original[0] = 'X'
+
+	require.Equal(t, []byte("my password"), secret.Reveal())
+}
+
+// ----------------------------------------------------------------------------
+//  Hashed.IsValidSecret()
+// ----------------------------------------------------------------------------
+
+func TestHashed_IsValidSecret(t *testing.T) {
+	t.Parallel()
+
+	hashedObj, err := argonize.Hash([]byte("my password"))
+	require.NoError(t, err)
+
+	require.True(t, hashedObj.IsValidSecret(argonize.NewSecretBytes([]byte("my password"))))
+	require.False(t, hashedObj.IsValidSecret(argonize.NewSecretBytes([]byte("wrong password"))))
+}
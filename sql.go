@@ -0,0 +1,62 @@
+package argonize
+
+import (
+	"database/sql/driver"
+
+	"github.com/pkg/errors"
+)
+
+// ----------------------------------------------------------------------------
+//  Methods of Hashed
+// ----------------------------------------------------------------------------
+
+// Scan implements the sql.Scanner interface. It decodes the PHC string of a
+// string or []byte column, such as VARCHAR or CHAR, into h. The filler of the
+// fixed-width columns is trimmed as in DecodeHashStr().
+//
+// A NULL column resets h to the zero value, which IsZero() reports. The ORMs
+// relying on sql.Scanner, such as GORM and ent, surface the decode errors at
+// query time. See the argonizegorm and argonizeent modules for their serializer
+// and field.
+func (h *Hashed) Scan(src any) error {
+	var text string
+
+	switch value := src.(type) {
+	case nil:
+		*h = Hashed{}
+
+		return nil
+	case string:
+		text = value
+	case []byte:
+		text = string(value)
+	default:
+		return errors.Errorf("failed to scan the hash: unsupported type %T", src)
+	}
+
+	decoded, err := DecodeHashStr(text)
+	if err != nil {
+		return errors.Wrap(err, "failed to scan the hash")
+	}
+
+	*h = *decoded
+
+	return nil
+}
+
+// Value implements the driver.Valuer interface. It stores the hash as the PHC
+// string of String(). A nil h is stored as NULL.
+//
+// It returns an error if the hash is invalid, so that a broken hash is not
+// stored silently.
+func (h *Hashed) Value() (driver.Value, error) {
+	if h == nil {
+		return nil, nil //nolint:nilnil // nil is NULL
+	}
+
+	if err := h.Validate(); err != nil {
+		return nil, errors.Wrap(err, "failed to store the hash")
+	}
+
+	return h.String(), nil
+}
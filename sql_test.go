@@ -0,0 +1,92 @@
+package argonize_test
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"testing"
+
+	"github.com/KEINOS/go-argonize"
+	"github.com/stretchr/testify/require"
+)
+
+// Compile-time checks of the interfaces.
+var (
+	_ sql.Scanner   = (*argonize.Hashed)(nil)
+	_ driver.Valuer = (*argonize.Hashed)(nil)
+)
+
+// ----------------------------------------------------------------------------
+//  Hashed.Scan()
+// ----------------------------------------------------------------------------
+
+func TestHashed_Scan(t *testing.T) {
+	t.Parallel()
+
+	for _, src := range []any{
+		sampleHashStr,
+		[]byte(sampleHashStr),
+		sampleHashStr + "      ", // CHAR column with filler
+	} {
+		var hashedObj argonize.Hashed
+
+		require.NoError(t, hashedObj.Scan(src), "src: %#v", src)
+		require.Equal(t, sampleHashStr, hashedObj.String())
+	}
+}
+
+func TestHashed_Scan_null(t *testing.T) {
+	t.Parallel()
+
+	hashedObj, err := argonize.DecodeHashStr(sampleHashStr)
+	require.NoError(t, err)
+
+	require.NoError(t, hashedObj.Scan(nil))
+	require.True(t, hashedObj.IsZero(), "NULL should reset to the zero value")
+}
+
+func TestHashed_Scan_error(t *testing.T) {
+	t.Parallel()
+
+	var hashedObj argonize.Hashed
+
+	err := hashedObj.Scan(int64(42))
+	require.EqualError(t, err, "failed to scan the hash: unsupported type int64")
+
+	err = hashedObj.Scan("$argon2id$v=19$m=65536,t=3,p=2$!!!$" + sampleHashB64)
+	require.ErrorIs(t, err, argonize.ErrInvalidSalt)
+	require.ErrorContains(t, err, "failed to scan the hash")
+	require.True(t, hashedObj.IsZero(), "it should not be modified on error")
+}
+
+// ----------------------------------------------------------------------------
+//  Hashed.Value()
+// ----------------------------------------------------------------------------
+
+func TestHashed_Value(t *testing.T) {
+	t.Parallel()
+
+	hashedObj, err := argonize.DecodeHashStr(sampleHashStr)
+	require.NoError(t, err)
+
+	value, err := hashedObj.Value()
+	require.NoError(t, err)
+	require.Equal(t, sampleHashStr, value)
+
+	// Round trip
+	var scanned argonize.Hashed
+
+	require.NoError(t, scanned.Scan(value))
+	require.Equal(t, hashedObj.String(), scanned.String())
+
+	// Nil is NULL
+	var nilHash *argonize.Hashed
+
+	value, err = nilHash.Value()
+	require.NoError(t, err)
+	require.Nil(t, value)
+
+	// Invalid hash is not stored
+	value, err = new(argonize.Hashed).Value()
+	require.ErrorContains(t, err, "failed to store the hash")
+	require.Nil(t, value)
+}
@@ -0,0 +1,70 @@
+package argonize
+
+import (
+	"crypto/sha256"
+	"io"
+
+	"github.com/pkg/errors"
+	"golang.org/x/crypto/hkdf"
+)
+
+// hkdfLengthMax is the maximum output length of HKDF-SHA256 in bytes.
+const hkdfLengthMax = 255 * sha256.Size
+
+// ============================================================================
+//  Methods of Hashed
+// ============================================================================
+
+// DeriveKeys verifies the password once and derives a subkey for each of the
+// infos with HKDF-SHA256, such as a session key and an encryption key after a
+// login, without running the expensive KDF per subkey. The i-th subkey has the
+// lengths[i] bytes and is bound to the infos[i] label, so use distinct labels
+// for the independent keys, e.g. "myapp session v1".
+//
+// The input key material is the key derived by VerifyAndExtract(), and the salt
+// of HKDF is the salt of the hash. As for VerifyAndExtract(), the subkeys are
+// as secret as the hash record itself: anyone holding the stored Hash can
+// derive them without the password. Do not use them to protect data from the
+// ones with access to the hash records.
+//
+// It returns an error wrapping ErrMismatchedHashAndPassword if the password
+// does not match, and an error if the infos and the lengths differ in count, a
+// length is zero or over 8160 bytes, or the verification failed. The inputs
+// are checked before the verification.
+func (h *Hashed) DeriveKeys(password []byte, infos [][]byte, lengths []uint32) ([][]byte, error) {
+	if len(infos) != len(lengths) {
+		return nil, errors.Errorf("failed to derive keys: %d infos and %d lengths mismatch", len(infos), len(lengths))
+	}
+
+	for index, length := range lengths {
+		if length == 0 || length > hkdfLengthMax {
+			return nil, errors.Errorf("failed to derive keys: length %d at index %d is out of range 1..%d",
+				length, index, hkdfLengthMax)
+		}
+	}
+
+	ok, derived, err := h.VerifyAndExtract(password)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to derive keys")
+	}
+
+	if !ok {
+		return nil, errors.Wrap(ErrMismatchedHashAndPassword, "failed to derive keys")
+	}
+
+	defer clear(derived)
+
+	subkeys := make([][]byte, len(infos))
+
+	for index, info := range infos {
+		subkey := make([]byte, lengths[index])
+
+		if _, err := io.ReadFull(hkdf.New(sha256.New, derived, h.Salt, info), subkey); err != nil {
+			return nil, errors.Wrapf(err, "failed to derive keys: failed to derive the key at index %d", index)
+		}
+
+		subkeys[index] = subkey
+	}
+
+	return subkeys, nil
+}
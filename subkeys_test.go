@@ -0,0 +1,83 @@
+package argonize_test
+
+import (
+	"crypto/sha256"
+	"io"
+	"testing"
+
+	"github.com/KEINOS/go-argonize"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/crypto/hkdf"
+)
+
+// ----------------------------------------------------------------------------
+//  Hashed.DeriveKeys()
+// ----------------------------------------------------------------------------
+
+func TestHashed_DeriveKeys(t *testing.T) {
+	t.Parallel()
+
+	password := []byte("password")
+
+	hashedObj, err := argonize.HashCustomChecked(password, []byte("0123456789abcdef"), lowCostParams())
+	require.NoError(t, err)
+
+	infos := [][]byte{[]byte("session"), []byte("encryption"), []byte("")}
+	lengths := []uint32{32, 16, 64}
+
+	subkeys, err := hashedObj.DeriveKeys(password, infos, lengths)
+	require.NoError(t, err)
+	require.Len(t, subkeys, 3)
+
+	for index, subkey := range subkeys {
+		require.Len(t, subkey, int(lengths[index]))
+
+		// It should be the HKDF-SHA256 of the derived key
+		expect := make([]byte, lengths[index])
+		_, err := io.ReadFull(hkdf.New(sha256.New, hashedObj.Hash, hashedObj.Salt, infos[index]), expect)
+		require.NoError(t, err)
+		require.Equal(t, expect, subkey, "index %d", index)
+	}
+
+	require.NotEqual(t, subkeys[0][:16], subkeys[1], "distinct infos should derive independent keys")
+
+	// Deterministic
+	again, err := hashedObj.DeriveKeys(password, infos, lengths)
+	require.NoError(t, err)
+	require.Equal(t, subkeys, again)
+
+	// No infos
+	subkeys, err = hashedObj.DeriveKeys(password, nil, nil)
+	require.NoError(t, err)
+	require.Empty(t, subkeys)
+}
+
+func TestHashed_DeriveKeys_errors(t *testing.T) {
+	t.Parallel()
+
+	password := []byte("password")
+
+	hashedObj, err := argonize.HashCustomChecked(password, nil, lowCostParams())
+	require.NoError(t, err)
+
+	subkeys, err := hashedObj.DeriveKeys([]byte("wrong"), [][]byte{[]byte("session")}, []uint32{32})
+	require.ErrorIs(t, err, argonize.ErrMismatchedHashAndPassword)
+	require.Nil(t, subkeys)
+
+	_, err = hashedObj.DeriveKeys(password, [][]byte{[]byte("a"), []byte("b")}, []uint32{32})
+	require.ErrorContains(t, err, "2 infos and 1 lengths mismatch")
+
+	_, err = hashedObj.DeriveKeys(password, [][]byte{[]byte("a")}, []uint32{0})
+	require.ErrorContains(t, err, "length 0 at index 0 is out of range")
+
+	_, err = hashedObj.DeriveKeys(password, [][]byte{[]byte("a")}, []uint32{255*32 + 1})
+	require.ErrorContains(t, err, "is out of range")
+
+	_, err = new(argonize.Hashed).DeriveKeys(password, nil, nil)
+	require.ErrorContains(t, err, "the hash is uninitialized")
+
+	hashedObj.KeyID = "v1"
+
+	_, err = hashedObj.DeriveKeys(password, nil, nil)
+	require.ErrorIs(t, err, argonize.ErrPepperUnavailable)
+}
@@ -0,0 +1,82 @@
+package argonize
+
+import (
+	"sync"
+)
+
+// ============================================================================
+//  Thread Gate
+// ============================================================================
+//
+// RFC 9106 distinguishes the number of lanes (p) from the number of threads
+// computing them. The "golang.org/x/crypto/argon2" package ties them together:
+// each hashing computes its p lanes in p goroutines, and there is no way to
+// limit it per call.
+//
+// As the closest approximation, the hashings whose Params.MaxThreads is set
+// share a process-wide gate per MaxThreads value. Each hashing occupies
+// min(p, MaxThreads) slots of the MaxThreads slots of the gate while running,
+// so the hashings using the same MaxThreads never occupy more than MaxThreads
+// threads in total, except for a single hashing with p > MaxThreads which still
+// runs p goroutines on its own.
+
+//nolint:gochecknoglobals // process-wide gates shared by all the hashings
+var (
+	threadGates   = make(map[uint8]*threadGate)
+	threadGatesMu sync.Mutex
+)
+
+// threadGate is a weighted semaphore limiting the number of threads used by
+// the concurrent hashings.
+type threadGate struct {
+	cond  *sync.Cond
+	inUse uint8
+	limit uint8
+}
+
+// ----------------------------------------------------------------------------
+//  Private Functions
+// ----------------------------------------------------------------------------
+
+// acquireThreads blocks until the threads for a hashing with the params are
+// available in the gate of params.MaxThreads. It returns the function to
+// release them. If MaxThreads is zero, it does nothing.
+func acquireThreads(params *Params) func() {
+	if params.MaxThreads == 0 {
+		return func() {}
+	}
+
+	threadGatesMu.Lock()
+
+	gate, ok := threadGates[params.MaxThreads]
+	if !ok {
+		gate = &threadGate{
+			cond:  sync.NewCond(new(sync.Mutex)),
+			limit: params.MaxThreads,
+		}
+		threadGates[params.MaxThreads] = gate
+	}
+
+	threadGatesMu.Unlock()
+
+	weight := min(params.Parallelism, params.MaxThreads)
+
+	gate.cond.L.Lock()
+
+	for gate.inUse+weight > gate.limit {
+		gate.cond.Wait()
+	}
+
+	gate.inUse += weight
+
+	gate.cond.L.Unlock()
+
+	return func() {
+		gate.cond.L.Lock()
+
+		gate.inUse -= weight
+
+		gate.cond.L.Unlock()
+		gate.cond.Broadcast()
+	}
+}
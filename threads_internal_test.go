@@ -0,0 +1,71 @@
+package argonize
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+// The gate is unexported, so it is tested from within the package.
+func TestAcquireThreads(t *testing.T) {
+	t.Parallel()
+
+	// Unique MaxThreads value to not share the gate with the other tests.
+	const maxThreads = 3
+
+	params := &Params{Parallelism: 2, MaxThreads: maxThreads}
+
+	release1 := acquireThreads(params) // 2 of 3 in use
+
+	acquired := make(chan func())
+
+	go func() {
+		acquired <- acquireThreads(params) // needs 2 more, must wait
+	}()
+
+	select {
+	case <-acquired:
+		t.Fatal("it should block while the threads are in use")
+	case <-time.After(50 * time.Millisecond):
+	}
+
+	// p=1 fits in the remaining slot.
+	release3 := acquireThreads(&Params{Parallelism: 1, MaxThreads: maxThreads})
+
+	release1()
+	release3()
+
+	select {
+	case release2 := <-acquired:
+		release2()
+	case <-time.After(5 * time.Second):
+		t.Fatal("it should acquire after the release")
+	}
+
+	// p larger than MaxThreads runs alone instead of dead-locking.
+	release4 := acquireThreads(&Params{Parallelism: 8, MaxThreads: maxThreads})
+	release4()
+
+	// Zero MaxThreads means no gate.
+	acquireThreads(&Params{Parallelism: 8})()
+}
+
+func TestHashWithSalt_max_threads(t *testing.T) {
+	t.Parallel()
+
+	params := NewParams()
+	params.MemoryCost = 32
+	params.Parallelism = 4
+	params.MaxThreads = 2
+
+	hashed, err := HashWithSalt([]byte("password"), nil, params)
+
+	require.NoError(t, err)
+	require.True(t, hashed.IsValidPassword([]byte("password")))
+
+	decoded, err := DecodeHashStr(hashed.String())
+
+	require.NoError(t, err)
+	require.Zero(t, decoded.Params.MaxThreads, "MaxThreads should not be encoded")
+}
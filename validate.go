@@ -0,0 +1,232 @@
+package argonize
+
+import (
+	"errors"
+	"fmt"
+)
+
+// ============================================================================
+//  Public Variables
+// ============================================================================
+
+// Sentinel errors of Params.Validate(). Every violation wraps ErrInvalidParams
+// and one of the others. Check them with errors.Is().
+//
+//nolint:gochecknoglobals // sentinel errors
+var (
+	// ErrInvalidParams is wrapped by every error of Params.Validate().
+	ErrInvalidParams = errors.New("invalid params")
+	// ErrNilParams is the error of a nil Params.
+	ErrNilParams = errors.New("params are nil")
+	// ErrZeroParams is the error of a zero value Params, such as the one left
+	// by JSON decoding or an ORM when the field is missing. Use NewParams() for
+	// the defaults.
+	ErrZeroParams = errors.New("params are the zero value")
+	// ErrIterationsTooLow is the error of zero iterations.
+	ErrIterationsTooLow = errors.New("iterations must be at least 1")
+	// ErrParallelismTooLow is the error of zero parallelism.
+	ErrParallelismTooLow = errors.New("parallelism must be at least 1")
+	// ErrMemoryCostTooLow is the error of a memory cost less than 8 KiB per
+	// lane (8 * parallelism).
+	ErrMemoryCostTooLow = fmt.Errorf("memory cost must be at least %d KiB per lane", memoryPerLaneMin)
+	// ErrMemoryCostOverflow is the error of Params.WithMemoryMiB() with more
+	// than MemoryMiBMax, which overflows the memory cost in KiB.
+	ErrMemoryCostOverflow = fmt.Errorf("memory must be at most %d MiB", MemoryMiBMax)
+	// ErrKeyLengthTooShort is the error of a key length less than KeyLengthMin.
+	ErrKeyLengthTooShort = fmt.Errorf("key length must be at least %d", KeyLengthMin)
+	// ErrSaltLengthTooShort is the error of a salt length less than SaltLengthMin.
+	ErrSaltLengthTooShort = fmt.Errorf("salt length must be at least %d", SaltLengthMin)
+)
+
+// ============================================================================
+//  Type: InvalidHashError
+// ============================================================================
+
+// InvalidHashError is the error returned when a Hashed object is inconsistent,
+// such as missing parameters or a too short salt. Use errors.As() to check it.
+type InvalidHashError struct {
+	// Err is the underlying error, such as the error of Params.Validate(). It
+	// is nil if there is none.
+	Err error
+	// Field is the name of the inconsistent field. E.g. "Params", "Salt".
+	Field string
+	// Reason describes why the field is invalid.
+	Reason string
+}
+
+// Error implements the error interface.
+func (e *InvalidHashError) Error() string {
+	return "invalid hash: " + e.Field + ": " + e.Reason
+}
+
+// Unwrap returns the underlying error.
+func (e *InvalidHashError) Unwrap() error {
+	return e.Err
+}
+
+// ----------------------------------------------------------------------------
+//  Methods of Hashed
+// ----------------------------------------------------------------------------
+
+// IsZero returns true if h is nil or uninitialized, that is, it has no
+// parameters (nil or the zero value) or no hash. Such an object is typically
+// left by JSON decoding or an ORM when the column is missing.
+//
+// An uninitialized object never matches any password. IsValidPassword() returns
+// false for it instead of panicking.
+func (h *Hashed) IsZero() bool {
+	return h == nil || h.Params.IsZero() || len(h.Hash) == 0
+}
+
+// Validate returns an error if the fields of the Hashed object disagree, such
+// as nil Params, Params.KeyLength not matching the length of the Hash, a salt
+// shorter than Params.SaltLength or invalid parameters like zero iterations.
+//
+// Use it as a pre-flight check before trusting a record pulled from storage or
+// built by hand. The decoders of this package call it. Every violation is an
+// *InvalidHashError and they are joined with errors.Join().
+//
+// Note that the salt may be longer than Params.SaltLength if a pepper was
+// appended with Salt.AddPepper(). IsValidPassword() on an invalid object
+// returns false rather than panicking.
+func (h *Hashed) Validate() error {
+	return h.validate(false)
+}
+
+// validate is the implementation of Validate(). If allowEmptySalt is true, an
+// empty salt such as the one of HashSaltless() is accepted.
+func (h *Hashed) validate(allowEmptySalt bool) error {
+	if h == nil {
+		return &InvalidHashError{Field: "Hashed", Reason: "the object is nil"}
+	}
+
+	if h.isZeroValue() {
+		return &InvalidHashError{Field: "Hashed", Reason: "the object is the zero value"}
+	}
+
+	if h.Params == nil {
+		return &InvalidHashError{Field: "Params", Reason: "missing parameters"}
+	}
+
+	params := *h.Params
+	noSalt := allowEmptySalt && len(h.Salt) == 0
+
+	if noSalt {
+		params.SaltLength = SaltLengthMin // nothing to check
+	}
+
+	var errs []error
+
+	if len(h.Salt) < int(SaltLengthMin) && !noSalt {
+		errs = append(errs, &InvalidHashError{
+			Field:  "Salt",
+			Reason: fmt.Sprintf("length %d is shorter than the minimum %d", len(h.Salt), SaltLengthMin),
+		})
+	}
+
+	if uint64(len(h.Salt)) < uint64(params.SaltLength) && !noSalt {
+		errs = append(errs, &InvalidHashError{
+			Field:  "Salt",
+			Reason: fmt.Sprintf("length %d is shorter than Params.SaltLength %d", len(h.Salt), params.SaltLength),
+		})
+	}
+
+	if len(h.Hash) < int(KeyLengthMin) {
+		errs = append(errs, &InvalidHashError{
+			Field:  "Hash",
+			Reason: fmt.Sprintf("length %d is shorter than the minimum %d", len(h.Hash), KeyLengthMin),
+		})
+	}
+
+	if uint64(len(h.Hash)) != uint64(params.KeyLength) {
+		errs = append(errs, &InvalidHashError{
+			Field:  "Params.KeyLength",
+			Reason: fmt.Sprintf("%d does not match the hash length %d", params.KeyLength, len(h.Hash)),
+		})
+	}
+
+	if err := params.Validate(); err != nil {
+		errs = append(errs, &InvalidHashError{Err: err, Field: "Params", Reason: err.Error()})
+	}
+
+	return errors.Join(errs...)
+}
+
+// isZeroValue returns true if all the fields of h are zero. The zero value
+// Params is treated as nil.
+func (h *Hashed) isZeroValue() bool {
+	return h.Params.IsZero() && len(h.Salt) == 0 && len(h.Hash) == 0 && h.KeyID == "" && h.Data == ""
+}
+
+// ----------------------------------------------------------------------------
+//  Methods of Params
+// ----------------------------------------------------------------------------
+
+// IsZero returns true if p is nil or all of its fields are zero. Hashing with
+// such parameters is rejected with ErrZeroParams.
+func (p *Params) IsZero() bool {
+	return p == nil || *p == Params{}
+}
+
+// Validate returns an error if the parameters are out of the ranges allowed by
+// the Argon2 specification.
+//
+// The iterations and parallelism must be at least 1, the memory cost at least
+// 8 KiB per lane, the key length at least KeyLengthMin and the salt length at
+// least SaltLengthMin. The overflow of WithMemoryMiB() is reported as well.
+//
+// All the violations are reported at once, joined with errors.Join(). Each of
+// them wraps ErrInvalidParams and its own sentinel error such as
+// ErrIterationsTooLow, so they can be checked with errors.Is().
+func (p *Params) Validate() error {
+	if p == nil {
+		return invalidParams(ErrNilParams)
+	}
+
+	// Report the cause at once instead of every zero field.
+	if p.IsZero() {
+		return invalidParams(ErrZeroParams)
+	}
+
+	var errs []error
+
+	if p.Iterations < 1 {
+		errs = append(errs, invalidParams(ErrIterationsTooLow))
+	}
+
+	if p.Parallelism < 1 {
+		errs = append(errs, invalidParams(ErrParallelismTooLow))
+	}
+
+	if !p.Variant.isSupported() {
+		errs = append(errs, fmt.Errorf("%w: %w %q", ErrInvalidParams, ErrUnsupportedVariant, p.Variant))
+	}
+
+	if p.memoryMiBOverflow != 0 {
+		errs = append(errs, fmt.Errorf("%w: %w: got %d MiB",
+			ErrInvalidParams, ErrMemoryCostOverflow, p.memoryMiBOverflow))
+	}
+
+	if uint64(p.MemoryCost) < memoryPerLaneMin*uint64(p.Parallelism) {
+		errs = append(errs, invalidParams(ErrMemoryCostTooLow))
+	}
+
+	if p.KeyLength < KeyLengthMin {
+		errs = append(errs, invalidParams(ErrKeyLengthTooShort))
+	}
+
+	if p.SaltLength < SaltLengthMin {
+		errs = append(errs, invalidParams(ErrSaltLengthTooShort))
+	}
+
+	return errors.Join(errs...)
+}
+
+// ----------------------------------------------------------------------------
+//  Private Functions
+// ----------------------------------------------------------------------------
+
+// invalidParams returns an error wrapping ErrInvalidParams and the sentinel.
+func invalidParams(sentinel error) error {
+	return fmt.Errorf("%w: %w", ErrInvalidParams, sentinel)
+}
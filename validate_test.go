@@ -0,0 +1,175 @@
+package argonize_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/KEINOS/go-argonize"
+	"github.com/stretchr/testify/require"
+)
+
+// ----------------------------------------------------------------------------
+//  Hashed.Validate()
+// ----------------------------------------------------------------------------
+
+func TestHashed_Validate(t *testing.T) {
+	t.Parallel()
+
+	hashedObj, err := argonize.DecodeHashStr(sampleHashStr)
+	require.NoError(t, err)
+	require.NoError(t, hashedObj.Validate())
+
+	for _, test := range []struct {
+		modify     func(h *argonize.Hashed)
+		field      string
+		msgContain string
+	}{
+		{func(h *argonize.Hashed) { h.Params = nil }, "Params", "missing parameters"},
+		{func(h *argonize.Hashed) { h.Salt = h.Salt[:4] }, "Salt", "length 4 is shorter than the minimum 8"},
+		{func(h *argonize.Hashed) { h.Salt = h.Salt[:10] }, "Salt", "length 10 is shorter than Params.SaltLength 16"},
+		{func(h *argonize.Hashed) { h.Hash = h.Hash[:3] }, "Hash", "length 3 is shorter than the minimum 4"},
+		{func(h *argonize.Hashed) { h.Params.KeyLength = 64 }, "Params.KeyLength", "64 does not match the hash length 32"},
+		{func(h *argonize.Hashed) { h.Params.Iterations = 0 }, "Params", "iterations must be at least 1"},
+	} {
+		hashedObj, err := argonize.DecodeHashStr(sampleHashStr)
+		require.NoError(t, err)
+
+		test.modify(hashedObj)
+
+		err = hashedObj.Validate()
+
+		var invalidErr *argonize.InvalidHashError
+
+		require.True(t, errors.As(err, &invalidErr), "it should be an InvalidHashError")
+		require.Equal(t, test.field, invalidErr.Field)
+		require.ErrorContains(t, err, test.msgContain)
+
+		// It should not panic
+		require.False(t, hashedObj.IsValidPassword([]byte("password")))
+	}
+
+	var nilHashed *argonize.Hashed
+
+	require.ErrorContains(t, nilHashed.Validate(), "the object is nil")
+	require.False(t, nilHashed.IsValidPassword([]byte("password")))
+}
+
+func TestHashed_Validate_multiple_errors(t *testing.T) {
+	t.Parallel()
+
+	hashedObj, err := argonize.DecodeHashStr(sampleHashStr)
+	require.NoError(t, err)
+
+	hashedObj.Params.KeyLength = 64
+	hashedObj.Params.Iterations = 0
+	hashedObj.Params.Parallelism = 0
+
+	err = hashedObj.Validate()
+
+	require.ErrorContains(t, err, "does not match the hash length")
+	require.ErrorIs(t, err, argonize.ErrIterationsTooLow)
+	require.ErrorIs(t, err, argonize.ErrParallelismTooLow)
+}
+
+func TestHashed_Validate_peppered_salt(t *testing.T) {
+	t.Parallel()
+
+	params := lowCostParams()
+
+	salt, err := argonize.NewSalt(params.SaltLength)
+	require.NoError(t, err)
+
+	salt.AddPepper([]byte("pepper"))
+
+	hashedObj, err := argonize.HashWithSalt([]byte("password"), salt, params)
+	require.NoError(t, err)
+
+	require.NoError(t, hashedObj.Validate(), "a salt longer than SaltLength should be valid")
+}
+
+// ----------------------------------------------------------------------------
+//  Hashed.IsZero() / Params.IsZero()
+// ----------------------------------------------------------------------------
+
+func TestHashed_IsZero(t *testing.T) {
+	t.Parallel()
+
+	var nilHashed *argonize.Hashed
+
+	require.True(t, nilHashed.IsZero())
+	require.True(t, new(argonize.Hashed).IsZero())
+	require.True(t, (&argonize.Hashed{Params: new(argonize.Params)}).IsZero())
+
+	hashedObj, err := argonize.DecodeHashStr(sampleHashStr)
+	require.NoError(t, err)
+	require.False(t, hashedObj.IsZero())
+
+	// Uninitialized objects
+	require.True(t, (&argonize.Hashed{KeyID: "k1"}).IsZero())
+	require.True(t, (&argonize.Hashed{Hash: hashedObj.Hash}).IsZero(), "nil params should be zero")
+	require.True(t, (&argonize.Hashed{Params: hashedObj.Params, Salt: hashedObj.Salt}).IsZero(),
+		"empty hash should be zero")
+	require.True(t, (&argonize.Hashed{Params: new(argonize.Params), Salt: hashedObj.Salt, Hash: hashedObj.Hash}).IsZero(),
+		"zero value params should be zero")
+
+	for _, hashed := range []*argonize.Hashed{
+		nil,
+		{},
+		{Hash: hashedObj.Hash},
+		{Params: hashedObj.Params, Salt: hashedObj.Salt},
+	} {
+		require.NotPanics(t, func() {
+			require.False(t, hashed.IsValidPassword([]byte("password")))
+		})
+	}
+}
+
+func TestParams_IsZero(t *testing.T) {
+	t.Parallel()
+
+	var nilParams *argonize.Params
+
+	require.True(t, nilParams.IsZero())
+	require.True(t, new(argonize.Params).IsZero())
+	require.False(t, argonize.NewParams().IsZero())
+	require.False(t, (&argonize.Params{MaxThreads: 1}).IsZero())
+}
+
+func TestZeroValue_guards(t *testing.T) {
+	t.Parallel()
+
+	var (
+		hashedObj argonize.Hashed
+		params    argonize.Params
+	)
+
+	password := []byte("password")
+	salt := []byte("0123456789abcdef")
+
+	require.NotPanics(t, func() {
+		require.False(t, hashedObj.IsValidPassword(password))
+	})
+
+	err := hashedObj.Validate()
+	require.ErrorContains(t, err, "the zero value")
+
+	require.PanicsWithError(t,
+		"argonize: HashCustom: invalid params: params are the zero value",
+		func() {
+			_ = argonize.HashCustom(password, salt, &params)
+		})
+
+	_, err = argonize.HashWithSalt(password, salt, &params)
+	require.ErrorIs(t, err, argonize.ErrZeroParams)
+	require.ErrorIs(t, err, argonize.ErrInvalidParams)
+
+	err = params.Validate()
+	require.ErrorIs(t, err, argonize.ErrZeroParams)
+	require.NotErrorIs(t, err, argonize.ErrIterationsTooLow, "only the cause should be reported")
+
+	_, err = argonize.NewHasher(&params).Hash(password)
+	require.ErrorIs(t, err, argonize.ErrZeroParams)
+
+	_, err = argonize.NewHasher(nil).Verify(&argonize.Hashed{Params: &params}, password)
+	require.ErrorContains(t, err, "the hash is uninitialized")
+}
@@ -0,0 +1,78 @@
+package argonize
+
+import (
+	"fmt"
+)
+
+// ============================================================================
+//  Type: Variant
+// ============================================================================
+
+// Variant is the variant of the Argon2 algorithm. The empty Variant means
+// VariantArgon2id, the default.
+//
+// Note that argon2d is not supported since the "golang.org/x/crypto/argon2"
+// package does not implement it.
+type Variant string
+
+const (
+	// VariantArgon2id is the Argon2id variant. It is the recommended one and
+	// the default.
+	VariantArgon2id Variant = "argon2id"
+	// VariantArgon2i is the Argon2i variant. Use it only for interoperability
+	// with existing argon2i hashes.
+	VariantArgon2i Variant = "argon2i"
+)
+
+// ----------------------------------------------------------------------------
+//  Constructor of Variant
+// ----------------------------------------------------------------------------
+
+// ParseVariant returns the Variant of the given name as in the PHC string, such
+// as "argon2id". The default variant is returned as the empty Variant. The names
+// of the KDFs registered with RegisterKDF() are accepted as well.
+func ParseVariant(name string) (Variant, error) {
+	switch Variant(name) {
+	case VariantArgon2id:
+		return "", nil
+	case VariantArgon2i:
+		return VariantArgon2i, nil
+	}
+
+	if _, ok := registeredKDF(Variant(name)); ok {
+		return Variant(name), nil
+	}
+
+	return "", fmt.Errorf("%w %q", ErrUnsupportedVariant, name)
+}
+
+// ----------------------------------------------------------------------------
+//  Methods of Variant
+// ----------------------------------------------------------------------------
+
+// String returns the name of the variant as in the PHC string. The empty
+// Variant returns "argon2id".
+func (v Variant) String() string {
+	if v == "" {
+		return string(VariantArgon2id)
+	}
+
+	return string(v)
+}
+
+// ----------------------------------------------------------------------------
+//  Methods of Variant (Private)
+// ----------------------------------------------------------------------------
+
+// isSupported returns true if the variant is a built-in one or the name of a
+// registered KDF.
+func (v Variant) isSupported() bool {
+	switch v {
+	case "", VariantArgon2id, VariantArgon2i:
+		return true
+	}
+
+	_, ok := registeredKDF(v)
+
+	return ok
+}
@@ -0,0 +1,125 @@
+package argonize_test
+
+import (
+	"testing"
+
+	"github.com/KEINOS/go-argonize"
+	"github.com/stretchr/testify/require"
+)
+
+// ----------------------------------------------------------------------------
+//  ParseVariant()
+// ----------------------------------------------------------------------------
+
+func TestParseVariant(t *testing.T) {
+	t.Parallel()
+
+	variant, err := argonize.ParseVariant("argon2id")
+
+	require.NoError(t, err)
+	require.Empty(t, variant, "argon2id should be the default (empty) variant")
+	require.Equal(t, "argon2id", variant.String())
+
+	variant, err = argonize.ParseVariant("argon2i")
+
+	require.NoError(t, err)
+	require.Equal(t, argonize.VariantArgon2i, variant)
+
+	for _, name := range []string{"argon2d", "ARGON2ID", "", "scrypt"} {
+		_, err := argonize.ParseVariant(name)
+
+		require.Error(t, err, name)
+		require.Contains(t, err.Error(), "unsupported algorithm variant", name)
+	}
+}
+
+// ----------------------------------------------------------------------------
+//  Hashed.String() with variants
+// ----------------------------------------------------------------------------
+
+func TestHashed_String_argon2i_round_trip(t *testing.T) {
+	t.Parallel()
+
+	// Test vector of the reference implementation of Argon2 (phc-winner-argon2).
+	const encoded = "$argon2i$v=19$m=65536,t=2,p=1$c29tZXNhbHQ$wWKIMhR9lyDFvRz9YTZweHKfbftvj+qf+YFY4NeBbtA"
+
+	hashedObj, err := argonize.DecodeHashStr(encoded)
+	require.NoError(t, err)
+
+	require.Equal(t, argonize.VariantArgon2i, hashedObj.Params.Variant)
+	require.Equal(t, encoded, hashedObj.String(), "it should emit the stored variant")
+	require.True(t, hashedObj.IsValidPassword([]byte("password")))
+	require.False(t, hashedObj.IsValidPassword([]byte("wrong password")))
+}
+
+func TestHashCustom_argon2i(t *testing.T) {
+	t.Parallel()
+
+	params := argonize.NewParams()
+	params.MemoryCost = 8
+	params.Parallelism = 1
+	params.Variant = argonize.VariantArgon2i
+
+	hashedObj := argonize.HashCustom([]byte("password"), nil, params)
+	require.NotNil(t, hashedObj)
+
+	decoded, err := argonize.DecodeHashStr(hashedObj.String())
+	require.NoError(t, err)
+
+	require.Contains(t, decoded.String(), "$argon2i$")
+	require.True(t, decoded.IsValidPassword([]byte("password")))
+
+	// The same password and salt with argon2id should differ.
+	params.Variant = argonize.VariantArgon2id
+
+	otherObj := argonize.HashCustom([]byte("password"), hashedObj.Salt, params)
+	require.NotEqual(t, hashedObj.Hash, otherObj.Hash)
+}
+
+func TestHashWithSalt_unsupported_variant(t *testing.T) {
+	t.Parallel()
+
+	params := argonize.NewParams()
+	params.Variant = "argon2d"
+
+	hashedObj, err := argonize.HashWithSalt([]byte("password"), nil, params)
+
+	require.Error(t, err)
+	require.Contains(t, err.Error(), `unsupported algorithm variant "argon2d"`)
+	require.Nil(t, hashedObj, "it should be nil on error")
+
+	// Verification with an unsupported variant should be false.
+	valid := &argonize.Hashed{Params: params, Salt: make([]byte, 16), Hash: make([]byte, 32)}
+
+	require.False(t, valid.IsValidPassword([]byte("password")))
+	require.Error(t, params.Validate())
+}
+
+func TestDecodeHashStr_unsupported_variant(t *testing.T) {
+	t.Parallel()
+
+	hashedObj, err := argonize.DecodeHashStr(
+		"$argon2d$v=19$m=65536,t=3,p=2$Woo1mErn1s7AHf96ewQ8Uw$D4TzIwGO4XD2buk96qAP+Ed2baMo/KbTRMqXX00wtsU",
+	)
+
+	require.Error(t, err)
+	require.Contains(t, err.Error(), `unsupported algorithm variant "argon2d"`)
+	require.Nil(t, hashedObj, "it should be nil on error")
+}
+
+func TestEncodeBinary_argon2i(t *testing.T) {
+	t.Parallel()
+
+	hashedObj, err := argonize.DecodeHashStr(
+		"$argon2i$v=19$m=65536,t=2,p=1$c29tZXNhbHQ$wWKIMhR9lyDFvRz9YTZweHKfbftvj+qf+YFY4NeBbtA",
+	)
+	require.NoError(t, err)
+
+	out, err := argonize.EncodeBinary(hashedObj)
+	require.NoError(t, err)
+
+	decoded, err := argonize.DecodeBinary(out)
+	require.NoError(t, err)
+	require.Equal(t, argonize.VariantArgon2i, decoded.Params.Variant, "it should keep the variant")
+	require.True(t, decoded.IsValidPassword([]byte("password")))
+}
@@ -0,0 +1,122 @@
+package argonize
+
+import (
+	"crypto/subtle"
+	"fmt"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// ============================================================================
+//  Public Variables
+// ============================================================================
+
+// ErrRateLimited is the error returned when the verification is refused by the
+// Limiter. Check it with errors.Is().
+//
+//nolint:gochecknoglobals // sentinel error
+var ErrRateLimited = errors.New("too many attempts")
+
+// ============================================================================
+//  Type: Limiter
+// ============================================================================
+
+// Limiter decides whether a verification attempt for the key (such as a user
+// name or an IP address) is allowed. It is typically a token bucket per key.
+//
+// Implementations must be safe for concurrent use.
+type Limiter interface {
+	// Allow reports whether an attempt for the key is allowed now. It consumes
+	// a token if allowed.
+	Allow(key string) bool
+}
+
+// ============================================================================
+//  Methods of Hashed
+// ============================================================================
+
+// IsValidPasswordLimited is similar to IsValidPassword() but asks the limiter
+// first. If the attempt for the key is not allowed, it returns an error wrapping
+// ErrRateLimited without running the expensive key derivation.
+//
+// This mitigates online brute-force attacks against the key.
+func (h *Hashed) IsValidPasswordLimited(password []byte, limiter Limiter, key string) (bool, error) {
+	if limiter == nil {
+		return false, errors.New("failed to verify password: limiter is nil")
+	}
+
+	if !limiter.Allow(key) {
+		return false, errors.Wrap(ErrRateLimited, "failed to verify password")
+	}
+
+	return h.IsValidPassword(password), nil
+}
+
+// VerifyWithMinDuration is similar to IsValidPassword() but takes at least the
+// minDuration, sleeping for the rest if the verification completed faster. It
+// flattens the response time of the early failures, such as an uninitialized
+// hash, against the timing side channels. A zero or negative minDuration is the
+// same as IsValidPassword().
+//
+// It does not speed up the verifications slower than minDuration, so choose one
+// longer than a regular verification on a loaded machine. It is not meant to
+// hide legitimate latency either, such as a slow storage, and the sleeping
+// goroutine still holds the request. Use Hasher.WithConstantDuration() to also
+// stop waiting on a context.
+func (h *Hashed) VerifyWithMinDuration(password []byte, minDuration time.Duration) bool {
+	start := time.Now()
+
+	isValid := h.IsValidPassword(password)
+
+	if rest := minDuration - time.Since(start); rest > 0 {
+		time.Sleep(rest)
+	}
+
+	return isValid
+}
+
+// VerifyAndExtract is similar to IsValidPassword() but also returns the key
+// freshly derived from the password if it matches, so that the re-encryption
+// workflows, such as rotating the envelope encryption of the hash record at
+// each login, can use it without running the expensive KDF twice.
+//
+// The derived key is returned only if ok is true. On a mismatch or an error it
+// is nil, and the temporary key is zeroed. It never returns the stored Hash.
+//
+// Sharp edges:
+//
+//   - The derived key equals the stored Hash on success. It is the verifier of
+//     the password, so treat it as secret as the hash record itself and zero it
+//     with clear() after use. Do not log it.
+//   - It is not a key for encryption by itself. Derive the encryption keys
+//     from it with a KDF such as HKDF, rather than using it directly.
+//   - The hashes with a KeyID need the pepper, so it returns an error wrapping
+//     ErrPepperUnavailable for them. Use Hasher.Verify() instead.
+//
+// It returns an error if the hash is uninitialized or if the key derivation
+// failed, such as due to invalid parameters, rather than false.
+func (h *Hashed) VerifyAndExtract(password []byte) (ok bool, derived []byte, err error) {
+	if h.IsZero() {
+		return false, nil, errors.New("failed to verify password: the hash is uninitialized")
+	}
+
+	if h.KeyID != "" {
+		return false, nil, fmt.Errorf("failed to verify password: %w: key ID %q needs the pepper",
+			ErrPepperUnavailable, h.KeyID)
+	}
+
+	// The KDF returns a new slice, which is handed over as is on success.
+	key, err := deriveKey(password, h.Salt, h.Params)
+	if err != nil {
+		return false, nil, errors.Wrap(err, "failed to verify password")
+	}
+
+	if subtle.ConstantTimeCompare(h.Hash, key) != 1 {
+		clear(key)
+
+		return false, nil, nil
+	}
+
+	return true, key, nil
+}
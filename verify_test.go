@@ -0,0 +1,155 @@
+package argonize_test
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/KEINOS/go-argonize"
+	"github.com/stretchr/testify/require"
+)
+
+// ----------------------------------------------------------------------------
+//  Hashed.IsValidPasswordLimited()
+// ----------------------------------------------------------------------------
+
+// countLimiter allows the first n attempts per key.
+type countLimiter struct {
+	attempts map[string]int
+	limit    int
+}
+
+func (l *countLimiter) Allow(key string) bool {
+	l.attempts[key]++
+
+	return l.attempts[key] <= l.limit
+}
+
+func TestHashed_IsValidPasswordLimited(t *testing.T) {
+	t.Parallel()
+
+	params := argonize.NewParams()
+	params.MemoryCost = 8
+	params.Parallelism = 1
+
+	hashedObj := argonize.HashCustom([]byte("password"), nil, params)
+	limiter := &countLimiter{attempts: map[string]int{}, limit: 2}
+
+	isValid, err := hashedObj.IsValidPasswordLimited([]byte("wrong"), limiter, "alice")
+	require.NoError(t, err)
+	require.False(t, isValid)
+
+	isValid, err = hashedObj.IsValidPasswordLimited([]byte("password"), limiter, "alice")
+	require.NoError(t, err)
+	require.True(t, isValid)
+
+	// Third attempt is refused even with the right password.
+	isValid, err = hashedObj.IsValidPasswordLimited([]byte("password"), limiter, "alice")
+	require.Error(t, err)
+	require.True(t, errors.Is(err, argonize.ErrRateLimited), "it should wrap ErrRateLimited")
+	require.False(t, isValid)
+
+	// Other keys are not affected.
+	isValid, err = hashedObj.IsValidPasswordLimited([]byte("password"), limiter, "bob")
+	require.NoError(t, err)
+	require.True(t, isValid)
+}
+
+func TestHashed_IsValidPasswordLimited_nil_limiter(t *testing.T) {
+	t.Parallel()
+
+	isValid, err := new(argonize.Hashed).IsValidPasswordLimited([]byte("password"), nil, "alice")
+
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "limiter is nil")
+	require.False(t, isValid)
+}
+
+// ----------------------------------------------------------------------------
+//  Hashed.VerifyWithMinDuration()
+// ----------------------------------------------------------------------------
+
+func TestHashed_VerifyWithMinDuration(t *testing.T) {
+	t.Parallel()
+
+	const minDuration = 100 * time.Millisecond
+
+	hashedObj, err := argonize.HashCustomChecked([]byte("password"), nil, lowCostParams())
+	require.NoError(t, err)
+
+	for _, test := range []struct {
+		hashed   *argonize.Hashed
+		password string
+		want     bool
+	}{
+		{hashed: hashedObj, password: "password", want: true},
+		{hashed: hashedObj, password: "wrong password", want: false},
+		{hashed: nil, password: "password", want: false}, // early failure
+	} {
+		start := time.Now()
+
+		require.Equal(t, test.want, test.hashed.VerifyWithMinDuration([]byte(test.password), minDuration))
+		require.GreaterOrEqual(t, time.Since(start), minDuration, "it should take at least the min duration")
+	}
+
+	// No min duration
+	start := time.Now()
+
+	require.True(t, hashedObj.VerifyWithMinDuration([]byte("password"), 0))
+	require.Less(t, time.Since(start), minDuration)
+}
+
+// ----------------------------------------------------------------------------
+//  Hashed.VerifyAndExtract()
+// ----------------------------------------------------------------------------
+
+func TestHashed_VerifyAndExtract(t *testing.T) {
+	t.Parallel()
+
+	hashedObj, err := argonize.HashCustomChecked([]byte("password"), nil, lowCostParams())
+	require.NoError(t, err)
+
+	ok, derived, err := hashedObj.VerifyAndExtract([]byte("password"))
+	require.NoError(t, err)
+	require.True(t, ok)
+	require.Equal(t, hashedObj.Hash, derived)
+
+	// It should be a copy rather than the stored hash
+	clear(derived)
+	require.NotEqual(t, derived, hashedObj.Hash)
+	require.True(t, hashedObj.IsValidPassword([]byte("password")))
+
+	ok, derived, err = hashedObj.VerifyAndExtract([]byte("wrong password"))
+	require.NoError(t, err)
+	require.False(t, ok)
+	require.Nil(t, derived, "nothing should be returned on mismatch")
+}
+
+func TestHashed_VerifyAndExtract_errors(t *testing.T) {
+	t.Parallel()
+
+	for _, hashedObj := range []*argonize.Hashed{nil, {}, {Params: lowCostParams()}} {
+		ok, derived, err := hashedObj.VerifyAndExtract([]byte("password"))
+		require.ErrorContains(t, err, "the hash is uninitialized")
+		require.False(t, ok)
+		require.Nil(t, derived)
+	}
+
+	hashedObj, err := argonize.HashCustomChecked([]byte("password"), nil, lowCostParams())
+	require.NoError(t, err)
+
+	hashedObj.KeyID = "v1"
+
+	ok, derived, err := hashedObj.VerifyAndExtract([]byte("password"))
+	require.ErrorIs(t, err, argonize.ErrPepperUnavailable)
+	require.False(t, ok)
+	require.Nil(t, derived)
+
+	hashedObj.KeyID = ""
+	hashedObj.Params.Iterations = 0
+
+	ok, derived, err = hashedObj.VerifyAndExtract([]byte("password"))
+	require.ErrorIs(t, err, argonize.ErrIterationsTooLow)
+	require.False(t, ok)
+	require.Nil(t, derived)
+}
@@ -0,0 +1,107 @@
+package argonize
+
+import (
+	"github.com/pkg/errors"
+)
+
+// ============================================================================
+//  Type: AuthResult
+// ============================================================================
+
+// AuthResult is the result of VerifyAndUpgrade().
+type AuthResult struct {
+	// NewHash is the encoded hash of the password under the target parameters.
+	// It is set only if Valid and NeedsRehash are true. Store it in place of
+	// the old one.
+	NewHash string
+	// Valid is true if the password matches the hash.
+	Valid bool
+	// NeedsRehash is true if the password is valid and the hash was made with
+	// parameters other than the target ones.
+	NeedsRehash bool
+}
+
+// ============================================================================
+//  Functions
+// ============================================================================
+
+// VerifyAndUpgrade bundles what a login handler needs in one call: it decodes
+// the hash, verifies the password and, if the password is valid but the hash
+// was made with parameters other than the target, rehashes the password under
+// the target with a new random salt.
+//
+//	result, err := argonize.VerifyAndUpgrade(stored, password, target)
+//	if err != nil || !result.Valid {
+//	    // reject the login
+//	}
+//
+//	if result.NewHash != "" {
+//	    // store result.NewHash in place of stored
+//	}
+//
+// The hash needs a rehash if any of the variant, memory cost, iterations,
+// parallelism, key length or salt length differ from the target. If target is
+// nil, the package-wide default parameters are the target.
+//
+// A wrong password is Valid=false with a nil error. A malformed hash is
+// reported as the *ParseError of DecodeHashStr(), and a hash with a KeyID as an
+// error wrapping ErrPepperUnavailable, since it needs the pepper of
+// Hasher.Verify(). If only the rehash fails, it returns the result with Valid
+// and NeedsRehash set but without NewHash, along with the error, so that the
+// login can still succeed.
+func VerifyAndUpgrade(encodedHash string, password []byte, target *Params) (AuthResult, error) {
+	if target == nil {
+		target = DefaultParams()
+	}
+
+	if err := target.Validate(); err != nil {
+		return AuthResult{}, errors.Wrap(err, "failed to verify and upgrade: invalid target params")
+	}
+
+	hashed, err := DecodeHashStr(encodedHash)
+	if err != nil {
+		return AuthResult{}, errors.Wrap(err, "failed to verify and upgrade")
+	}
+
+	isValid, err := new(Hasher).verify(hashed, password)
+	if err != nil {
+		return AuthResult{}, errors.Wrap(err, "failed to verify and upgrade")
+	}
+
+	if !isValid {
+		return AuthResult{}, nil
+	}
+
+	result := AuthResult{
+		Valid:       true,
+		NeedsRehash: hashed.needsRehash(target),
+	}
+
+	if !result.NeedsRehash {
+		return result, nil
+	}
+
+	rehashed, err := HashWithSalt(password, nil, target)
+	if err != nil {
+		return result, errors.Wrap(err, "failed to verify and upgrade: failed to rehash")
+	}
+
+	result.NewHash = rehashed.String()
+
+	return result, nil
+}
+
+// ----------------------------------------------------------------------------
+//  Methods of Hashed (Private)
+// ----------------------------------------------------------------------------
+
+// needsRehash returns true if the hash was made with parameters other than the
+// target. The lengths are taken from the salt and hash themselves.
+func (h *Hashed) needsRehash(target *Params) bool {
+	return h.Params.Variant.String() != target.Variant.String() ||
+		h.Params.MemoryCost != target.MemoryCost ||
+		h.Params.Iterations != target.Iterations ||
+		h.Params.Parallelism != target.Parallelism ||
+		len(h.Hash) != int(target.KeyLength) ||
+		len(h.Salt) != int(target.SaltLength)
+}
@@ -0,0 +1,101 @@
+package argonize_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/KEINOS/go-argonize"
+	"github.com/stretchr/testify/require"
+)
+
+// ----------------------------------------------------------------------------
+//  VerifyAndUpgrade()
+// ----------------------------------------------------------------------------
+
+func TestVerifyAndUpgrade(t *testing.T) {
+	t.Parallel()
+
+	hashedObj, err := argonize.HashCustomChecked([]byte("password"), nil, lowCostParams())
+	require.NoError(t, err)
+
+	stored := hashedObj.String()
+
+	// Up to date
+	result, err := argonize.VerifyAndUpgrade(stored, []byte("password"), lowCostParams())
+	require.NoError(t, err)
+	require.Equal(t, argonize.AuthResult{Valid: true}, result)
+
+	// Wrong password
+	result, err = argonize.VerifyAndUpgrade(stored, []byte("wrong password"), lowCostParams())
+	require.NoError(t, err)
+	require.Equal(t, argonize.AuthResult{}, result)
+
+	// Outdated
+	for _, modify := range []func(p *argonize.Params){
+		func(p *argonize.Params) { p.MemoryCost *= 2 },
+		func(p *argonize.Params) { p.Iterations++ },
+		func(p *argonize.Params) { p.Parallelism++ },
+		func(p *argonize.Params) { p.KeyLength = 64 },
+		func(p *argonize.Params) { p.SaltLength = 32 },
+		func(p *argonize.Params) { p.Variant = argonize.VariantArgon2i },
+	} {
+		target := lowCostParams()
+		modify(target)
+
+		result, err = argonize.VerifyAndUpgrade(stored, []byte("password"), target)
+		require.NoError(t, err)
+		require.True(t, result.Valid)
+		require.True(t, result.NeedsRehash, "target: %v", target)
+		require.NotEmpty(t, result.NewHash)
+
+		rehashed, err := argonize.DecodeHashStr(result.NewHash)
+		require.NoError(t, err)
+		require.Equal(t, target.EncodeParams(), rehashed.Params.EncodeParams())
+		require.True(t, rehashed.IsValidPassword([]byte("password")))
+
+		// The new hash should be up to date
+		result, err = argonize.VerifyAndUpgrade(result.NewHash, []byte("password"), target)
+		require.NoError(t, err)
+		require.Equal(t, argonize.AuthResult{Valid: true}, result)
+	}
+
+	// Outdated but wrong password
+	target := lowCostParams()
+	target.Iterations = 2
+
+	result, err = argonize.VerifyAndUpgrade(stored, []byte("wrong password"), target)
+	require.NoError(t, err)
+	require.Equal(t, argonize.AuthResult{}, result, "it should not rehash a wrong password")
+}
+
+func TestVerifyAndUpgrade_default_target(t *testing.T) {
+	t.Parallel()
+
+	hashedObj, err := argonize.HashCustomChecked([]byte("password"), nil, lowCostParams())
+	require.NoError(t, err)
+
+	result, err := argonize.VerifyAndUpgrade(hashedObj.String(), []byte("password"), nil)
+	require.NoError(t, err)
+	require.True(t, result.Valid)
+	require.True(t, result.NeedsRehash, "low cost params should be outdated against the defaults")
+	require.NotEmpty(t, result.NewHash)
+}
+
+func TestVerifyAndUpgrade_errors(t *testing.T) {
+	t.Parallel()
+
+	result, err := argonize.VerifyAndUpgrade("invalid", []byte("password"), lowCostParams())
+	require.ErrorIs(t, err, argonize.ErrInvalidFormat)
+	require.Equal(t, argonize.AuthResult{}, result)
+
+	withKeyID := strings.Replace(sampleHashStr, "p=2$", "p=2,keyid=djE$", 1)
+
+	result, err = argonize.VerifyAndUpgrade(withKeyID, []byte("password"), lowCostParams())
+	require.ErrorIs(t, err, argonize.ErrPepperUnavailable)
+	require.Equal(t, argonize.AuthResult{}, result)
+
+	result, err = argonize.VerifyAndUpgrade(sampleHashStr, []byte("password"), &argonize.Params{})
+	require.ErrorIs(t, err, argonize.ErrZeroParams)
+	require.ErrorContains(t, err, "invalid target params")
+	require.Equal(t, argonize.AuthResult{}, result)
+}
@@ -0,0 +1,159 @@
+package argonize
+
+import (
+	"fmt"
+)
+
+// Thresholds of WarnIfWeak().
+const (
+	// warnMemorySinglePass is the memory cost in KiB below which a single pass
+	// is considered weak. It is the memory of PresetRFC9106Second.
+	warnMemorySinglePass = 64 * 1024
+	// warnParallelismMax is the parallelism above the core counts of the
+	// typical servers.
+	warnParallelismMax = 16
+	// warnKeyLengthMin is the key length in bytes of RFC 9106 (256-bit tag).
+	warnKeyLengthMin = 32
+	// warnSaltLengthMin is the salt length in bytes of RFC 9106 (128-bit salt).
+	warnSaltLengthMin = 16
+)
+
+// ============================================================================
+//  Type: Severity
+// ============================================================================
+
+// Severity is the severity of a Warning.
+type Severity int
+
+const (
+	// SeverityLow is a questionable but harmless choice, such as the
+	// parallelism exceeding the CPU cores.
+	SeverityLow Severity = iota + 1
+	// SeverityMedium weakens the hash below the recommendations.
+	SeverityMedium
+	// SeverityHigh makes the hash cheap to attack. Fix it before production.
+	SeverityHigh
+)
+
+// ----------------------------------------------------------------------------
+//  Methods of Severity
+// ----------------------------------------------------------------------------
+
+// String returns the name of the severity, such as "high".
+func (s Severity) String() string {
+	switch s {
+	case SeverityLow:
+		return "low"
+	case SeverityMedium:
+		return "medium"
+	case SeverityHigh:
+		return "high"
+	default:
+		return "unknown"
+	}
+}
+
+// ============================================================================
+//  Type: WarningCode
+// ============================================================================
+
+// WarningCode is the stable identifier of the rule of a Warning. Match on it
+// rather than on the message, which may be reworded.
+type WarningCode string
+
+const (
+	// WarnMemoryBelowOWASP is the memory cost and iterations below all the
+	// minimum configurations of the OWASP Password Storage Cheat Sheet.
+	WarnMemoryBelowOWASP WarningCode = "memory-below-owasp"
+	// WarnSinglePassSmallMemory is a single iteration with less memory than
+	// PresetRFC9106Second.
+	WarnSinglePassSmallMemory WarningCode = "single-pass-small-memory"
+	// WarnParallelismHigh is the parallelism exceeding the typical core counts.
+	WarnParallelismHigh WarningCode = "parallelism-high"
+	// WarnKeyLengthShort is the key length under 32 bytes.
+	WarnKeyLengthShort WarningCode = "key-length-short"
+	// WarnSaltLengthShort is the salt length under 16 bytes.
+	WarnSaltLengthShort WarningCode = "salt-length-short"
+)
+
+// ============================================================================
+//  Type: Warning
+// ============================================================================
+
+// Warning is an advisory of WarnIfWeak() on a legal but unwise parameter.
+type Warning struct {
+	// Code identifies the rule.
+	Code WarningCode
+	// Message is the human readable explanation.
+	Message string
+	// Severity is the severity of the advisory.
+	Severity Severity
+}
+
+// String returns the warning in the form of "[severity] code: message".
+func (w Warning) String() string {
+	return fmt.Sprintf("[%s] %s: %s", w.Severity, w.Code, w.Message)
+}
+
+// ============================================================================
+//  Functions
+// ============================================================================
+
+// WarnIfWeak returns the advisories on the parameters that pass Validate() but
+// are weaker than recommended. Validate() answers "is this legal" and it
+// answers "is this wise". It returns nil if there is nothing to advise, such
+// as for the presets of RFC 9106, or if p is nil.
+//
+// The rules are:
+//
+//   - WarnMemoryBelowOWASP: the memory cost and iterations are below all the
+//     OWASP minimums, such as m=19456 (19 MiB) with t=2 (SeverityHigh).
+//   - WarnSinglePassSmallMemory: t=1 with less than 64 MiB (SeverityMedium).
+//   - WarnParallelismHigh: more than 16 lanes (SeverityLow).
+//   - WarnKeyLengthShort: a key shorter than 32 bytes (SeverityMedium).
+//   - WarnSaltLengthShort: a salt shorter than 16 bytes (SeverityMedium).
+func WarnIfWeak(p *Params) []Warning {
+	if p == nil {
+		return nil
+	}
+
+	var warnings []Warning
+
+	add := func(code WarningCode, severity Severity, format string, args ...any) {
+		warnings = append(warnings, Warning{
+			Code:     code,
+			Message:  fmt.Sprintf(format, args...),
+			Severity: severity,
+		})
+	}
+
+	if !p.meetsOWASPMinimum() {
+		add(WarnMemoryBelowOWASP, SeverityHigh,
+			"memory %s with %d iterations is below the OWASP minimums, such as 19MiB with 2 iterations",
+			FormatMemory(p.MemoryCost), p.Iterations)
+	}
+
+	if p.Iterations == 1 && p.MemoryCost < warnMemorySinglePass {
+		add(WarnSinglePassSmallMemory, SeverityMedium,
+			"a single iteration with memory %s is weak, use at least %s or more iterations",
+			FormatMemory(p.MemoryCost), FormatMemory(warnMemorySinglePass))
+	}
+
+	if p.Parallelism > warnParallelismMax {
+		add(WarnParallelismHigh, SeverityLow,
+			"parallelism %d exceeds the typical core counts of %d, which adds no strength",
+			p.Parallelism, warnParallelismMax)
+	}
+
+	if p.KeyLength < warnKeyLengthMin {
+		add(WarnKeyLengthShort, SeverityMedium,
+			"key length %d bytes is shorter than %d bytes", p.KeyLength, warnKeyLengthMin)
+	}
+
+	if p.SaltLength < warnSaltLengthMin {
+		add(WarnSaltLengthShort, SeverityMedium,
+			"salt length %d bytes is shorter than %d bytes", p.SaltLength, warnSaltLengthMin)
+	}
+
+	return warnings
+}
@@ -0,0 +1,136 @@
+package argonize_test
+
+import (
+	"testing"
+
+	"github.com/KEINOS/go-argonize"
+	"github.com/stretchr/testify/require"
+)
+
+// ----------------------------------------------------------------------------
+//  WarnIfWeak()
+// ----------------------------------------------------------------------------
+
+func TestWarnIfWeak(t *testing.T) {
+	t.Parallel()
+
+	// strong returns the params without warnings modified by fn.
+	strong := func(fn func(p *argonize.Params)) *argonize.Params {
+		params := argonize.PresetRFC9106Second.Params()
+		fn(params)
+
+		return params
+	}
+
+	for _, test := range []struct {
+		params *argonize.Params
+		name   string
+		codes  []argonize.WarningCode
+	}{
+		{name: "nil", params: nil},
+		{name: "RFC 9106 first", params: argonize.PresetRFC9106First.Params()},
+		{name: "RFC 9106 second", params: argonize.PresetRFC9106Second.Params()},
+		{name: "package defaults", params: argonize.NewParams()},
+		{
+			name:   "OWASP minimum m=19MiB,t=2",
+			params: strong(func(p *argonize.Params) { p.MemoryCost, p.Iterations = 19456, 2 }),
+		},
+		{
+			name:   "below OWASP minimum",
+			params: strong(func(p *argonize.Params) { p.MemoryCost, p.Iterations = 19455, 2 }),
+			codes:  []argonize.WarningCode{argonize.WarnMemoryBelowOWASP},
+		},
+		{
+			name:   "single pass with OWASP minimum memory",
+			params: strong(func(p *argonize.Params) { p.MemoryCost, p.Iterations = 47104, 1 }),
+			codes:  []argonize.WarningCode{argonize.WarnSinglePassSmallMemory},
+		},
+		{
+			name:   "single pass with small memory",
+			params: strong(func(p *argonize.Params) { p.MemoryCost, p.Iterations = 8192, 1 }),
+			codes:  []argonize.WarningCode{argonize.WarnMemoryBelowOWASP, argonize.WarnSinglePassSmallMemory},
+		},
+		{
+			name:   "high parallelism",
+			params: strong(func(p *argonize.Params) { p.Parallelism = 17 }),
+			codes:  []argonize.WarningCode{argonize.WarnParallelismHigh},
+		},
+		{
+			name:   "parallelism of 16",
+			params: strong(func(p *argonize.Params) { p.Parallelism = 16 }),
+		},
+		{
+			name:   "short key",
+			params: strong(func(p *argonize.Params) { p.KeyLength = 31 }),
+			codes:  []argonize.WarningCode{argonize.WarnKeyLengthShort},
+		},
+		{
+			name:   "short salt",
+			params: strong(func(p *argonize.Params) { p.SaltLength = 15 }),
+			codes:  []argonize.WarningCode{argonize.WarnSaltLengthShort},
+		},
+		{
+			name: "everything",
+			params: &argonize.Params{
+				MemoryCost: 64 * 32, Iterations: 1, Parallelism: 32, KeyLength: 16, SaltLength: 8,
+			},
+			codes: []argonize.WarningCode{
+				argonize.WarnMemoryBelowOWASP,
+				argonize.WarnSinglePassSmallMemory,
+				argonize.WarnParallelismHigh,
+				argonize.WarnKeyLengthShort,
+				argonize.WarnSaltLengthShort,
+			},
+		},
+	} {
+		if test.params != nil {
+			require.NoError(t, test.params.Validate(), "the params should be legal: %s", test.name)
+		}
+
+		warnings := argonize.WarnIfWeak(test.params)
+
+		codes := make([]argonize.WarningCode, 0, len(warnings))
+		for _, warning := range warnings {
+			require.NotEmpty(t, warning.Message, test.name)
+			require.NotEqual(t, "unknown", warning.Severity.String(), test.name)
+
+			codes = append(codes, warning.Code)
+		}
+
+		if len(test.codes) == 0 {
+			require.Empty(t, warnings, test.name)
+
+			continue
+		}
+
+		require.Equal(t, test.codes, codes, test.name)
+	}
+}
+
+func TestWarning_String(t *testing.T) {
+	t.Parallel()
+
+	params := argonize.PresetRFC9106Second.Params()
+	params.MemoryCost, params.Iterations = 8192, 2
+
+	warnings := argonize.WarnIfWeak(params)
+	require.Len(t, warnings, 1)
+	require.Equal(t, argonize.SeverityHigh, warnings[0].Severity)
+	require.Equal(t,
+		"[high] memory-below-owasp: memory 8MiB with 2 iterations is below the OWASP minimums, "+
+			"such as 19MiB with 2 iterations",
+		warnings[0].String())
+}
+
+// ----------------------------------------------------------------------------
+//  Severity.String()
+// ----------------------------------------------------------------------------
+
+func TestSeverity_String(t *testing.T) {
+	t.Parallel()
+
+	require.Equal(t, "low", argonize.SeverityLow.String())
+	require.Equal(t, "medium", argonize.SeverityMedium.String())
+	require.Equal(t, "high", argonize.SeverityHigh.String())
+	require.Equal(t, "unknown", argonize.Severity(0).String())
+}
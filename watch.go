@@ -0,0 +1,239 @@
+package argonize
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// WatchIntervalDefault is the default polling interval of PollNotifier.
+const WatchIntervalDefault = 5 * time.Second
+
+// ============================================================================
+//  Type: FileNotifier
+// ============================================================================
+
+// FileNotifier notifies WatchParamsFileWith() that a file may have changed.
+// PollNotifier is the default. Implement it with a package such as fsnotify to
+// be notified without polling.
+type FileNotifier interface {
+	// Notify returns a channel receiving a value whenever the file of the path
+	// may have changed. The channel must be closed when ctx is done.
+	Notify(ctx context.Context, path string) (<-chan struct{}, error)
+}
+
+// ============================================================================
+//  Type: PollNotifier
+// ============================================================================
+
+// PollNotifier is the FileNotifier polling the modification time and the size
+// of the file at the interval.
+type PollNotifier struct {
+	// Interval is the polling interval. Zero or a negative value means
+	// WatchIntervalDefault.
+	Interval time.Duration
+}
+
+// ----------------------------------------------------------------------------
+//  Methods of PollNotifier
+// ----------------------------------------------------------------------------
+
+// Notify implements FileNotifier. A missing file is not an error, so that the
+// file can be created or replaced later.
+func (n PollNotifier) Notify(ctx context.Context, path string) (<-chan struct{}, error) {
+	interval := n.Interval
+	if interval <= 0 {
+		interval = WatchIntervalDefault
+	}
+
+	type signature struct {
+		modTime time.Time
+		size    int64
+	}
+
+	stat := func() signature {
+		info, err := os.Stat(path)
+		if err != nil {
+			return signature{}
+		}
+
+		return signature{modTime: info.ModTime(), size: info.Size()}
+	}
+
+	last := stat()
+	changes := make(chan struct{}, 1)
+
+	go func() {
+		defer close(changes)
+
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+			}
+
+			current := stat()
+			if current == last {
+				continue
+			}
+
+			last = current
+
+			select {
+			case changes <- struct{}{}:
+			default: // a notification is already pending
+			}
+		}
+	}()
+
+	return changes, nil
+}
+
+// ============================================================================
+//  Type: WatchOptions
+// ============================================================================
+
+// WatchOptions configures WatchParamsFileWith().
+type WatchOptions struct {
+	// Notifier notifies the changes of the file. If nil, a PollNotifier with
+	// the default interval is used.
+	Notifier FileNotifier
+	// Decode decodes the content of the file into Params. If nil, the content
+	// is decoded as a JSON object with the same keys as Params.UnmarshalYAML().
+	// Set it to decode the other formats, such as YAML:
+	//
+	//	Decode: func(data []byte) (*argonize.Params, error) {
+	//	    params := new(argonize.Params)
+	//	    return params, yaml.Unmarshal(data, params)
+	//	},
+	Decode func(data []byte) (*Params, error)
+	// Warn is called with the advisories of WarnIfWeak() on the installed
+	// parameters, if any, such as to log them. If nil, they are not reported.
+	Warn func(params *Params, warnings []Warning)
+}
+
+// ============================================================================
+//  Functions
+// ============================================================================
+
+// WatchParamsFile is similar to WatchParamsFileWith() with the default options,
+// which polls the JSON file every WatchIntervalDefault.
+func WatchParamsFile(ctx context.Context, path string, onChange func(*Params, error)) {
+	WatchParamsFileWith(ctx, path, onChange, WatchOptions{})
+}
+
+// WatchParamsFileWith loads the parameters from the file and installs them as
+// the package-wide defaults with SetDefaultParams(), then reloads them whenever
+// the file changes until ctx is done. It returns immediately and watches in the
+// background. It allows raising the cost fleet-wide without a deploy.
+//
+// The onChange is called with a copy of the installed parameters after each
+// load, or with nil and the error if the file could not be read, decoded or
+// validated. On error, the previous defaults are kept. It may be nil.
+//
+// Only the new hashes with the defaults pick up the new parameters, such as the
+// ones of Hash() and NewHasher(nil) created afterwards. The existing hashes are
+// verified with their own parameters as before, and the Hasher objects keep the
+// parameters they were created with.
+func WatchParamsFileWith(ctx context.Context, path string, onChange func(*Params, error), opts WatchOptions) {
+	if onChange == nil {
+		onChange = func(*Params, error) {}
+	}
+
+	notifier := opts.Notifier
+	if notifier == nil {
+		notifier = PollNotifier{}
+	}
+
+	decode := opts.Decode
+	if decode == nil {
+		decode = decodeParamsJSON
+	}
+
+	reload := func() {
+		params, err := loadParamsFile(path, decode)
+		if err == nil {
+			err = SetDefaultParams(params)
+		}
+
+		if err != nil {
+			onChange(nil, errors.Wrapf(err, "failed to reload params from %q", path))
+
+			return
+		}
+
+		if warnings := WarnIfWeak(params); len(warnings) > 0 && opts.Warn != nil {
+			opts.Warn(DefaultParams(), warnings)
+		}
+
+		onChange(DefaultParams(), nil)
+	}
+
+	// Start watching before the first load not to miss a change in between.
+	changes, err := notifier.Notify(ctx, path)
+	if err != nil {
+		onChange(nil, errors.Wrapf(err, "failed to watch %q", path))
+
+		return
+	}
+
+	reload()
+
+	go func() {
+		for range changes {
+			if ctx.Err() != nil {
+				return
+			}
+
+			reload()
+		}
+	}()
+}
+
+// ----------------------------------------------------------------------------
+//  Private Functions
+// ----------------------------------------------------------------------------
+
+// decodeParamsJSON decodes the JSON object of the parameters. The "memory"
+// accepts both a number in KiB and a size string such as "64MiB".
+func decodeParamsJSON(data []byte) (*Params, error) {
+	var mapping map[string]any
+
+	if err := json.Unmarshal(data, &mapping); err != nil {
+		return nil, errors.Wrap(err, "failed to unmarshal params")
+	}
+
+	params := new(Params)
+
+	if err := params.setFromMap(mapping); err != nil {
+		return nil, err
+	}
+
+	return params, nil
+}
+
+// loadParamsFile reads and decodes the parameter file.
+func loadParamsFile(path string, decode func([]byte) (*Params, error)) (*Params, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to read the file")
+	}
+
+	params, err := decode(data)
+	if err == nil && params == nil {
+		err = errors.New("decoded params are nil")
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	return params, nil
+}
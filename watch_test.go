@@ -0,0 +1,166 @@
+package argonize_test
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/KEINOS/go-argonize"
+	"github.com/stretchr/testify/require"
+)
+
+// manualNotifier is a FileNotifier notifying the changes on demand.
+type manualNotifier chan struct{}
+
+func (n manualNotifier) Notify(context.Context, string) (<-chan struct{}, error) {
+	return n, nil
+}
+
+// watchResult is the arguments of the onChange callback.
+type watchResult struct {
+	params *argonize.Params
+	err    error
+}
+
+// watchParamsFile starts watching the path and returns the channel of the
+// onChange calls. The defaults are restored at the end of the test.
+func watchParamsFile(t *testing.T, path string, opts argonize.WatchOptions) <-chan watchResult {
+	t.Helper()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	results := make(chan watchResult, 16)
+
+	t.Cleanup(func() {
+		cancel()
+		require.NoError(t, argonize.SetDefaultParams(nil))
+	})
+
+	argonize.WatchParamsFileWith(ctx, path, func(params *argonize.Params, err error) {
+		results <- watchResult{params: params, err: err}
+	}, opts)
+
+	return results
+}
+
+// nextResult returns the next onChange call or fails after a timeout.
+func nextResult(t *testing.T, results <-chan watchResult) watchResult {
+	t.Helper()
+
+	select {
+	case result := <-results:
+		return result
+	case <-time.After(5 * time.Second):
+		require.FailNow(t, "onChange was not called")
+	}
+
+	return watchResult{}
+}
+
+// ----------------------------------------------------------------------------
+//  WatchParamsFileWith()
+// ----------------------------------------------------------------------------
+
+//nolint:paralleltest // disable parallel since it changes the package-wide defaults
+func TestWatchParamsFileWith(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "argon2.json")
+	require.NoError(t, os.WriteFile(path, []byte(`{"memory": "64KiB", "iterations": 2, "parallelism": 1}`), 0o600))
+
+	notifier := make(manualNotifier)
+	results := watchParamsFile(t, path, argonize.WatchOptions{Notifier: notifier})
+
+	// Initial load
+	result := nextResult(t, results)
+	require.NoError(t, result.err)
+	require.Equal(t, "m=64,t=2,p=1", result.params.EncodeParams())
+	require.Equal(t, uint32(64), argonize.NewParams().MemoryCost)
+
+	// Stronger settings should be picked up by new hashes
+	require.NoError(t, os.WriteFile(path, []byte(`{"memory": 128, "iterations": 3, "parallelism": 1}`), 0o600))
+	notifier <- struct{}{}
+
+	result = nextResult(t, results)
+	require.NoError(t, result.err)
+	require.Equal(t, "m=128,t=3,p=1", result.params.EncodeParams())
+
+	hashed, err := argonize.Hash([]byte("password"))
+	require.NoError(t, err)
+	require.Equal(t, "m=128,t=3,p=1", hashed.Params.EncodeParams())
+
+	// Invalid updates should be rejected and keep the old params
+	for _, content := range []string{
+		`{"memory": "1KiB"}`,
+		`{"memory": 128, "unknown": 1}`,
+		`{"memory": 128, "variant": "argon2i"}`,
+		`not json`,
+	} {
+		require.NoError(t, os.WriteFile(path, []byte(content), 0o600))
+		notifier <- struct{}{}
+
+		result = nextResult(t, results)
+		require.ErrorContains(t, result.err, "failed to reload params from", "content: %s", content)
+		require.Nil(t, result.params)
+		require.Equal(t, "m=128,t=3,p=1", argonize.DefaultParams().EncodeParams(), "content: %s", content)
+	}
+
+	// A removed file should be reported as well
+	require.NoError(t, os.Remove(path))
+	notifier <- struct{}{}
+
+	result = nextResult(t, results)
+	require.ErrorIs(t, result.err, os.ErrNotExist)
+	require.Equal(t, "m=128,t=3,p=1", argonize.DefaultParams().EncodeParams())
+
+	close(notifier)
+}
+
+//nolint:paralleltest // disable parallel since it changes the package-wide defaults
+func TestWatchParamsFileWith_decode_and_poll(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "argon2.conf")
+	require.NoError(t, os.WriteFile(path, []byte("m=64,t=1,p=1\n"), 0o600))
+
+	warned := make(chan []argonize.Warning, 2)
+
+	results := watchParamsFile(t, path, argonize.WatchOptions{
+		Notifier: argonize.PollNotifier{Interval: 10 * time.Millisecond},
+		Warn: func(_ *argonize.Params, warnings []argonize.Warning) {
+			warned <- warnings
+		},
+		Decode: func(data []byte) (*argonize.Params, error) {
+			return argonize.ParseParamString(strings.TrimSpace(string(data)))
+		},
+	})
+
+	result := nextResult(t, results)
+	require.NoError(t, result.err)
+	require.Equal(t, "m=64,t=1,p=1", result.params.EncodeParams())
+	require.Equal(t, argonize.WarnMemoryBelowOWASP, (<-warned)[0].Code, "weak params should be warned")
+
+	require.NoError(t, os.WriteFile(path, []byte("m=256,t=4,p=1\n"), 0o600))
+	// Make sure the modification time changes on the coarse file systems
+	later := time.Now().Add(time.Minute)
+	require.NoError(t, os.Chtimes(path, later, later))
+
+	result = nextResult(t, results)
+	require.NoError(t, result.err)
+	require.Equal(t, "m=256,t=4,p=1", result.params.EncodeParams())
+}
+
+//nolint:paralleltest // disable parallel since it changes the package-wide defaults
+func TestWatchParamsFile_missing_file(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	results := make(chan watchResult, 1)
+
+	argonize.WatchParamsFile(ctx, filepath.Join(t.TempDir(), "missing.json"), func(params *argonize.Params, err error) {
+		results <- watchResult{params: params, err: err}
+	})
+
+	result := nextResult(t, results)
+	require.ErrorIs(t, result.err, os.ErrNotExist)
+	require.Nil(t, result.params)
+	require.Equal(t, argonize.MemoryCostDefault, argonize.DefaultParams().MemoryCost)
+}
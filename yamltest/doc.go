@@ -0,0 +1,9 @@
+/*
+Package yamltest holds the tests of the YAML support of argonize.Params with
+"gopkg.in/yaml.v3", such as Params.UnmarshalYAML() and the YAML decoder of
+argonize.WatchParamsFileWith().
+
+The core argonize package implements the yaml.Unmarshaler interface without
+depending on the YAML package. It is a separate module to keep it that way.
+*/
+package yamltest
@@ -0,0 +1,19 @@
+module github.com/KEINOS/go-argonize/yamltest
+
+go 1.22
+
+replace github.com/KEINOS/go-argonize => ../
+
+require (
+	github.com/KEINOS/go-argonize v0.0.0
+	github.com/stretchr/testify v1.10.0
+	gopkg.in/yaml.v3 v3.0.1
+)
+
+require (
+	github.com/davecgh/go-spew v1.1.1 // indirect
+	github.com/pkg/errors v0.9.1 // indirect
+	github.com/pmezard/go-difflib v1.0.0 // indirect
+	golang.org/x/crypto v0.32.0 // indirect
+	golang.org/x/sys v0.29.0 // indirect
+)
@@ -0,0 +1,159 @@
+package yamltest_test
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/KEINOS/go-argonize"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/yaml.v3"
+)
+
+// ----------------------------------------------------------------------------
+//  Params.UnmarshalYAML()
+// ----------------------------------------------------------------------------
+
+func TestParams_UnmarshalYAML(t *testing.T) {
+	t.Parallel()
+
+	for _, test := range []struct {
+		input  string
+		expect argonize.Params
+	}{
+		{
+			"argon2:\n  memory: 64MiB\n  iterations: 3\n  parallelism: 4\n",
+			argonize.Params{MemoryCost: 65536, Iterations: 3, Parallelism: 4, KeyLength: 32, SaltLength: 16},
+		},
+		{
+			"argon2:\n  memory: 131072\n",
+			argonize.Params{MemoryCost: 131072, Iterations: 1, Parallelism: 2, KeyLength: 32, SaltLength: 16},
+		},
+		{
+			"argon2: {}\n",
+			*argonize.NewParams(),
+		},
+		{
+			"argon2:\n  variant: argon2i\n  key_length: 64\n  salt_length: 32\n",
+			argonize.Params{
+				Variant: argonize.VariantArgon2i, MemoryCost: 65536, Iterations: 1, Parallelism: 2,
+				KeyLength: 64, SaltLength: 32,
+			},
+		},
+	} {
+		var config struct {
+			Argon2 argonize.Params `yaml:"argon2"`
+		}
+
+		require.NoError(t, yaml.Unmarshal([]byte(test.input), &config), test.input)
+		require.Equal(t, test.expect, config.Argon2, test.input)
+	}
+}
+
+func TestParams_UnmarshalYAML_invalid(t *testing.T) {
+	t.Parallel()
+
+	for input, msgContain := range map[string]string{
+		"memory: 64MB\n":              `bad value of "memory": invalid memory size`,
+		"memory: -1\n":                `bad value of "memory": negative value`,
+		"parallelism: 256\n":          `bad value of "parallelism": value 256 overflows`,
+		"iterations: 1.5\n":           `bad value of "iterations": not an unsigned integer`,
+		"iterations: three\n":         `bad value of "iterations": want a number, got string`,
+		"variant: argon2d\n":          `unsupported algorithm variant "argon2d"`,
+		"variant: 1\n":                `bad value of "variant": want a string, got int`,
+		"threads: 4\n":                `bad value of "threads": unknown key`,
+		"salt_length: 4\n":            "salt length must be at least 8",
+		"- memory\n":                  "failed to unmarshal params",
+		"memory: 8\nparallelism: 2\n": "memory cost must be at least 8 KiB per lane",
+	} {
+		var params argonize.Params
+
+		err := yaml.Unmarshal([]byte(input), &params)
+
+		require.Error(t, err, input)
+		require.Contains(t, err.Error(), msgContain, input)
+	}
+}
+
+func TestParams_yaml_round_trip(t *testing.T) {
+	t.Parallel()
+
+	params := argonize.PresetRFC9106First.Params()
+
+	out, err := yaml.Marshal(params)
+	require.NoError(t, err)
+	require.Contains(t, string(out), "memory: 2097152")
+
+	var restored argonize.Params
+
+	require.NoError(t, yaml.Unmarshal(out, &restored))
+	require.Equal(t, params, &restored)
+}
+
+// ----------------------------------------------------------------------------
+//  Params.UnmarshalTOML()
+// ----------------------------------------------------------------------------
+
+func TestParams_UnmarshalTOML(t *testing.T) {
+	t.Parallel()
+
+	var params argonize.Params
+
+	// Values as decoded by the "github.com/BurntSushi/toml" package.
+	err := params.UnmarshalTOML(map[string]any{
+		"memory":      "256MiB",
+		"iterations":  int64(2),
+		"parallelism": int64(4),
+	})
+
+	require.NoError(t, err)
+	require.Equal(t, uint32(256*1024), params.MemoryCost)
+	require.Equal(t, uint32(2), params.Iterations)
+	require.Equal(t, uint8(4), params.Parallelism)
+	require.Equal(t, argonize.SaltLengthDefault, params.SaltLength)
+
+	err = params.UnmarshalTOML("m=65536")
+
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "want a table, got string")
+}
+
+// ----------------------------------------------------------------------------
+//  WatchParamsFileWith()
+// ----------------------------------------------------------------------------
+
+//nolint:paralleltest // disable parallel since it changes the package-wide defaults
+func TestWatchParamsFileWith_yaml(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "argon2.yaml")
+	require.NoError(t, os.WriteFile(path, []byte("memory: 64KiB\niterations: 1\nparallelism: 1\n"), 0o600))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	results := make(chan *argonize.Params, 16)
+
+	t.Cleanup(func() {
+		cancel()
+		require.NoError(t, argonize.SetDefaultParams(nil))
+	})
+
+	argonize.WatchParamsFileWith(ctx, path, func(params *argonize.Params, err error) {
+		if err == nil {
+			results <- params
+		}
+	}, argonize.WatchOptions{
+		Notifier: argonize.PollNotifier{Interval: 10 * time.Millisecond},
+		Decode: func(data []byte) (*argonize.Params, error) {
+			params := new(argonize.Params)
+
+			return params, yaml.Unmarshal(data, params)
+		},
+	})
+
+	select {
+	case params := <-results:
+		require.Equal(t, "m=64,t=1,p=1", params.EncodeParams())
+	case <-time.After(5 * time.Second):
+		require.FailNow(t, "onChange was not called")
+	}
+}